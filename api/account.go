@@ -5,14 +5,18 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
 	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
 )
 
 // ? バリデーションは、https://github.com/go-playground/validatorが使われている
 
 type createAccountRequest struct {
-	Currency string `json:"currency" binding:"required,currency"`
+	Currency    string `json:"currency" binding:"required,currency"`
+	AccountType string `json:"account_type" binding:"omitempty,account_type"`
 }
 
 func (server *Server) createAccount(ctx *gin.Context) {
@@ -25,10 +29,15 @@ func (server *Server) createAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	if req.AccountType == "" {
+		req.AccountType = util.CheckingAccount
+	}
+
 	arg := db.CreateAccountParams{
-		Owner:    authPayload.Username,
-		Currency: req.Currency,
-		Balance:  0,
+		Owner:       authPayload.Username,
+		Currency:    req.Currency,
+		Balance:     0,
+		AccountType: req.AccountType,
 	}
 
 	account, err := server.store.CreateAccount(ctx, arg)
@@ -86,8 +95,13 @@ func (server *Server) getAccount(ctx *gin.Context) {
 }
 
 type listAccountsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	PageSize  int32  `form:"page_size" binding:"min=0"`
+	PageToken string `form:"page_token"`
+}
+
+type listAccountsResponse struct {
+	Accounts      []db.Account `json:"accounts"`
+	NextPageToken string       `json:"next_page_token"`
 }
 
 func (server *Server) listAccount(ctx *gin.Context) {
@@ -100,10 +114,25 @@ func (server *Server) listAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	scope := "accounts:" + authPayload.Username
+	pageSize := pagination.PageSize(req.PageSize)
+
+	// 次のページがあるかどうかを知るために1件多く取得する
 	arg := db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  int32(req.PageSize),
-		Offset: (int32(req.PageID) - 1) * int32(req.PageSize),
+		Owner: authPayload.Username,
+		Limit: pageSize + 1,
+	}
+
+	if req.PageToken != "" {
+		cursor, err := server.pageTokens.Decode(scope, req.PageToken)
+
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		arg.CursorCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
+		arg.CursorID = pgtype.Int8{Int64: cursor.ID, Valid: true}
 	}
 
 	accounts, err := server.store.ListAccounts(ctx, arg)
@@ -113,7 +142,24 @@ func (server *Server) listAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
+	rsp := listAccountsResponse{Accounts: accounts}
+
+	if len(accounts) > int(pageSize) {
+		rsp.Accounts = accounts[:pageSize]
+		last := rsp.Accounts[pageSize-1]
+
+		rsp.NextPageToken, err = server.pageTokens.Encode(scope, pagination.Cursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		})
+
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 type deleteAccountRequest struct {
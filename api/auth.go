@@ -65,13 +65,13 @@ func (server *Server) login(ctx *gin.Context) {
 	}
 
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    ctx.Request.UserAgent(),
-		ClientIp:     ctx.ClientIP(),
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiresAt.Time,
+		ID:               refreshPayload.ID,
+		Username:         user.Username,
+		RefreshTokenHash: util.HashSecret(refreshToken),
+		UserAgent:        ctx.Request.UserAgent(),
+		ClientIp:         ctx.ClientIP(),
+		IsBlocked:        false,
+		ExpiresAt:        refreshPayload.ExpiresAt.Time,
 	})
 
 	if err != nil {
@@ -15,6 +15,7 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
 		AccessTokenDuration: time.Minute,
+		PageTokenKey:        util.RandomString(32),
 	}
 
 	server, err := NewServer(config, store)
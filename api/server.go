@@ -7,15 +7,17 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
 	"github.com/shouta0715/simple-bank/token"
 	"github.com/shouta0715/simple-bank/util"
 )
 
 type Server struct {
-	store  db.Store
-	maker  token.Maker
-	router *gin.Engine
-	config util.Config
+	store      db.Store
+	maker      token.Maker
+	router     *gin.Engine
+	config     util.Config
+	pageTokens *pagination.Signer
 }
 
 // setup api server
@@ -26,9 +28,16 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		return nil, fmt.Errorf("cannnot create token maker: %w", err)
 	}
 
-	server := &Server{store: store, maker: tokenMaker, config: config}
+	pageTokens, err := pagination.NewSigner(config.PageTokenKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot create page token signer: %w", err)
+	}
+
+	server := &Server{store: store, maker: tokenMaker, config: config, pageTokens: pageTokens}
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("account_type", validAccountType)
 	}
 
 	server.setupRouter()
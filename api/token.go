@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
 )
 
 type renewAccessTokenRequest struct {
@@ -57,7 +58,7 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	if session.RefreshToken != req.RefreshToken {
+	if !util.CheckSecret(req.RefreshToken, session.RefreshTokenHash) {
 		err := fmt.Errorf("miss match refresh token")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
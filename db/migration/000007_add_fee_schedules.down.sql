@@ -0,0 +1,16 @@
+DELETE FROM "entries"
+WHERE "account_id" IN (
+    SELECT "id"
+    FROM "accounts"
+    WHERE "owner" = 'system_fees'
+  );
+
+DELETE FROM "accounts"
+WHERE "owner" = 'system_fees';
+
+DELETE FROM "users"
+WHERE "username" = 'system_fees';
+
+ALTER TABLE "transfers" DROP COLUMN "fee";
+
+DROP TABLE IF EXISTS "fee_schedules";
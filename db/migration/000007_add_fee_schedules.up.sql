@@ -0,0 +1,40 @@
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "role" varchar NOT NULL,
+  "account_type" varchar NOT NULL DEFAULT 'checking',
+  "currency" varchar NOT NULL,
+  "flat_fee" bigint NOT NULL DEFAULT 0,
+  "percent_fee_bps" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "fee_schedules" ("role", "account_type", "currency");
+
+COMMENT ON COLUMN "fee_schedules"."percent_fee_bps" IS 'percentage fee in basis points (1/100 of a percent)';
+
+ALTER TABLE "transfers"
+ADD COLUMN "fee" bigint NOT NULL DEFAULT 0;
+
+-- 手数料を受け取る銀行の口座
+INSERT INTO "users" (
+    "username",
+    "role",
+    "hashed_password",
+    "full_name",
+    "email",
+    "is_email_verified"
+  )
+VALUES (
+    'system_fees',
+    'system',
+    '',
+    'Simple Bank Fees',
+    'fees@system.simplebank',
+    true
+  );
+
+INSERT INTO "accounts" ("owner", "balance", "currency")
+VALUES ('system_fees', 0, 'USD'),
+  ('system_fees', 0, 'EUR'),
+  ('system_fees', 0, 'CAD'),
+  ('system_fees', 0, 'JPY');
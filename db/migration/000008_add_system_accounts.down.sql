@@ -0,0 +1,24 @@
+DELETE FROM "entries"
+WHERE "account_id" IN (
+    SELECT "id"
+    FROM "accounts"
+    WHERE "owner" IN ('system_vault', 'system_interest')
+  );
+
+DELETE FROM "transfers"
+WHERE "from_account_id" IN (
+    SELECT "id"
+    FROM "accounts"
+    WHERE "owner" IN ('system_vault', 'system_interest')
+  )
+  OR "to_account_id" IN (
+    SELECT "id"
+    FROM "accounts"
+    WHERE "owner" IN ('system_vault', 'system_interest')
+  );
+
+DELETE FROM "accounts"
+WHERE "owner" IN ('system_vault', 'system_interest');
+
+DELETE FROM "users"
+WHERE "username" IN ('system_vault', 'system_interest');
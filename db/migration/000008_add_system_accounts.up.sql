@@ -0,0 +1,35 @@
+-- 現金の入出金と利息の支払いに使う銀行の口座
+INSERT INTO "users" (
+    "username",
+    "role",
+    "hashed_password",
+    "full_name",
+    "email",
+    "is_email_verified"
+  )
+VALUES (
+    'system_vault',
+    'system',
+    '',
+    'Simple Bank Cash Vault',
+    'vault@system.simplebank',
+    true
+  ),
+  (
+    'system_interest',
+    'system',
+    '',
+    'Simple Bank Interest Expense',
+    'interest@system.simplebank',
+    true
+  );
+
+INSERT INTO "accounts" ("owner", "balance", "currency")
+VALUES ('system_vault', 0, 'USD'),
+  ('system_vault', 0, 'EUR'),
+  ('system_vault', 0, 'CAD'),
+  ('system_vault', 0, 'JPY'),
+  ('system_interest', 0, 'USD'),
+  ('system_interest', 0, 'EUR'),
+  ('system_interest', 0, 'CAD'),
+  ('system_interest', 0, 'JPY');
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reconciliation_reports";
+
+ALTER TABLE "entries" DROP COLUMN "transfer_id";
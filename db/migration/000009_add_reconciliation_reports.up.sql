@@ -0,0 +1,22 @@
+ALTER TABLE "entries"
+ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+CREATE TABLE "reconciliation_reports" (
+  "id" bigserial PRIMARY KEY,
+  "run_id" varchar NOT NULL,
+  "kind" varchar NOT NULL,
+  "account_id" bigint,
+  "transfer_id" bigint,
+  "expected" bigint NOT NULL,
+  "actual" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "reconciliation_reports" ("run_id");
+
+COMMENT ON COLUMN "reconciliation_reports"."kind" IS 'account_balance or transfer_imbalance';
@@ -0,0 +1,49 @@
+ALTER TABLE "accounts"
+ADD COLUMN "account_type" varchar NOT NULL DEFAULT 'checking';
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "owner_currency_key";
+
+ALTER TABLE "accounts"
+ADD CONSTRAINT "owner_currency_account_type_key" UNIQUE ("owner", "currency", "account_type");
+
+COMMENT ON COLUMN "accounts"."account_type" IS 'checking or savings';
+
+CREATE TABLE "interest_rate_plans" (
+  "id" bigserial PRIMARY KEY,
+  "account_type" varchar NOT NULL DEFAULT 'savings',
+  "currency" varchar NOT NULL,
+  "annual_rate_bps" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "interest_rate_plans" ("account_type", "currency");
+
+COMMENT ON COLUMN "interest_rate_plans"."annual_rate_bps" IS 'annual interest rate in basis points (1/100 of a percent)';
+
+CREATE TABLE "interest_accruals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "accrual_date" date NOT NULL,
+  "balance" bigint NOT NULL,
+  "annual_rate_bps" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "remainder" bigint NOT NULL,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "interest_accruals"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "interest_accruals"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE UNIQUE INDEX ON "interest_accruals" ("account_id", "accrual_date");
+
+CREATE INDEX ON "interest_accruals" ("account_id", "transfer_id");
+
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'whole units of interest accrued on this date';
+
+COMMENT ON COLUMN "interest_accruals"."remainder" IS 'fractional interest carried forward to the next accrual';
+
+COMMENT ON COLUMN "interest_accruals"."transfer_id" IS 'set when the accrual is posted to the account';
@@ -0,0 +1,19 @@
+CREATE TABLE "currencies" (
+  "code" varchar PRIMARY KEY,
+  "minor_unit" integer NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 currency code';
+
+COMMENT ON COLUMN "currencies"."minor_unit" IS 'number of decimal places of the minor unit (2 for USD cents, 0 for JPY)';
+
+INSERT INTO "currencies" ("code", "minor_unit")
+VALUES ('USD', 2),
+  ('EUR', 2),
+  ('CAD', 2),
+  ('JPY', 0);
+
+ALTER TABLE "accounts"
+ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
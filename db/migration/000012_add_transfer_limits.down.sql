@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_idx";
+
+DROP TABLE IF EXISTS "user_transfer_limits";
+
+DROP TABLE IF EXISTS "transfer_limits";
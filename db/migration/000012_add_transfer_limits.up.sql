@@ -0,0 +1,67 @@
+-- NULL の項目は上限なし
+CREATE TABLE "transfer_limits" (
+  "id" bigserial PRIMARY KEY,
+  "role" varchar NOT NULL,
+  "account_type" varchar NOT NULL DEFAULT 'checking',
+  "currency" varchar NOT NULL,
+  "per_transaction" bigint,
+  "daily_amount" bigint,
+  "monthly_amount" bigint,
+  "daily_count" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "transfer_limits" ("role", "account_type", "currency");
+
+-- 銀行員が設定するユーザーごとの上限。NULL の項目は role の上限を使う
+CREATE TABLE "user_transfer_limits" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "per_transaction" bigint,
+  "daily_amount" bigint,
+  "monthly_amount" bigint,
+  "daily_count" bigint,
+  "updated_by" varchar NOT NULL,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "user_transfer_limits"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "user_transfer_limits"
+ADD FOREIGN KEY ("updated_by") REFERENCES "users" ("username");
+
+CREATE UNIQUE INDEX ON "user_transfer_limits" ("username", "currency");
+
+-- 直近の送金を集計するためのindex
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+COMMENT ON COLUMN "transfer_limits"."daily_amount" IS 'total amount sent in the last 24 hours';
+
+COMMENT ON COLUMN "transfer_limits"."monthly_amount" IS 'total amount sent in the last 30 days';
+
+COMMENT ON COLUMN "transfer_limits"."daily_count" IS 'number of transfers sent in the last 24 hours';
+
+INSERT INTO "transfer_limits" (
+    "role",
+    "account_type",
+    "currency",
+    "per_transaction",
+    "daily_amount",
+    "monthly_amount",
+    "daily_count"
+  )
+SELECT 'depositor',
+  "account_type",
+  "code",
+  1000000,
+  2500000,
+  10000000,
+  50
+FROM "currencies"
+  CROSS JOIN (
+    VALUES ('checking'),
+      ('savings')
+  ) AS "types" ("account_type");
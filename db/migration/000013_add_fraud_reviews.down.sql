@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "sessions_username_client_ip_idx";
+
+DROP TABLE IF EXISTS "fraud_reviews";
@@ -0,0 +1,40 @@
+CREATE TABLE "fraud_reviews" (
+  "id" bigserial PRIMARY KEY,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "requested_by" varchar NOT NULL,
+  "rules" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "reviewed_by" varchar,
+  "reviewed_at" timestamptz,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "fraud_reviews"
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fraud_reviews"
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "fraud_reviews"
+ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "fraud_reviews"
+ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "fraud_reviews"
+ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "fraud_reviews"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "fraud_reviews" ("status", "id");
+
+COMMENT ON COLUMN "fraud_reviews"."rules" IS 'comma separated names of the rules that held the transfer';
+
+COMMENT ON COLUMN "fraud_reviews"."status" IS 'pending, approved or rejected';
+
+CREATE INDEX ON "sessions" ("username", "client_ip");
@@ -0,0 +1,55 @@
+CREATE TABLE "pending_operations" (
+  "id" bigserial PRIMARY KEY,
+  "kind" varchar NOT NULL,
+  "from_account_id" bigint,
+  "to_account_id" bigint,
+  "amount" bigint NOT NULL,
+  "currency" varchar NOT NULL,
+  "requested_by" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "reviewed_by" varchar,
+  "reviewed_at" timestamptz,
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "audit_logs" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "resource" varchar NOT NULL,
+  "resource_id" varchar NOT NULL,
+  "detail" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "pending_operations"
+ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "pending_operations"
+ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "pending_operations"
+ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "pending_operations"
+ADD FOREIGN KEY ("requested_by") REFERENCES "users" ("username");
+
+ALTER TABLE "pending_operations"
+ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("username");
+
+ALTER TABLE "pending_operations"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "pending_operations" ("status", "expires_at");
+
+CREATE INDEX ON "audit_logs" ("resource", "resource_id");
+
+CREATE INDEX ON "audit_logs" ("actor");
+
+COMMENT ON COLUMN "pending_operations"."kind" IS 'transfer, deposit or withdraw';
+
+COMMENT ON COLUMN "pending_operations"."status" IS 'pending, approved or rejected';
+
+COMMENT ON COLUMN "pending_operations"."expires_at" IS 'the operation can no longer be approved after this time';
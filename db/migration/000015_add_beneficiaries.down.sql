@@ -0,0 +1,3 @@
+ALTER TABLE "transfer_limits" DROP COLUMN IF EXISTS "new_beneficiary_amount";
+
+DROP TABLE IF EXISTS "beneficiaries";
@@ -0,0 +1,29 @@
+CREATE TABLE "beneficiaries" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "nickname" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "verified" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "beneficiaries"
+ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "beneficiaries"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE UNIQUE INDEX ON "beneficiaries" ("owner", "account_id");
+
+COMMENT ON COLUMN "beneficiaries"."verified" IS 'whether the owner of the target account had a verified email when it was added';
+
+-- 追加してから24時間以内の受取人への送金の合計の上限。NULL の場合は上限なし
+ALTER TABLE "transfer_limits"
+ADD COLUMN "new_beneficiary_amount" bigint;
+
+COMMENT ON COLUMN "transfer_limits"."new_beneficiary_amount" IS 'total amount sent to a beneficiary in the first 24 hours after it was added';
+
+-- 1日の上限の10分の1にする
+UPDATE "transfer_limits"
+SET "new_beneficiary_amount" = "daily_amount" / 10
+WHERE "role" = 'depositor';
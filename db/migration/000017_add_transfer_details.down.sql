@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "entries_account_id_created_at_id_idx";
+
+DROP INDEX IF EXISTS "transfers_to_tsvector_idx";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "category";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "reference";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "memo";
@@ -0,0 +1,15 @@
+ALTER TABLE "transfers"
+ADD COLUMN "memo" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers"
+ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "transfers"
+ADD COLUMN "category" varchar NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN "transfers"."reference" IS 'reference given by the sender, such as an invoice number';
+
+-- メモの全文検索に使う。言語に依存しないように simple の辞書を使う
+CREATE INDEX ON "transfers" USING GIN (to_tsvector('simple', "memo"));
+
+CREATE INDEX ON "entries" ("account_id", "created_at", "id");
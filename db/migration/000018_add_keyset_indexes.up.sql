@@ -0,0 +1,6 @@
+-- キーセットページネーションで (created_at, id) の順に読むための索引
+CREATE INDEX ON "accounts" ("owner", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at", "id");
+
+CREATE INDEX ON "transfers" ("to_account_id", "created_at", "id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "notifications";
+
+DROP TABLE IF EXISTS "notification_preferences";
+
+ALTER TABLE "users" DROP COLUMN "phone_number";
@@ -0,0 +1,36 @@
+ALTER TABLE "users"
+ADD COLUMN "phone_number" varchar NOT NULL DEFAULT '';
+
+CREATE TABLE "notification_preferences" (
+  "username" varchar NOT NULL,
+  "event" varchar NOT NULL,
+  "email" boolean NOT NULL DEFAULT true,
+  "sms" boolean NOT NULL DEFAULT false,
+  "in_app" boolean NOT NULL DEFAULT true,
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "event")
+);
+
+CREATE TABLE "notifications" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "event" varchar NOT NULL,
+  "subject" varchar NOT NULL,
+  "content" varchar NOT NULL,
+  "read_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "notification_preferences"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+ALTER TABLE "notifications"
+ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "notifications" ("username", "created_at", "id");
+
+COMMENT ON COLUMN "users"."phone_number" IS 'E.164 number used for SMS notifications';
+
+COMMENT ON COLUMN "notification_preferences"."event" IS 'transfer_received, low_balance or new_login';
+
+COMMENT ON COLUMN "notifications"."read_at" IS 'NULL until the user marks the notification as read';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "alert_events";
+
+DROP TABLE IF EXISTS "alert_rules";
+
+COMMENT ON COLUMN "notification_preferences"."event" IS 'transfer_received, low_balance or new_login';
@@ -0,0 +1,49 @@
+CREATE TABLE "alert_rules" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "account_id" bigint NOT NULL,
+  "kind" varchar NOT NULL,
+  "threshold" bigint NOT NULL,
+  "triggered" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "alert_events" (
+  "id" bigserial PRIMARY KEY,
+  "rule_id" bigint NOT NULL,
+  "transfer_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "delivered_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "alert_rules"
+ADD FOREIGN KEY ("owner") REFERENCES "users" ("username");
+
+ALTER TABLE "alert_rules"
+ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "alert_events"
+ADD FOREIGN KEY ("rule_id") REFERENCES "alert_rules" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "alert_events"
+ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE UNIQUE INDEX ON "alert_rules" ("account_id", "kind");
+
+CREATE INDEX ON "alert_rules" ("owner");
+
+-- 同じ送金で同じルールの通知を2回送らない
+CREATE UNIQUE INDEX ON "alert_events" ("rule_id", "transfer_id");
+
+COMMENT ON COLUMN "alert_rules"."kind" IS 'low_balance or large_incoming_transfer';
+
+COMMENT ON COLUMN "alert_rules"."triggered" IS 'true while the balance stays below the threshold of a low_balance rule';
+
+COMMENT ON COLUMN "alert_events"."amount" IS 'balance when the rule was evaluated for low_balance, amount received for large_incoming_transfer';
+
+-- 残高の通知は口座ごとの alert_rules で設定する
+DELETE FROM "notification_preferences"
+WHERE "event" = 'low_balance';
+
+COMMENT ON COLUMN "notification_preferences"."event" IS 'transfer_received or new_login';
@@ -0,0 +1,4 @@
+ALTER TABLE "users"
+ADD COLUMN "locale" varchar NOT NULL DEFAULT 'en';
+
+COMMENT ON COLUMN "users"."locale" IS 'language of the emails sent to the user, en or ja';
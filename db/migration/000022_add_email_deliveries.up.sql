@@ -0,0 +1,26 @@
+CREATE TABLE "email_deliveries" (
+  "id" bigserial PRIMARY KEY,
+  "template" varchar NOT NULL DEFAULT '',
+  "recipient" varchar NOT NULL,
+  "cc" varchar NOT NULL DEFAULT '',
+  "bcc" varchar NOT NULL DEFAULT '',
+  "subject" varchar NOT NULL,
+  "content_html" varchar NOT NULL,
+  "content_text" varchar NOT NULL,
+  "message_id" varchar NOT NULL DEFAULT '',
+  "status" varchar NOT NULL,
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "email_deliveries" ("created_at", "id");
+
+CREATE INDEX ON "email_deliveries" ("message_id");
+
+COMMENT ON COLUMN "email_deliveries"."template" IS 'name of the email template, empty when the email was not rendered from a template';
+
+COMMENT ON COLUMN "email_deliveries"."recipient" IS 'comma separated addresses the email was sent to';
+
+COMMENT ON COLUMN "email_deliveries"."status" IS 'sent or failed';
+
+COMMENT ON COLUMN "email_deliveries"."error" IS 'why the email could not be sent when the status is failed';
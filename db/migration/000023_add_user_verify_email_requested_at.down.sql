@@ -0,0 +1 @@
+ALTER TABLE "users" DROP COLUMN "verify_email_requested_at";
@@ -0,0 +1,4 @@
+ALTER TABLE "users"
+ADD COLUMN "verify_email_requested_at" timestamptz;
+
+COMMENT ON COLUMN "users"."verify_email_requested_at" IS 'last time the user asked to resend the verification email';
@@ -0,0 +1,14 @@
+-- ハッシュから元の値には戻せないので、今あるセッションと確認コードは使えなくする
+ALTER TABLE "verify_emails" DROP COLUMN "attempts";
+
+UPDATE "verify_emails"
+SET "is_used" = TRUE;
+
+ALTER TABLE "verify_emails"
+  RENAME COLUMN "secret_code_hash" TO "secret_code";
+
+UPDATE "sessions"
+SET "is_blocked" = TRUE;
+
+ALTER TABLE "sessions"
+  RENAME COLUMN "refresh_token_hash" TO "refresh_token";
@@ -0,0 +1,21 @@
+-- リフレッシュトークンと確認コードは SHA-256 のハッシュだけを保存する。今ある行もハッシュに置き換える
+ALTER TABLE "sessions"
+  RENAME COLUMN "refresh_token" TO "refresh_token_hash";
+
+UPDATE "sessions"
+SET "refresh_token_hash" = encode(sha256(convert_to("refresh_token_hash", 'UTF8')), 'hex');
+
+ALTER TABLE "verify_emails"
+  RENAME COLUMN "secret_code" TO "secret_code_hash";
+
+UPDATE "verify_emails"
+SET "secret_code_hash" = encode(sha256(convert_to("secret_code_hash", 'UTF8')), 'hex');
+
+ALTER TABLE "verify_emails"
+ADD COLUMN "attempts" integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "sessions"."refresh_token_hash" IS 'hex encoded SHA-256 of the refresh token';
+
+COMMENT ON COLUMN "verify_emails"."secret_code_hash" IS 'hex encoded SHA-256 of the secret code';
+
+COMMENT ON COLUMN "verify_emails"."attempts" IS 'number of wrong secret codes tried for this email';
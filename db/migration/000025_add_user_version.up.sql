@@ -0,0 +1,4 @@
+ALTER TABLE "users"
+ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN "users"."version" IS 'incremented on every update and used as the etag for optimistic concurrency';
@@ -0,0 +1,3 @@
+ALTER TABLE "users" DROP COLUMN "deleted_at";
+
+DROP TABLE IF EXISTS "data_exports";
@@ -0,0 +1,25 @@
+CREATE TABLE "data_exports" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "archive" bytea,
+  "error" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "completed_at" timestamptz,
+  "expires_at" timestamptz
+);
+
+ALTER TABLE "data_exports" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE INDEX ON "data_exports" ("username");
+
+COMMENT ON COLUMN "data_exports"."status" IS 'pending, ready or failed';
+
+COMMENT ON COLUMN "data_exports"."archive" IS 'ZIP file of the user data, set when the status is ready';
+
+COMMENT ON COLUMN "data_exports"."expires_at" IS 'the archive cannot be downloaded after this time';
+
+ALTER TABLE "users"
+ADD COLUMN "deleted_at" timestamptz;
+
+COMMENT ON COLUMN "users"."deleted_at" IS 'when the user asked to delete the account. personal data is pseudonymized and ledger rows are kept';
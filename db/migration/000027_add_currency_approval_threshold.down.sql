@@ -0,0 +1 @@
+ALTER TABLE "currencies" DROP COLUMN "approval_threshold";
@@ -0,0 +1,7 @@
+ALTER TABLE "currencies"
+ADD COLUMN "approval_threshold" bigint NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "currencies"."approval_threshold" IS 'banker operations above this amount (in minor units) need a second banker''s approval. 0 disables approval';
+
+UPDATE "currencies" SET "approval_threshold" = 1000000 WHERE "code" IN ('USD', 'EUR', 'CAD');
+UPDATE "currencies" SET "approval_threshold" = 1500000 WHERE "code" = 'JPY';
@@ -0,0 +1,11 @@
+ALTER TABLE "pending_operations" DROP COLUMN "category";
+
+ALTER TABLE "pending_operations" DROP COLUMN "reference";
+
+ALTER TABLE "pending_operations" DROP COLUMN "memo";
+
+ALTER TABLE "fraud_reviews" DROP COLUMN "category";
+
+ALTER TABLE "fraud_reviews" DROP COLUMN "reference";
+
+ALTER TABLE "fraud_reviews" DROP COLUMN "memo";
@@ -0,0 +1,18 @@
+-- 保留や承認待ちの送金を承認したときに、依頼されたときの送金の詳細で送金する
+ALTER TABLE "fraud_reviews"
+ADD COLUMN "memo" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "fraud_reviews"
+ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "fraud_reviews"
+ADD COLUMN "category" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "pending_operations"
+ADD COLUMN "memo" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "pending_operations"
+ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "pending_operations"
+ADD COLUMN "category" varchar NOT NULL DEFAULT '';
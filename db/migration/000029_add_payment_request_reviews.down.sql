@@ -0,0 +1,3 @@
+COMMENT ON COLUMN "payment_requests"."status" IS 'pending, paid, declined, cancelled or expired';
+
+ALTER TABLE "fraud_reviews" DROP COLUMN "payment_request_id";
@@ -0,0 +1,10 @@
+-- 請求の支払いが不正検知のルールで保留された場合に、審査が終わるまで請求を held にする
+ALTER TABLE "fraud_reviews"
+ADD COLUMN "payment_request_id" bigint;
+
+ALTER TABLE "fraud_reviews"
+ADD FOREIGN KEY ("payment_request_id") REFERENCES "payment_requests" ("id");
+
+COMMENT ON COLUMN "fraud_reviews"."payment_request_id" IS 'payment request paid by the held transfer';
+
+COMMENT ON COLUMN "payment_requests"."status" IS 'pending, held, paid, declined, cancelled or expired';
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "reconciliation_reports_created_at_id_idx";
+
+DROP INDEX IF EXISTS "pending_operations_status_created_at_id_idx";
+
+DROP INDEX IF EXISTS "payment_requests_requester_created_at_id_idx";
+
+DROP INDEX IF EXISTS "payment_requests_payer_created_at_id_idx";
+
+DROP INDEX IF EXISTS "fraud_reviews_status_created_at_id_idx";
+
+DROP INDEX IF EXISTS "beneficiaries_owner_created_at_id_idx";
@@ -0,0 +1,12 @@
+-- キーセットページネーションで (created_at, id) の順に読むための索引
+CREATE INDEX ON "beneficiaries" ("owner", "created_at", "id");
+
+CREATE INDEX ON "fraud_reviews" ("status", "created_at", "id");
+
+CREATE INDEX ON "payment_requests" ("payer", "created_at", "id");
+
+CREATE INDEX ON "payment_requests" ("requester", "created_at", "id");
+
+CREATE INDEX ON "pending_operations" ("status", "created_at", "id");
+
+CREATE INDEX ON "reconciliation_reports" ("created_at", "id");
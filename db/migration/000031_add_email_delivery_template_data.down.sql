@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "email_deliveries"."content_text" IS NULL;
+
+COMMENT ON COLUMN "email_deliveries"."content_html" IS NULL;
+
+ALTER TABLE "email_deliveries" DROP COLUMN IF EXISTS "template_data";
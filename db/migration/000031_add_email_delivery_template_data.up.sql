@@ -0,0 +1,14 @@
+ALTER TABLE "email_deliveries"
+ADD COLUMN "template_data" jsonb NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN "email_deliveries"."template_data" IS 'values that are not secret and are used to render the template again when the email is resent';
+
+COMMENT ON COLUMN "email_deliveries"."content_html" IS 'secret codes and signed links are replaced before the content is recorded';
+
+COMMENT ON COLUMN "email_deliveries"."content_text" IS 'secret codes and signed links are replaced before the content is recorded';
+
+-- 確認コードと署名付きのリンクを含む本文は記録に残さない
+UPDATE "email_deliveries"
+SET "content_html" = '',
+  "content_text" = ''
+WHERE "template" IN ('verify_email', 'data_export');
@@ -0,0 +1,11 @@
+UPDATE "pending_operations"
+SET "status" = 'rejected'
+WHERE "status" = 'cancelled';
+
+UPDATE "fraud_reviews"
+SET "status" = 'rejected'
+WHERE "status" = 'cancelled';
+
+COMMENT ON COLUMN "pending_operations"."status" IS 'pending, approved or rejected';
+
+COMMENT ON COLUMN "fraud_reviews"."status" IS 'pending, approved or rejected';
@@ -0,0 +1,4 @@
+-- ユーザーを削除したときに、審査や承認を待っているものを取り消す
+COMMENT ON COLUMN "fraud_reviews"."status" IS 'pending, approved, rejected or cancelled';
+
+COMMENT ON COLUMN "pending_operations"."status" IS 'pending, approved, rejected or cancelled';
@@ -0,0 +1,5 @@
+UPDATE "pending_operations"
+SET "status" = 'pending'
+WHERE "status" = 'expired';
+
+COMMENT ON COLUMN "pending_operations"."status" IS 'pending, approved, rejected or cancelled';
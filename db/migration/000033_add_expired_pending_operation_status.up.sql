@@ -0,0 +1,7 @@
+COMMENT ON COLUMN "pending_operations"."status" IS 'pending, approved, rejected, cancelled or expired';
+
+-- 期限が切れたまま承認待ちになっている操作
+UPDATE "pending_operations"
+SET "status" = 'expired'
+WHERE "status" = 'pending'
+  AND "expires_at" <= now();
@@ -0,0 +1,5 @@
+-- タスクの順番が入れ替わっても、古い送金の残高で残高のルールの状態を戻さないようにする
+ALTER TABLE "alert_rules"
+ADD COLUMN "last_transfer_id" bigint NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "alert_rules"."last_transfer_id" IS 'latest transfer evaluated for a low_balance rule, older transfers are skipped';
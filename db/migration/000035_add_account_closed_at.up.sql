@@ -0,0 +1,11 @@
+-- 削除したユーザーの口座は残すが、お金を送ったり受け取ったりできないようにする
+ALTER TABLE "accounts"
+ADD COLUMN "closed_at" timestamptz;
+
+COMMENT ON COLUMN "accounts"."closed_at" IS 'set when the owner is deleted. closed accounts cannot send or receive money';
+
+UPDATE "accounts"
+SET "closed_at" = "users"."deleted_at"
+FROM "users"
+WHERE "users"."username" = "accounts"."owner"
+  AND "users"."deleted_at" IS NOT NULL;
@@ -1,6 +1,6 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (owner, balance, currency)
-VALUES ($1, $2, $3)
+INSERT INTO accounts (owner, balance, currency, account_type)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: GetAccount :one
@@ -9,6 +9,14 @@ FROM accounts
 WHERE id = $1
 LIMIT 1;
 
+-- name: GetAccountByOwner :one
+SELECT *
+FROM accounts
+WHERE owner = $1
+  AND currency = $2
+  AND account_type = $3
+LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT *
 FROM accounts
@@ -17,17 +25,36 @@ LIMIT 1 FOR NO KEY
 UPDATE;
 
 -- name: ListAccounts :many
+-- 口座を作成した古い順に cursor の次から返す
 SELECT *
 FROM accounts
-WHERE owner = $1
-ORDER BY id
-LIMIT $2 OFFSET $3;
+WHERE owner = sqlc.arg(owner)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) > (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT sqlc.arg(limit);
 
--- name: UpdateAccount :one
-UPDATE accounts
-SET balance = $2
-WHERE id = $1
-RETURNING *;
+-- name: ListAccountsDesc :many
+-- 口座を作成した新しい順に cursor の次から返す
+SELECT *
+FROM accounts
+WHERE owner = sqlc.arg(owner)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT sqlc.arg(limit);
 
 -- name: AddAccountBalance :one
 UPDATE accounts
@@ -37,4 +64,23 @@ RETURNING *;
 
 -- name: DeleteAccount :exec
 DELETE FROM accounts
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ListAccountsByOwner :many
+SELECT *
+FROM accounts
+WHERE owner = $1
+ORDER BY id;
+
+-- name: ListAccountsByOwnerForUpdate :many
+SELECT *
+FROM accounts
+WHERE owner = $1
+ORDER BY id FOR NO KEY
+UPDATE;
+
+-- name: CloseUserAccounts :exec
+UPDATE accounts
+SET closed_at = now()
+WHERE owner = $1
+  AND closed_at IS NULL;
@@ -0,0 +1,68 @@
+-- name: CreateAlertRule :one
+INSERT INTO alert_rules (
+    owner,
+    account_id,
+    kind,
+    threshold
+  )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAlertRule :one
+SELECT *
+FROM alert_rules
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListAlertRules :many
+SELECT *
+FROM alert_rules
+WHERE owner = $1
+ORDER BY id;
+
+-- name: DeleteAlertRule :exec
+DELETE FROM alert_rules
+WHERE id = $1;
+
+-- name: DeleteUserAlertRules :exec
+-- 記録した通知も一緒に消える
+DELETE FROM alert_rules
+WHERE owner = $1;
+
+-- name: ListAlertRulesForTransferForUpdate :many
+-- 送金元と送金先の口座のルールを、評価している間に他のタスクが変更しないようにロックする
+SELECT *
+FROM alert_rules
+WHERE account_id = sqlc.arg(from_account_id)
+  OR account_id = sqlc.arg(to_account_id)
+ORDER BY id FOR NO KEY
+UPDATE;
+
+-- name: UpdateAlertRuleEvaluation :one
+UPDATE alert_rules
+SET triggered = $2,
+  last_transfer_id = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateAlertEvent :one
+INSERT INTO alert_events (
+    rule_id,
+    transfer_id,
+    amount
+  )
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetAlertEvent :one
+SELECT *
+FROM alert_events
+WHERE rule_id = $1
+  AND transfer_id = $2
+LIMIT 1;
+
+-- name: MarkAlertEventDelivered :one
+UPDATE alert_events
+SET delivered_at = now()
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,17 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_logs (
+    actor,
+    action,
+    resource,
+    resource_id,
+    detail
+  )
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListAuditLogs :many
+SELECT *
+FROM audit_logs
+WHERE resource = $1
+  AND resource_id = $2
+ORDER BY id;
@@ -0,0 +1,101 @@
+-- name: CreateBeneficiary :one
+-- verified は送金先の口座の持ち主がメールアドレスを確認済みかどうか
+INSERT INTO beneficiaries (
+    owner,
+    nickname,
+    account_id,
+    verified
+  )
+SELECT sqlc.arg(owner),
+  sqlc.arg(nickname),
+  accounts.id,
+  users.is_email_verified
+FROM accounts
+  JOIN users ON users.username = accounts.owner
+WHERE accounts.id = sqlc.arg(account_id)
+RETURNING *;
+
+-- name: GetBeneficiary :one
+SELECT *
+FROM beneficiaries
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListBeneficiaries :many
+-- 受取人を登録した古い順に cursor の次から返す
+SELECT *
+FROM beneficiaries
+WHERE owner = sqlc.arg(owner)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) > (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: DeleteBeneficiary :exec
+DELETE FROM beneficiaries
+WHERE id = $1;
+
+-- name: DeleteUserBeneficiaries :exec
+-- 削除したユーザーが登録した受取人と、ほかのユーザーがそのユーザーの口座に付けた受取人を消す
+DELETE FROM beneficiaries
+WHERE owner = sqlc.arg(username)
+  OR account_id = ANY(sqlc.arg(account_ids)::bigint []);
+
+-- name: GetNewBeneficiaryForTransfer :one
+-- 送金元の口座の持ち主が24時間以内に追加した送金先の受取人
+SELECT beneficiaries.*
+FROM beneficiaries
+  JOIN accounts ON accounts.owner = beneficiaries.owner
+WHERE accounts.id = sqlc.arg(from_account_id)
+  AND beneficiaries.account_id = sqlc.arg(to_account_id)
+  AND beneficiaries.created_at > now() - interval '1 day'
+LIMIT 1;
+
+-- name: IsNewPayee :one
+-- 送金先が送金元の口座の持ち主にとって新しい送金先かどうか
+-- 持ち主の口座から24時間より前に送金したことがある口座と、持ち主自身の口座は新しい送金先として扱わない
+SELECT NOT EXISTS (
+    SELECT 1
+    FROM accounts AS from_accounts
+      JOIN accounts AS to_accounts ON to_accounts.owner = from_accounts.owner
+    WHERE from_accounts.id = sqlc.arg(from_account_id)
+      AND to_accounts.id = sqlc.arg(to_account_id)
+  )
+  AND NOT EXISTS (
+    SELECT 1
+    FROM transfers
+      JOIN accounts ON accounts.id = transfers.from_account_id
+    WHERE accounts.owner = (
+        SELECT owner
+        FROM accounts
+        WHERE id = sqlc.arg(from_account_id)
+      )
+      AND transfers.to_account_id = sqlc.arg(to_account_id)
+      AND transfers.created_at <= now() - interval '1 day'
+  ) AS is_new_payee;
+
+-- name: GetTransferTotalTo :one
+-- 送金元の口座の持ち主のすべての口座から、since 以降に送金先へ送った合計
+SELECT COALESCE(SUM(transfers.amount), 0)::bigint AS total
+FROM transfers
+  JOIN accounts ON accounts.id = transfers.from_account_id
+WHERE accounts.owner = (
+    SELECT owner
+    FROM accounts
+    WHERE id = sqlc.arg(from_account_id)
+  )
+  AND transfers.to_account_id = sqlc.arg(to_account_id)
+  AND transfers.created_at > sqlc.arg(since);
+
+-- name: LockOwnerForNewBeneficiaryLimit :exec
+-- 持ち主の別の口座から同時に送っても上限を超えないように、持ち主ごとにロックする
+-- 2つのキーのロックは LockAccountForTransferLimit の1つのキーのロックと重ならない
+SELECT pg_advisory_xact_lock(1, hashtext(owner))
+FROM accounts
+WHERE id = $1;
@@ -0,0 +1,30 @@
+-- name: CreateDataExport :one
+INSERT INTO data_exports (username)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetDataExport :one
+SELECT *
+FROM data_exports
+WHERE id = $1
+LIMIT 1;
+
+-- name: CompleteDataExport :one
+UPDATE data_exports
+SET status = 'ready',
+  archive = sqlc.arg(archive),
+  completed_at = now(),
+  expires_at = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: FailDataExport :exec
+UPDATE data_exports
+SET status = 'failed',
+  error = sqlc.arg(error),
+  completed_at = now()
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteUserDataExports :exec
+DELETE FROM data_exports
+WHERE username = $1;
@@ -0,0 +1,67 @@
+-- name: CreateEmailDelivery :one
+INSERT INTO email_deliveries (
+    template,
+    recipient,
+    cc,
+    bcc,
+    subject,
+    content_html,
+    content_text,
+    message_id,
+    status,
+    error,
+    template_data
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetEmailDelivery :one
+SELECT *
+FROM email_deliveries
+WHERE id = $1
+LIMIT 1;
+
+-- name: SearchEmailDeliveries :many
+-- 送ったメールを新しい順に検索する。NULL の条件は使わない
+SELECT *
+FROM email_deliveries
+WHERE (
+    sqlc.narg(recipient)::varchar IS NULL
+    OR position(
+      lower(sqlc.narg(recipient)::varchar) IN lower(recipient)
+    ) > 0
+  )
+  AND (
+    sqlc.narg(template)::varchar IS NULL
+    OR template = sqlc.narg(template)::varchar
+  )
+  AND (
+    sqlc.narg(status)::varchar IS NULL
+    OR status = sqlc.narg(status)::varchar
+  )
+  AND (
+    sqlc.narg(start_time)::timestamptz IS NULL
+    OR created_at >= sqlc.narg(start_time)::timestamptz
+  )
+  AND (
+    sqlc.narg(end_time)::timestamptz IS NULL
+    OR created_at < sqlc.narg(end_time)::timestamptz
+  )
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: DeleteUserEmailDeliveries :exec
+-- 削除したユーザーに送ったメールの記録を消す。emails は小文字にしたメールアドレス
+DELETE FROM email_deliveries
+WHERE template_data->>'username' = sqlc.arg(username)::varchar
+  OR string_to_array(lower(replace(recipient, ' ', '')), ',') && sqlc.arg(emails)::text []
+  OR string_to_array(lower(replace(cc, ' ', '')), ',') && sqlc.arg(emails)::text []
+  OR string_to_array(lower(replace(bcc, ' ', '')), ',') && sqlc.arg(emails)::text [];
@@ -1,6 +1,6 @@
 -- name: CreateEntry :one
-INSERT INTO entries (account_id, amount)
-VALUES ($1, $2)
+INSERT INTO entries (account_id, amount, transfer_id)
+VALUES ($1, $2, $3)
 RETURNING *;
 
 -- name: GetEntry :one
@@ -10,8 +10,100 @@ WHERE id = $1
 LIMIT 1;
 
 -- name: ListEntries :many
+-- 口座の entry を古い順に cursor の次から返す
 SELECT *
 FROM entries
-WHERE account_id = $1
-ORDER BY id
-LIMIT $2 OFFSET $3;
\ No newline at end of file
+WHERE account_id = sqlc.arg(account_id)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) > (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: ListEntriesDesc :many
+-- 口座の entry を新しい順に cursor の次から返す
+SELECT *
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: SearchTransactions :many
+-- ユーザーの口座の送金の entry を新しい順に検索する。NULL の条件は使わない
+-- 相手の口座は送金元の entry では送金先、送金先の entry では送金元になる
+SELECT entries.id,
+  entries.account_id,
+  entries.amount,
+  entries.transfer_id,
+  entries.created_at,
+  accounts.currency,
+  transfers.memo,
+  transfers.reference,
+  transfers.category,
+  counterparty.id AS counterparty_account_id
+FROM entries
+  JOIN accounts ON accounts.id = entries.account_id
+  JOIN transfers ON transfers.id = entries.transfer_id
+  JOIN accounts AS counterparty ON counterparty.id = CASE
+    WHEN transfers.from_account_id = entries.account_id THEN transfers.to_account_id
+    ELSE transfers.from_account_id
+  END
+WHERE accounts.owner = sqlc.arg(owner)
+  AND (
+    sqlc.narg(query)::text IS NULL
+    OR to_tsvector('simple', transfers.memo) @@ plainto_tsquery('simple', sqlc.narg(query)::text)
+  )
+  AND (
+    sqlc.narg(min_amount)::bigint IS NULL
+    OR abs(entries.amount) >= sqlc.narg(min_amount)::bigint
+  )
+  AND (
+    sqlc.narg(max_amount)::bigint IS NULL
+    OR abs(entries.amount) <= sqlc.narg(max_amount)::bigint
+  )
+  AND (
+    sqlc.narg(start_time)::timestamptz IS NULL
+    OR entries.created_at >= sqlc.narg(start_time)::timestamptz
+  )
+  AND (
+    sqlc.narg(end_time)::timestamptz IS NULL
+    OR entries.created_at < sqlc.narg(end_time)::timestamptz
+  )
+  AND (
+    sqlc.narg(counterparty)::varchar IS NULL
+    OR counterparty.owner = sqlc.narg(counterparty)::varchar
+  )
+  AND (
+    sqlc.narg(currency)::varchar IS NULL
+    OR accounts.currency = sqlc.narg(currency)::varchar
+  )
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (entries.created_at, entries.id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY entries.created_at DESC,
+  entries.id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListEntriesByOwner :many
+SELECT entries.*
+FROM entries
+  JOIN accounts ON accounts.id = entries.account_id
+WHERE accounts.owner = $1
+ORDER BY entries.id;
@@ -0,0 +1,39 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+    role,
+    account_type,
+    currency,
+    flat_fee,
+    percent_fee_bps
+  )
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetFeeSchedule :one
+SELECT *
+FROM fee_schedules
+WHERE role = $1
+  AND account_type = $2
+  AND currency = $3
+LIMIT 1;
+
+-- name: GetFeeScheduleForAccount :one
+SELECT fee_schedules.*
+FROM fee_schedules
+  JOIN accounts ON accounts.currency = fee_schedules.currency
+  JOIN users ON users.username = accounts.owner
+WHERE accounts.id = $1
+  AND fee_schedules.role = users.role
+  AND fee_schedules.account_type = accounts.account_type
+LIMIT 1;
+
+-- name: ListFeeSchedules :many
+SELECT *
+FROM fee_schedules
+ORDER BY role,
+  account_type,
+  currency;
+
+-- name: DeleteFeeSchedule :exec
+DELETE FROM fee_schedules
+WHERE id = $1;
@@ -0,0 +1,115 @@
+-- name: CountRecentNewPayees :one
+-- since 以降に初めて送金した送金先の数。出金で使うシステム口座は数えない
+SELECT COUNT(DISTINCT transfers.to_account_id)
+FROM transfers
+  JOIN accounts ON accounts.id = transfers.to_account_id
+WHERE transfers.from_account_id = sqlc.arg(from_account_id)
+  AND transfers.created_at > sqlc.arg(since)
+  AND accounts.owner NOT IN ('system_fees', 'system_vault', 'system_interest')
+  AND NOT EXISTS (
+    SELECT 1
+    FROM transfers AS previous
+    WHERE previous.from_account_id = transfers.from_account_id
+      AND previous.to_account_id = transfers.to_account_id
+      AND previous.created_at <= sqlc.arg(since)
+  );
+
+-- name: HasTransferredTo :one
+SELECT EXISTS (
+    SELECT 1
+    FROM transfers
+    WHERE from_account_id = $1
+      AND to_account_id = $2
+  );
+
+-- name: GetAverageTransferAmount :one
+-- 出金で使うシステム口座への送金は平均に含めない
+SELECT COUNT(*) AS transfer_count,
+  COALESCE(AVG(transfers.amount), 0)::bigint AS average_amount
+FROM transfers
+  JOIN accounts ON accounts.id = transfers.to_account_id
+WHERE transfers.from_account_id = sqlc.arg(from_account_id)
+  AND transfers.created_at > sqlc.arg(since)
+  AND accounts.owner NOT IN ('system_fees', 'system_vault', 'system_interest');
+
+-- name: GetRecentLoginFromNewIP :one
+-- since 以降のログインのうち、それまで使われたことのないIPからのもの
+SELECT sessions.*
+FROM sessions
+WHERE sessions.username = sqlc.arg(username)
+  AND sessions.created_at > sqlc.arg(since)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM sessions AS previous
+    WHERE previous.username = sessions.username
+      AND previous.client_ip = sessions.client_ip
+      AND previous.created_at < sessions.created_at
+  )
+ORDER BY sessions.created_at DESC
+LIMIT 1;
+
+-- name: CreateFraudReview :one
+INSERT INTO fraud_reviews (
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    requested_by,
+    rules,
+    memo,
+    reference,
+    category,
+    payment_request_id
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: GetFraudReview :one
+SELECT *
+FROM fraud_reviews
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetFraudReviewForUpdate :one
+SELECT *
+FROM fraud_reviews
+WHERE id = $1
+LIMIT 1 FOR NO KEY
+UPDATE;
+
+-- name: ListFraudReviews :many
+-- 保留した古い順に cursor の次から返す
+SELECT *
+FROM fraud_reviews
+WHERE status = sqlc.arg(status)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) > (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: UpdateFraudReviewStatus :one
+UPDATE fraud_reviews
+SET status = sqlc.arg(status),
+  reviewed_by = sqlc.arg(reviewed_by),
+  reviewed_at = now(),
+  transfer_id = sqlc.narg(transfer_id)
+WHERE id = sqlc.arg(id)
+  AND status = 'pending'
+RETURNING *;
+
+-- name: CancelUserFraudReviews :exec
+-- 削除したユーザーが送金したか、そのユーザーの口座の審査待ちの送金を取り消す
+UPDATE fraud_reviews
+SET status = 'cancelled'
+WHERE status = 'pending'
+  AND (
+    requested_by = sqlc.arg(username)
+    OR from_account_id = ANY(sqlc.arg(account_ids)::bigint [])
+    OR to_account_id = ANY(sqlc.arg(account_ids)::bigint [])
+  );
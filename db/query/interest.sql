@@ -0,0 +1,76 @@
+-- name: CreateInterestRatePlan :one
+INSERT INTO interest_rate_plans (account_type, currency, annual_rate_bps)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetInterestRatePlan :one
+SELECT *
+FROM interest_rate_plans
+WHERE account_type = $1
+  AND currency = $2
+LIMIT 1;
+
+-- name: ListInterestRatePlans :many
+SELECT *
+FROM interest_rate_plans
+ORDER BY account_type,
+  currency;
+
+-- name: ListAccountsForAccrual :many
+SELECT accounts.id,
+  accounts.balance,
+  interest_rate_plans.annual_rate_bps
+FROM accounts
+  JOIN interest_rate_plans ON interest_rate_plans.account_type = accounts.account_type
+  AND interest_rate_plans.currency = accounts.currency
+WHERE accounts.id > sqlc.arg(after_id)
+ORDER BY accounts.id
+LIMIT sqlc.arg(limit);
+
+-- name: GetLatestInterestAccrual :one
+SELECT *
+FROM interest_accruals
+WHERE account_id = $1
+  AND accrual_date < $2
+ORDER BY accrual_date DESC
+LIMIT 1;
+
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+    account_id,
+    accrual_date,
+    balance,
+    annual_rate_bps,
+    amount,
+    remainder
+  )
+VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT account_id
+FROM interest_accruals
+WHERE transfer_id IS NULL
+  AND amount > 0
+  AND accrual_date <= sqlc.arg(accrual_date)
+  AND account_id > sqlc.arg(after_id)
+ORDER BY account_id
+LIMIT sqlc.arg(limit);
+
+-- name: ListUnpostedInterestAccrualsForUpdate :many
+SELECT *
+FROM interest_accruals
+WHERE account_id = $1
+  AND transfer_id IS NULL
+  AND amount > 0
+  AND accrual_date <= $2
+ORDER BY accrual_date FOR NO KEY
+UPDATE;
+
+-- name: MarkInterestAccrualsPosted :exec
+UPDATE interest_accruals
+SET transfer_id = $1
+WHERE account_id = $2
+  AND transfer_id IS NULL
+  AND amount > 0
+  AND accrual_date <= $3;
@@ -0,0 +1,81 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+    username,
+    event,
+    subject,
+    content
+  )
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListNotifications :many
+-- 受信箱は新しい順に (created_at, id) のカーソルで読む
+SELECT *
+FROM notifications
+WHERE username = sqlc.arg(username)
+  AND (
+    NOT sqlc.arg(unread_only)::boolean
+    OR read_at IS NULL
+  )
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*)
+FROM notifications
+WHERE username = $1
+  AND read_at IS NULL;
+
+-- name: MarkNotificationsRead :many
+-- 他のユーザーの通知や既読の通知は更新しない
+UPDATE notifications
+SET read_at = now()
+WHERE username = sqlc.arg(username)
+  AND id = ANY(sqlc.arg(ids)::bigint [])
+  AND read_at IS NULL
+RETURNING *;
+
+-- name: GetNotificationPreference :one
+SELECT *
+FROM notification_preferences
+WHERE username = $1
+  AND event = $2
+LIMIT 1;
+
+-- name: ListNotificationPreferences :many
+SELECT *
+FROM notification_preferences
+WHERE username = $1
+ORDER BY event;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (
+    username,
+    event,
+    email,
+    sms,
+    in_app
+  )
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT (username, event) DO
+UPDATE
+SET email = EXCLUDED.email,
+  sms = EXCLUDED.sms,
+  in_app = EXCLUDED.in_app,
+  updated_at = now()
+RETURNING *;
+
+-- name: DeleteUserNotifications :exec
+DELETE FROM notifications
+WHERE username = $1;
+
+-- name: DeleteUserNotificationPreferences :exec
+DELETE FROM notification_preferences
+WHERE username = $1;
@@ -0,0 +1,103 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests (
+    requester,
+    payer,
+    to_account_id,
+    amount,
+    currency,
+    memo,
+    expires_at
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetPaymentRequest :one
+SELECT *
+FROM payment_requests
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPaymentRequestForUpdate :one
+SELECT *
+FROM payment_requests
+WHERE id = $1
+LIMIT 1 FOR NO KEY
+UPDATE;
+
+-- name: ListIncomingPaymentRequests :many
+-- 認証したユーザーが支払いを求められている請求を新しい順に cursor の次から返す
+SELECT *
+FROM payment_requests
+WHERE payer = sqlc.arg(payer)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListOutgoingPaymentRequests :many
+-- 認証したユーザーが送った請求を新しい順に cursor の次から返す
+SELECT *
+FROM payment_requests
+WHERE requester = sqlc.arg(requester)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: UpdatePaymentRequestStatus :one
+UPDATE payment_requests
+SET status = sqlc.arg(status),
+  transfer_id = sqlc.narg(transfer_id),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'pending'
+RETURNING *;
+
+-- name: ResolveHeldPaymentRequest :one
+-- 保留された支払いの審査の結果で、請求を支払い済みにするか支払い待ちに戻す
+UPDATE payment_requests
+SET status = sqlc.arg(status),
+  transfer_id = sqlc.narg(transfer_id),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'held'
+RETURNING *;
+
+-- name: ExpirePaymentRequests :many
+-- 期限が切れた支払い待ちの請求を期限切れにする
+UPDATE payment_requests
+SET status = 'expired',
+  updated_at = now()
+WHERE id IN (
+    SELECT id
+    FROM payment_requests
+    WHERE status = 'pending'
+      AND expires_at <= now()
+    ORDER BY id
+    LIMIT $1 FOR NO KEY
+    UPDATE SKIP LOCKED
+  )
+RETURNING *;
+
+-- name: CancelUserPaymentRequests :exec
+-- 削除したユーザーが送ったか支払いを求められている、まだ終わっていない請求を取り消す
+UPDATE payment_requests
+SET status = 'cancelled',
+  updated_at = now()
+WHERE (
+    requester = sqlc.arg(username)
+    OR payer = sqlc.arg(username)
+  )
+  AND status IN ('pending', 'held');
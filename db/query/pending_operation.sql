@@ -0,0 +1,81 @@
+-- name: CreatePendingOperation :one
+INSERT INTO pending_operations (
+    kind,
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    requested_by,
+    expires_at,
+    memo,
+    reference,
+    category
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: GetPendingOperation :one
+SELECT *
+FROM pending_operations
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetPendingOperationForUpdate :one
+SELECT *
+FROM pending_operations
+WHERE id = $1
+LIMIT 1 FOR NO KEY
+UPDATE;
+
+-- name: ListPendingOperations :many
+-- 期限が切れていない承認待ちの操作
+SELECT *
+FROM pending_operations
+WHERE status = 'pending'
+  AND expires_at > now()
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) > (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: UpdatePendingOperationStatus :one
+UPDATE pending_operations
+SET status = sqlc.arg(status),
+  reviewed_by = sqlc.arg(reviewed_by),
+  reviewed_at = now(),
+  transfer_id = sqlc.narg(transfer_id)
+WHERE id = sqlc.arg(id)
+  AND status = 'pending'
+RETURNING *;
+
+-- name: CancelUserPendingOperations :exec
+-- 削除したユーザーが申請したか、そのユーザーの口座の承認待ちの操作を取り消す
+UPDATE pending_operations
+SET status = 'cancelled'
+WHERE status = 'pending'
+  AND (
+    requested_by = sqlc.arg(username)
+    OR from_account_id = ANY(sqlc.arg(account_ids)::bigint [])
+    OR to_account_id = ANY(sqlc.arg(account_ids)::bigint [])
+  );
+
+-- name: ExpirePendingOperations :many
+-- 期限が切れた承認待ちの操作を期限切れにする
+UPDATE pending_operations
+SET status = 'expired'
+WHERE id IN (
+    SELECT id
+    FROM pending_operations
+    WHERE status = 'pending'
+      AND expires_at <= now()
+    ORDER BY id
+    LIMIT $1 FOR NO KEY
+    UPDATE SKIP LOCKED
+  )
+RETURNING *;
@@ -0,0 +1,48 @@
+-- name: ListAccountEntryTotals :many
+SELECT accounts.id,
+  accounts.balance,
+  COALESCE(SUM(entries.amount), 0)::bigint AS entries_total
+FROM accounts
+  LEFT JOIN entries ON entries.account_id = accounts.id
+WHERE accounts.id > sqlc.arg(after_id)
+GROUP BY accounts.id
+ORDER BY accounts.id
+LIMIT sqlc.arg(limit);
+
+-- name: ListTransferEntryTotals :many
+SELECT transfers.id,
+  COUNT(entries.id) AS entry_count,
+  COALESCE(SUM(entries.amount), 0)::bigint AS entries_total
+FROM transfers
+  LEFT JOIN entries ON entries.transfer_id = transfers.id
+WHERE transfers.id > sqlc.arg(after_id)
+GROUP BY transfers.id
+ORDER BY transfers.id
+LIMIT sqlc.arg(limit);
+
+-- name: CreateReconciliationReport :one
+INSERT INTO reconciliation_reports (
+    run_id,
+    kind,
+    account_id,
+    transfer_id,
+    expected,
+    actual
+  )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListReconciliationReports :many
+-- 新しい順に cursor の次から返す
+SELECT *
+FROM reconciliation_reports
+WHERE (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT sqlc.arg(limit);
@@ -2,7 +2,7 @@
 INSERT INTO sessions (
     id,
     username,
-    refresh_token,
+    refresh_token_hash,
     user_agent,
     client_ip,
     is_blocked,
@@ -15,4 +15,25 @@ RETURNING *;
 SELECT *
 FROM sessions
 WHERE id = $1
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+
+-- name: BlockUserSessions :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1
+  AND is_blocked = false
+  AND expires_at > now();
+
+-- name: ListSessionsByUsername :many
+SELECT *
+FROM sessions
+WHERE username = $1
+ORDER BY created_at;
+
+-- name: ScrubUserSessions :exec
+-- 削除したユーザーのセッションを使えなくして、接続元の情報を消す
+UPDATE sessions
+SET is_blocked = true,
+  client_ip = '',
+  user_agent = ''
+WHERE username = $1;
@@ -1,6 +1,14 @@
 -- name: CreateTransfer :one
-INSERT INTO transfers (from_account_id, to_account_id, amount)
-VALUES ($1, $2, $3)
+INSERT INTO transfers (
+    from_account_id,
+    to_account_id,
+    amount,
+    fee,
+    memo,
+    reference,
+    category
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: GetTransfer :one
@@ -10,8 +18,55 @@ WHERE id = $1
 LIMIT 1;
 
 -- name: ListTransfers :many
+-- 口座から送金した、または口座が受け取った送金を古い順に cursor の次から返す
 SELECT *
 FROM transfers
-WHERE from_account_id = $1
-  OR to_account_id = $2
-LIMIT $3 OFFSET $4;
\ No newline at end of file
+WHERE (
+    from_account_id = sqlc.arg(from_account_id)
+    OR to_account_id = sqlc.arg(to_account_id)
+  )
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) > (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: ListTransfersDesc :many
+-- 口座から送金した、または口座が受け取った送金を新しい順に cursor の次から返す
+SELECT *
+FROM transfers
+WHERE (
+    from_account_id = sqlc.arg(from_account_id)
+    OR to_account_id = sqlc.arg(to_account_id)
+  )
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (created_at, id) < (
+      sqlc.narg(cursor_created_at)::timestamptz,
+      sqlc.narg(cursor_id)::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListTransfersByOwner :many
+-- ユーザーの口座から送金した、またはユーザーの口座が受け取った送金を返す
+SELECT *
+FROM transfers
+WHERE from_account_id IN (
+    SELECT id
+    FROM accounts
+    WHERE owner = $1
+  )
+  OR to_account_id IN (
+    SELECT id
+    FROM accounts
+    WHERE owner = $1
+  )
+ORDER BY id;
@@ -0,0 +1,95 @@
+-- name: CreateTransferLimit :one
+INSERT INTO transfer_limits (
+    role,
+    account_type,
+    currency,
+    per_transaction,
+    daily_amount,
+    monthly_amount,
+    daily_count
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListTransferLimits :many
+SELECT *
+FROM transfer_limits
+ORDER BY role,
+  account_type,
+  currency;
+
+-- name: UpsertUserTransferLimit :one
+INSERT INTO user_transfer_limits (
+    username,
+    currency,
+    per_transaction,
+    daily_amount,
+    monthly_amount,
+    daily_count,
+    updated_by
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (username, currency) DO
+UPDATE
+SET per_transaction = EXCLUDED.per_transaction,
+  daily_amount = EXCLUDED.daily_amount,
+  monthly_amount = EXCLUDED.monthly_amount,
+  daily_count = EXCLUDED.daily_count,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = now()
+RETURNING *;
+
+-- name: GetUserTransferLimit :one
+SELECT *
+FROM user_transfer_limits
+WHERE username = $1
+  AND currency = $2
+LIMIT 1;
+
+-- name: GetTransferLimitForAccount :one
+SELECT COALESCE(
+    user_transfer_limits.per_transaction,
+    transfer_limits.per_transaction
+  ) AS per_transaction,
+  COALESCE(
+    user_transfer_limits.daily_amount,
+    transfer_limits.daily_amount
+  ) AS daily_amount,
+  COALESCE(
+    user_transfer_limits.monthly_amount,
+    transfer_limits.monthly_amount
+  ) AS monthly_amount,
+  COALESCE(
+    user_transfer_limits.daily_count,
+    transfer_limits.daily_count
+  ) AS daily_count,
+  transfer_limits.new_beneficiary_amount
+FROM accounts
+  JOIN users ON users.username = accounts.owner
+  LEFT JOIN transfer_limits ON transfer_limits.role = users.role
+  AND transfer_limits.account_type = accounts.account_type
+  AND transfer_limits.currency = accounts.currency
+  LEFT JOIN user_transfer_limits ON user_transfer_limits.username = accounts.owner
+  AND user_transfer_limits.currency = accounts.currency
+WHERE accounts.id = $1
+LIMIT 1;
+
+-- name: LockAccountForTransferLimit :exec
+SELECT pg_advisory_xact_lock($1);
+
+-- name: GetTransferTotals :one
+-- 出金はシステム口座への送金として記録するので、送金上限には数えない
+SELECT COALESCE(
+    SUM(transfers.amount) FILTER (
+      WHERE transfers.created_at > now() - interval '1 day'
+    ),
+    0
+  )::bigint AS daily_amount,
+  COUNT(*) FILTER (
+    WHERE transfers.created_at > now() - interval '1 day'
+  ) AS daily_count,
+  COALESCE(SUM(transfers.amount), 0)::bigint AS monthly_amount
+FROM transfers
+  JOIN accounts ON accounts.id = transfers.to_account_id
+WHERE transfers.from_account_id = $1
+  AND transfers.created_at > now() - interval '30 days'
+  AND accounts.owner NOT IN ('system_fees', 'system_vault', 'system_interest');
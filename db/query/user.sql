@@ -14,6 +14,26 @@ FROM users
 WHERE username = $1
 LIMIT 1;
 
+-- name: GetUserForUpdate :one
+SELECT *
+FROM users
+WHERE username = $1
+LIMIT 1 FOR NO KEY
+UPDATE;
+
+-- name: IsUserDeleted :one
+-- トークンを確かめるたびに呼ぶので、削除したかどうかだけを読む
+SELECT deleted_at IS NOT NULL AS is_deleted
+FROM users
+WHERE username = $1
+LIMIT 1;
+
+-- name: GetUserByEmail :one
+SELECT *
+FROM users
+WHERE email = $1
+LIMIT 1;
+
 -- name: UpdateUser :one
 UPDATE users
 SET hashed_password = COALESCE(sqlc.narg(hashed_password), hashed_password),
@@ -23,6 +43,48 @@ SET hashed_password = COALESCE(sqlc.narg(hashed_password), hashed_password),
   ),
   full_name = COALESCE(sqlc.narg(full_name), full_name),
   email = COALESCE(sqlc.narg(email), email),
-  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified)
+  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
+  phone_number = COALESCE(sqlc.narg(phone_number), phone_number),
+  locale = COALESCE(sqlc.narg(locale), locale),
+  version = version + 1
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: RehashUserPassword :exec
+-- ログインのときにハッシュを作り直す。同じパスワードなので version は変えない
+-- 読んだ後にパスワードが変わった場合は、新しいパスワードを上書きしないように何もしない
+UPDATE users
+SET hashed_password = sqlc.arg(new_hashed_password)
+WHERE username = sqlc.arg(username)
+  AND hashed_password = sqlc.arg(old_hashed_password);
+
+-- name: ListUsersByRole :many
+SELECT *
+FROM users
+WHERE role = $1
+ORDER BY username;
+
+-- name: RequestVerifyEmail :one
+-- 前回の要求が requested_before より前の場合だけ要求した時刻を記録する。短い間に何度も送らないために使う
+UPDATE users
+SET verify_email_requested_at = now()
 WHERE username = sqlc.arg(username)
-RETURNING *;
\ No newline at end of file
+  AND (
+    verify_email_requested_at IS NULL
+    OR verify_email_requested_at <= sqlc.arg(requested_before)
+  )
+RETURNING *;
+
+-- name: PseudonymizeUser :one
+-- 口座と台帳の行は残すために username はそのままにして、個人を特定できる値を置き換える
+UPDATE users
+SET full_name = sqlc.arg(full_name),
+  email = sqlc.arg(email),
+  hashed_password = '',
+  phone_number = '',
+  is_email_verified = false,
+  verify_email_requested_at = NULL,
+  deleted_at = now(),
+  version = version + 1
+WHERE username = sqlc.arg(username)
+RETURNING *;
@@ -1,13 +1,46 @@
 -- name: CreateVerifyEmail :one
-INSERT INTO verify_emails (username, email, secret_code)
+INSERT INTO verify_emails (username, email, secret_code_hash)
 VALUES ($1, $2, $3)
 RETURNING *;
 
+-- name: GetVerifyEmailForUpdate :one
+SELECT *
+FROM verify_emails
+WHERE id = $1
+LIMIT 1 FOR NO KEY
+UPDATE;
+
+-- name: IncrementVerifyEmailAttempts :one
+-- 間違った確認コードを試した回数を数える
+UPDATE verify_emails
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING *;
+
 -- name: UpdateVerifyEmail :one
+-- 確認コードはハッシュを一定時間で比べてから使ったことにする
 UPDATE verify_emails
 SET is_used = TRUE
 WHERE id = @id
-  AND secret_code = @secret_code
   AND is_used = FALSE
   AND expires_at > now()
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: InvalidateVerifyEmails :exec
+-- まだ使っていない確認コードをすべて使えなくする。メールアドレスを変えたときに古いアドレスに送ったコードを無効にする
+UPDATE verify_emails
+SET is_used = TRUE
+WHERE username = $1
+  AND is_used = FALSE;
+
+-- name: ListVerifyEmailsByUsername :many
+SELECT *
+FROM verify_emails
+WHERE username = $1
+ORDER BY id;
+
+-- name: DeleteUserVerifyEmails :many
+-- 削除したユーザーの確認コードを消す。確認のメールを送ったアドレスを返す
+DELETE FROM verify_emails
+WHERE username = $1
+RETURNING *;
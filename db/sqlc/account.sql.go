@@ -7,13 +7,15 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, account_type, closed_at
 `
 
 type AddAccountBalanceParams struct {
@@ -30,24 +32,44 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountType,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
+const closeUserAccounts = `-- name: CloseUserAccounts :exec
+UPDATE accounts
+SET closed_at = now()
+WHERE owner = $1
+  AND closed_at IS NULL
+`
+
+func (q *Queries) CloseUserAccounts(ctx context.Context, owner string) error {
+	_, err := q.db.Exec(ctx, closeUserAccounts, owner)
+	return err
+}
+
 const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (owner, balance, currency)
-VALUES ($1, $2, $3)
-RETURNING id, owner, balance, currency, created_at
+INSERT INTO accounts (owner, balance, currency, account_type)
+VALUES ($1, $2, $3, $4)
+RETURNING id, owner, balance, currency, created_at, account_type, closed_at
 `
 
 type CreateAccountParams struct {
-	Owner    string `json:"owner"`
-	Balance  int64  `json:"balance"`
-	Currency string `json:"currency"`
+	Owner       string `json:"owner"`
+	Balance     int64  `json:"balance"`
+	Currency    string `json:"currency"`
+	AccountType string `json:"account_type"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRow(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRow(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.AccountType,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -55,6 +77,8 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountType,
+		&i.ClosedAt,
 	)
 	return i, err
 }
@@ -70,7 +94,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, account_type, closed_at
 FROM accounts
 WHERE id = $1
 LIMIT 1
@@ -85,12 +109,44 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountType,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getAccountByOwner = `-- name: GetAccountByOwner :one
+SELECT id, owner, balance, currency, created_at, account_type, closed_at
+FROM accounts
+WHERE owner = $1
+  AND currency = $2
+  AND account_type = $3
+LIMIT 1
+`
+
+type GetAccountByOwnerParams struct {
+	Owner       string `json:"owner"`
+	Currency    string `json:"currency"`
+	AccountType string `json:"account_type"`
+}
+
+func (q *Queries) GetAccountByOwner(ctx context.Context, arg GetAccountByOwnerParams) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByOwner, arg.Owner, arg.Currency, arg.AccountType)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.AccountType,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, account_type, closed_at
 FROM accounts
 WHERE id = $1
 LIMIT 1 FOR NO KEY
@@ -106,26 +162,43 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.AccountType,
+		&i.ClosedAt,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at
+SELECT id, owner, balance, currency, created_at, account_type, closed_at
 FROM accounts
 WHERE owner = $1
-ORDER BY id
-LIMIT $2 OFFSET $3
+  AND (
+    $2::timestamptz IS NULL
+    OR (created_at, id) > (
+      $2::timestamptz,
+      $3::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT $4
 `
 
 type ListAccountsParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	Owner           string             `json:"owner"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
 }
 
+// 口座を作成した古い順に cursor の次から返す
 func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
-	rows, err := q.db.Query(ctx, listAccounts, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listAccounts,
+		arg.Owner,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +212,8 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.AccountType,
+			&i.ClosedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -150,27 +225,130 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
-const updateAccount = `-- name: UpdateAccount :one
-UPDATE accounts
-SET balance = $2
-WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+const listAccountsByOwner = `-- name: ListAccountsByOwner :many
+SELECT id, owner, balance, currency, created_at, account_type, closed_at
+FROM accounts
+WHERE owner = $1
+ORDER BY id
 `
 
-type UpdateAccountParams struct {
-	ID      int64 `json:"id"`
-	Balance int64 `json:"balance"`
+func (q *Queries) ListAccountsByOwner(ctx context.Context, owner string) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsByOwner, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.AccountType,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
-	row := q.db.QueryRow(ctx, updateAccount, arg.ID, arg.Balance)
-	var i Account
-	err := row.Scan(
-		&i.ID,
-		&i.Owner,
-		&i.Balance,
-		&i.Currency,
-		&i.CreatedAt,
+const listAccountsByOwnerForUpdate = `-- name: ListAccountsByOwnerForUpdate :many
+SELECT id, owner, balance, currency, created_at, account_type, closed_at
+FROM accounts
+WHERE owner = $1
+ORDER BY id FOR NO KEY
+UPDATE
+`
+
+func (q *Queries) ListAccountsByOwnerForUpdate(ctx context.Context, owner string) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsByOwnerForUpdate, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.AccountType,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsDesc = `-- name: ListAccountsDesc :many
+SELECT id, owner, balance, currency, created_at, account_type, closed_at
+FROM accounts
+WHERE owner = $1
+  AND (
+    $2::timestamptz IS NULL
+    OR (created_at, id) < (
+      $2::timestamptz,
+      $3::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT $4
+`
+
+type ListAccountsDescParams struct {
+	Owner           string             `json:"owner"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
+}
+
+// 口座を作成した新しい順に cursor の次から返す
+func (q *Queries) ListAccountsDesc(ctx context.Context, arg ListAccountsDescParams) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsDesc,
+		arg.Owner,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
 	)
-	return i, err
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.AccountType,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
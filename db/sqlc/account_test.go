@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shouta0715/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
@@ -12,10 +13,12 @@ import (
 func createRandomAccount(t *testing.T) Account {
 	user := createRandomUser(t)
 
+	// 送金のテストで残高が足りなくならないように、少なくとも 1000 にする
 	args := CreateAccountParams{
-		Owner:    user.Username,
-		Balance:  util.RandomMoney(),
-		Currency: util.RandomCurrency(),
+		Owner:       user.Username,
+		Balance:     1000 + util.RandomMoney(),
+		Currency:    util.RandomCurrency(),
+		AccountType: util.CheckingAccount,
 	}
 	account, err := testStore.CreateAccount(context.Background(), args)
 
@@ -25,6 +28,7 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, args.Owner, account.Owner)
 	require.Equal(t, args.Balance, account.Balance)
 	require.Equal(t, args.Currency, account.Currency)
+	require.Equal(t, args.AccountType, account.AccountType)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -52,27 +56,6 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
 }
 
-func TestUpdateAccount(t *testing.T) {
-	account1 := createRandomAccount(t)
-	args := UpdateAccountParams{
-		ID:      account1.ID,
-		Balance: util.RandomMoney(),
-	}
-
-	account2, err := testStore.UpdateAccount(context.Background(), args)
-
-	require.NoError(t, err)
-	require.NotEmpty(t, account2)
-
-	require.Equal(t, account1.ID, account2.ID)
-	require.Equal(t, account1.Owner, account2.Owner)
-	require.Equal(t, args.Balance, account2.Balance)
-	require.Equal(t, account1.Currency, account2.Currency)
-
-	require.WithinDuration(t, account1.CreatedAt, account2.CreatedAt, time.Second)
-
-}
-
 func TestDeleteAccount(t *testing.T) {
 	account1 := createRandomAccount(t)
 	err := testStore.DeleteAccount(context.Background(), account1.ID)
@@ -92,9 +75,8 @@ func TestListAccount(t *testing.T) {
 	}
 
 	args := ListAccountsParams{
-		Owner:  lastAccount.Owner,
-		Limit:  5,
-		Offset: 0,
+		Owner: lastAccount.Owner,
+		Limit: 5,
 	}
 	accounts, err := testStore.ListAccounts(context.Background(), args)
 	require.NoError(t, err)
@@ -104,4 +86,17 @@ func TestListAccount(t *testing.T) {
 		require.NotEmpty(t, account)
 		require.Equal(t, lastAccount.Owner, account.Owner)
 	}
+
+	// 最後の口座より後ろには何もない
+	args.CursorCreatedAt = pgtype.Timestamptz{Time: lastAccount.CreatedAt, Valid: true}
+	args.CursorID = pgtype.Int8{Int64: lastAccount.ID, Valid: true}
+
+	accounts, err = testStore.ListAccounts(context.Background(), args)
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	accounts, err = testStore.ListAccountsDesc(context.Background(), ListAccountsDescParams(args))
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.NotEqual(t, lastAccount.ID, accounts[0].ID)
 }
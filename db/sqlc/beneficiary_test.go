@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomBeneficiary(t *testing.T, owner Account, target Account) Beneficiary {
+	arg := CreateBeneficiaryParams{
+		Owner:     owner.Owner,
+		Nickname:  util.RandomOwner(),
+		AccountID: target.ID,
+	}
+
+	beneficiary, err := testStore.CreateBeneficiary(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Owner, beneficiary.Owner)
+	require.Equal(t, arg.Nickname, beneficiary.Nickname)
+	require.Equal(t, arg.AccountID, beneficiary.AccountID)
+	// ランダムなユーザーはメールアドレスを確認していない
+	require.False(t, beneficiary.Verified)
+	require.NotZero(t, beneficiary.CreatedAt)
+
+	return beneficiary
+}
+
+func TestCreateBeneficiary(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	beneficiary := createRandomBeneficiary(t, account1, account2)
+
+	_, err := testStore.CreateBeneficiary(context.Background(), CreateBeneficiaryParams{
+		Owner:     account1.Owner,
+		Nickname:  util.RandomOwner(),
+		AccountID: account2.ID,
+	})
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+
+	err = testStore.DeleteBeneficiary(context.Background(), beneficiary.ID)
+	require.NoError(t, err)
+
+	_, err = testStore.GetBeneficiary(context.Background(), beneficiary.ID)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+}
+
+func TestTransferTxNewBeneficiaryLimit(t *testing.T) {
+	account1 := createRandomAccount(t)
+
+	limit, err := testStore.GetTransferLimitForAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.True(t, limit.NewBeneficiaryAmount.Valid)
+
+	amount := limit.NewBeneficiaryAmount.Int64 + 1
+
+	account2 := createRandomAccount(t)
+	createRandomBeneficiary(t, account1, account2)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitNewBeneficiary, limitErr.Limit)
+	require.Equal(t, limit.NewBeneficiaryAmount.Int64, limitErr.Remaining)
+}
+
+func TestTransferTxNewPayeeLimit(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	limit, err := testStore.GetTransferLimitForAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.True(t, limit.NewBeneficiaryAmount.Valid)
+
+	_, err = testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account1.ID,
+		Amount: limit.NewBeneficiaryAmount.Int64,
+	})
+	require.NoError(t, err)
+
+	// 受取人として追加していなくても、送金したことがない口座には上限がかかる
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        limit.NewBeneficiaryAmount.Int64 + 1,
+	})
+
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitNewBeneficiary, limitErr.Limit)
+
+	// 上限の範囲で送金を分けても、24時間以内の合計で確認する
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        limit.NewBeneficiaryAmount.Int64,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, int64(0), limitErr.Remaining)
+}
+
+func TestTransferTxNewPayeeLimitAcrossOwnerAccounts(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	// 同じ持ち主の別の口座から送っても、持ち主ごとの合計で確認する
+	savings, err := testStore.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:       account1.Owner,
+		Balance:     1000,
+		Currency:    account1.Currency,
+		AccountType: util.SavingsAccount,
+	})
+	require.NoError(t, err)
+
+	limit, err := testStore.GetTransferLimitForAccount(context.Background(), savings.ID)
+	require.NoError(t, err)
+	require.True(t, limit.NewBeneficiaryAmount.Valid)
+
+	_, err = testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account1.ID,
+		Amount: limit.NewBeneficiaryAmount.Int64,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        limit.NewBeneficiaryAmount.Int64,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: savings.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitNewBeneficiary, limitErr.Limit)
+}
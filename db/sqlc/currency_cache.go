@@ -0,0 +1,31 @@
+package db
+
+import (
+	"context"
+
+	"github.com/shouta0715/simple-bank/util"
+)
+
+// LoadCurrencies は currencies テーブルを読み込み、util の通貨キャッシュを更新する
+func LoadCurrencies(ctx context.Context, q Querier) error {
+	rows, err := q.ListCurrencies(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	currencies := make([]util.Currency, 0, len(rows))
+
+	for _, row := range rows {
+		currencies = append(currencies, util.Currency{
+			Code:              row.Code,
+			MinorUnit:         row.MinorUnit,
+			Enabled:           row.Enabled,
+			ApprovalThreshold: row.ApprovalThreshold,
+		})
+	}
+
+	util.SetCurrencies(currencies)
+
+	return nil
+}
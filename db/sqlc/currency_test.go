@@ -0,0 +1,32 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCurrency(t *testing.T) {
+	currency, err := testStore.GetCurrency(context.Background(), util.JPY)
+	require.NoError(t, err)
+
+	require.Equal(t, util.JPY, currency.Code)
+	require.Equal(t, int32(0), currency.MinorUnit)
+	require.True(t, currency.Enabled)
+	require.Equal(t, int64(1500000), currency.ApprovalThreshold)
+}
+
+func TestLoadCurrencies(t *testing.T) {
+	err := LoadCurrencies(context.Background(), testStore)
+	require.NoError(t, err)
+
+	for _, code := range []string{util.USD, util.EUR, util.CAD, util.JPY} {
+		require.True(t, util.IsSupportedCurrency(code))
+	}
+
+	currency, ok := util.GetCurrency(util.USD)
+	require.True(t, ok)
+	require.Equal(t, int32(2), currency.MinorUnit)
+}
@@ -7,33 +7,38 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createEntry = `-- name: CreateEntry :one
-INSERT INTO entries (account_id, amount)
-VALUES ($1, $2)
-RETURNING id, account_id, amount, created_at
+INSERT INTO entries (account_id, amount, transfer_id)
+VALUES ($1, $2, $3)
+RETURNING id, account_id, amount, created_at, transfer_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID  int64       `json:"account_id"`
+	Amount     int64       `json:"amount"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount, arg.TransferID)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
 	)
 	return i, err
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, transfer_id
 FROM entries
 WHERE id = $1
 LIMIT 1
@@ -47,26 +52,76 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
 	)
 	return i, err
 }
 
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at
+SELECT id, account_id, amount, created_at, transfer_id
 FROM entries
 WHERE account_id = $1
-ORDER BY id
-LIMIT $2 OFFSET $3
+  AND (
+    $2::timestamptz IS NULL
+    OR (created_at, id) > (
+      $2::timestamptz,
+      $3::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT $4
 `
 
 type ListEntriesParams struct {
-	AccountID int64 `json:"account_id"`
-	Limit     int32 `json:"limit"`
-	Offset    int32 `json:"offset"`
+	AccountID       int64              `json:"account_id"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
 }
 
+// 口座の entry を古い順に cursor の次から返す
 func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
-	rows, err := q.db.Query(ctx, listEntries, arg.AccountID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listEntries,
+		arg.AccountID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesByOwner = `-- name: ListEntriesByOwner :many
+SELECT entries.id, entries.account_id, entries.amount, entries.created_at, entries.transfer_id
+FROM entries
+  JOIN accounts ON accounts.id = entries.account_id
+WHERE accounts.owner = $1
+ORDER BY entries.id
+`
+
+func (q *Queries) ListEntriesByOwner(ctx context.Context, owner string) ([]Entry, error) {
+	rows, err := q.db.Query(ctx, listEntriesByOwner, owner)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +134,193 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEntriesDesc = `-- name: ListEntriesDesc :many
+SELECT id, account_id, amount, created_at, transfer_id
+FROM entries
+WHERE account_id = $1
+  AND (
+    $2::timestamptz IS NULL
+    OR (created_at, id) < (
+      $2::timestamptz,
+      $3::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT $4
+`
+
+type ListEntriesDescParams struct {
+	AccountID       int64              `json:"account_id"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
+}
+
+// 口座の entry を新しい順に cursor の次から返す
+func (q *Queries) ListEntriesDesc(ctx context.Context, arg ListEntriesDescParams) ([]Entry, error) {
+	rows, err := q.db.Query(ctx, listEntriesDesc,
+		arg.AccountID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTransactions = `-- name: SearchTransactions :many
+SELECT entries.id,
+  entries.account_id,
+  entries.amount,
+  entries.transfer_id,
+  entries.created_at,
+  accounts.currency,
+  transfers.memo,
+  transfers.reference,
+  transfers.category,
+  counterparty.id AS counterparty_account_id
+FROM entries
+  JOIN accounts ON accounts.id = entries.account_id
+  JOIN transfers ON transfers.id = entries.transfer_id
+  JOIN accounts AS counterparty ON counterparty.id = CASE
+    WHEN transfers.from_account_id = entries.account_id THEN transfers.to_account_id
+    ELSE transfers.from_account_id
+  END
+WHERE accounts.owner = $1
+  AND (
+    $2::text IS NULL
+    OR to_tsvector('simple', transfers.memo) @@ plainto_tsquery('simple', $2::text)
+  )
+  AND (
+    $3::bigint IS NULL
+    OR abs(entries.amount) >= $3::bigint
+  )
+  AND (
+    $4::bigint IS NULL
+    OR abs(entries.amount) <= $4::bigint
+  )
+  AND (
+    $5::timestamptz IS NULL
+    OR entries.created_at >= $5::timestamptz
+  )
+  AND (
+    $6::timestamptz IS NULL
+    OR entries.created_at < $6::timestamptz
+  )
+  AND (
+    $7::varchar IS NULL
+    OR counterparty.owner = $7::varchar
+  )
+  AND (
+    $8::varchar IS NULL
+    OR accounts.currency = $8::varchar
+  )
+  AND (
+    $9::timestamptz IS NULL
+    OR (entries.created_at, entries.id) < (
+      $9::timestamptz,
+      $10::bigint
+    )
+  )
+ORDER BY entries.created_at DESC,
+  entries.id DESC
+LIMIT $11
+`
+
+type SearchTransactionsParams struct {
+	Owner           string             `json:"owner"`
+	Query           pgtype.Text        `json:"query"`
+	MinAmount       pgtype.Int8        `json:"min_amount"`
+	MaxAmount       pgtype.Int8        `json:"max_amount"`
+	StartTime       pgtype.Timestamptz `json:"start_time"`
+	EndTime         pgtype.Timestamptz `json:"end_time"`
+	Counterparty    pgtype.Text        `json:"counterparty"`
+	Currency        pgtype.Text        `json:"currency"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
+}
+
+type SearchTransactionsRow struct {
+	ID                    int64       `json:"id"`
+	AccountID             int64       `json:"account_id"`
+	Amount                int64       `json:"amount"`
+	TransferID            pgtype.Int8 `json:"transfer_id"`
+	CreatedAt             time.Time   `json:"created_at"`
+	Currency              string      `json:"currency"`
+	Memo                  string      `json:"memo"`
+	Reference             string      `json:"reference"`
+	Category              string      `json:"category"`
+	CounterpartyAccountID int64       `json:"counterparty_account_id"`
+}
+
+// ユーザーの口座の送金の entry を新しい順に検索する。NULL の条件は使わない
+// 相手の口座は送金元の entry では送金先、送金先の entry では送金元になる
+func (q *Queries) SearchTransactions(ctx context.Context, arg SearchTransactionsParams) ([]SearchTransactionsRow, error) {
+	rows, err := q.db.Query(ctx, searchTransactions,
+		arg.Owner,
+		arg.Query,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Counterparty,
+		arg.Currency,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchTransactionsRow{}
+	for rows.Next() {
+		var i SearchTransactionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.TransferID,
+			&i.CreatedAt,
+			&i.Currency,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+			&i.CounterpartyAccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shouta0715/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
@@ -50,14 +51,17 @@ func TestGetEntry(t *testing.T) {
 func TestListEntries(t *testing.T) {
 	account := createRandomAccount(t)
 
-	for i := 0; i < 10; i++ {
-		createRandomEntry(t, account)
+	created := make([]Entry, 10)
+
+	for i := range created {
+		created[i] = createRandomEntry(t, account)
 	}
 
 	args := ListEntriesParams{
-		AccountID: account.ID,
-		Limit:     5,
-		Offset:    5,
+		AccountID:       account.ID,
+		CursorCreatedAt: pgtype.Timestamptz{Time: created[4].CreatedAt, Valid: true},
+		CursorID:        pgtype.Int8{Int64: created[4].ID, Valid: true},
+		Limit:           5,
 	}
 
 	entries, err := testStore.ListEntries(context.Background(), args)
@@ -65,9 +69,85 @@ func TestListEntries(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, entries, 5)
 
-	for _, entry := range entries {
+	for i, entry := range entries {
 		require.NotEmpty(t, entry)
 		require.Equal(t, args.AccountID, entry.AccountID)
+		require.Equal(t, created[5+i].ID, entry.ID)
+	}
+
+	entries, err = testStore.ListEntriesDesc(context.Background(), ListEntriesDescParams(args))
+
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.Equal(t, created[3].ID, entries[0].ID)
+}
+
+func TestSearchTransactions(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	memos := []string{"rent for march", "dinner with friends", "rent for april"}
+
+	for _, memo := range memos {
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+			Memo:          memo,
+			Category:      util.CategoryRent,
+		})
+		require.NoError(t, err)
+	}
+
+	arg := SearchTransactionsParams{
+		Owner: account1.Owner,
+		Query: pgtype.Text{
+			String: "rent",
+			Valid:  true,
+		},
+		Counterparty: pgtype.Text{
+			String: account2.Owner,
+			Valid:  true,
+		},
+		Limit: 1,
+	}
+
+	page1, err := testStore.SearchTransactions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, page1, 1)
+	require.Equal(t, "rent for april", page1[0].Memo)
+	require.Equal(t, int64(-10), page1[0].Amount)
+	require.Equal(t, account2.ID, page1[0].CounterpartyAccountID)
+
+	arg.CursorCreatedAt = pgtype.Timestamptz{
+		Time:  page1[0].CreatedAt,
+		Valid: true,
+	}
+	arg.CursorID = pgtype.Int8{
+		Int64: page1[0].ID,
+		Valid: true,
 	}
+	arg.Limit = 5
 
+	page2, err := testStore.SearchTransactions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, page2, 1)
+	require.Equal(t, "rent for march", page2[0].Memo)
+
+	// 送金先から見ると送金元が相手になる
+	received, err := testStore.SearchTransactions(context.Background(), SearchTransactionsParams{
+		Owner: account2.Owner,
+		MinAmount: pgtype.Int8{
+			Int64: 10,
+			Valid: true,
+		},
+		Limit: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, received, len(memos))
+
+	for _, transaction := range received {
+		require.Equal(t, int64(10), transaction.Amount)
+		require.Equal(t, account1.ID, transaction.CounterpartyAccountID)
+	}
 }
@@ -14,6 +14,17 @@ const (
 
 var ErrorRecordNotFound = pgx.ErrNoRows
 var ErrorUniqueViolation = &pgconn.PgError{Code: UniqueViolation}
+var ErrInsufficientBalance = errors.New("insufficient balance")
+var ErrReviewNotPending = errors.New("review is not pending")
+var ErrOperationExpired = errors.New("operation has expired")
+var ErrSameApprover = errors.New("operation must be approved by another banker")
+var ErrPaymentRequestNotPending = errors.New("payment request is not pending")
+var ErrInvalidSecretCode = errors.New("secret code is invalid or expired")
+var ErrTooManyAttempts = errors.New("too many wrong secret codes")
+var ErrVersionMismatch = errors.New("record has been modified since it was read")
+var ErrNonZeroBalance = errors.New("account balance must be zero")
+var ErrUserDeleted = errors.New("user has been deleted")
+var ErrAccountClosed = errors.New("account is closed")
 
 func ErrorCode(err error) string {
 	var pgErr *pgconn.PgError
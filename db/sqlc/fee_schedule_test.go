@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomFeeSchedule(t *testing.T) FeeSchedule {
+	// 他のテストの送金に手数料がかからないように、ランダムなroleで作成する
+	arg := CreateFeeScheduleParams{
+		Role:          util.RandomString(8),
+		AccountType:   util.CheckingAccount,
+		Currency:      util.RandomCurrency(),
+		FlatFee:       util.RandomMoney(),
+		PercentFeeBps: int64(util.RandomInt(0, 500)),
+	}
+
+	schedule, err := testStore.CreateFeeSchedule(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, schedule)
+
+	require.Equal(t, arg.Role, schedule.Role)
+	require.Equal(t, arg.AccountType, schedule.AccountType)
+	require.Equal(t, arg.Currency, schedule.Currency)
+	require.Equal(t, arg.FlatFee, schedule.FlatFee)
+	require.Equal(t, arg.PercentFeeBps, schedule.PercentFeeBps)
+
+	require.NotZero(t, schedule.ID)
+	require.NotZero(t, schedule.CreatedAt)
+
+	return schedule
+}
+
+func TestCreateFeeSchedule(t *testing.T) {
+	createRandomFeeSchedule(t)
+}
+
+func TestGetFeeSchedule(t *testing.T) {
+	schedule1 := createRandomFeeSchedule(t)
+
+	schedule2, err := testStore.GetFeeSchedule(context.Background(), GetFeeScheduleParams{
+		Role:        schedule1.Role,
+		AccountType: schedule1.AccountType,
+		Currency:    schedule1.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, schedule1, schedule2)
+}
+
+func TestDeleteFeeSchedule(t *testing.T) {
+	schedule1 := createRandomFeeSchedule(t)
+
+	err := testStore.DeleteFeeSchedule(context.Background(), schedule1.ID)
+	require.NoError(t, err)
+
+	schedule2, err := testStore.GetFeeSchedule(context.Background(), GetFeeScheduleParams{
+		Role:        schedule1.Role,
+		AccountType: schedule1.AccountType,
+		Currency:    schedule1.Currency,
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+	require.Empty(t, schedule2)
+}
+
+func TestGetFeeScheduleForAccountWithoutSchedule(t *testing.T) {
+	account := createRandomAccount(t)
+
+	_, err := testStore.GetFeeScheduleForAccount(context.Background(), account.ID)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxHeldByScreen(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	var review FraudReview
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		Memo:          "invoice 42",
+		Reference:     "INV-42",
+		Category:      "rent",
+		Screen: func(q Querier) (bool, error) {
+			var err error
+
+			review, err = q.CreateFraudReview(context.Background(), CreateFraudReviewParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        10,
+				Currency:      account1.Currency,
+				RequestedBy:   account1.Owner,
+				Rules:         "test",
+				Memo:          "invoice 42",
+				Reference:     "INV-42",
+				Category:      "rent",
+			})
+
+			return false, err
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Held)
+	require.Zero(t, result.Transfer.ID)
+	require.Equal(t, util.ReviewPending, review.Status)
+
+	// 保留された送金は残高を動かさない
+	account, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, account.Balance)
+
+	// 手数料があっても残高が足りるようにする
+	account1, err = testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account1.ID,
+		Amount: 10000,
+	})
+	require.NoError(t, err)
+
+	banker := createRandomUser(t)
+
+	approved, err := testStore.ReviewFraudTx(context.Background(), ReviewFraudTxParams{
+		ReviewID:   review.ID,
+		ReviewedBy: banker.Username,
+		Approve:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewApproved, approved.Review.Status)
+	require.Equal(t, banker.Username, approved.Review.ReviewedBy.String)
+	require.Equal(t, approved.Transfer.Transfer.ID, approved.Review.TransferID.Int64)
+	require.Equal(t, account1.Balance-10-approved.Transfer.Fee, approved.Transfer.FromAccount.Balance)
+
+	// 保留したときのメモなどで送金される
+	require.Equal(t, "invoice 42", approved.Transfer.Transfer.Memo)
+	require.Equal(t, "INV-42", approved.Transfer.Transfer.Reference)
+	require.Equal(t, "rent", approved.Transfer.Transfer.Category)
+
+	// 二度目の審査はできない
+	_, err = testStore.ReviewFraudTx(context.Background(), ReviewFraudTxParams{
+		ReviewID:   review.ID,
+		ReviewedBy: banker.Username,
+	})
+	require.ErrorIs(t, err, ErrReviewNotPending)
+}
+
+func TestCountRecentNewPayees(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+
+	since := account1.CreatedAt.Add(-1)
+
+	createRandomTransfer(t, account1, account2)
+	createRandomTransfer(t, account1, account2)
+	createRandomTransfer(t, account1, account3)
+
+	count, err := testStore.CountRecentNewPayees(context.Background(), CountRecentNewPayeesParams{
+		FromAccountID: account1.ID,
+		Since:         since,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	transferred, err := testStore.HasTransferredTo(context.Background(), HasTransferredToParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, transferred)
+}
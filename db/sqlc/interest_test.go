@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateInterestRatePlan(t *testing.T) {
+	// 他のテストの口座に利息がつかないように、ランダムな口座種別で作成する
+	arg := CreateInterestRatePlanParams{
+		AccountType:   util.RandomString(8),
+		Currency:      util.RandomCurrency(),
+		AnnualRateBps: int64(util.RandomInt(1, 500)),
+	}
+
+	plan, err := testStore.CreateInterestRatePlan(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, plan.ID)
+
+	require.Equal(t, arg.AccountType, plan.AccountType)
+	require.Equal(t, arg.Currency, plan.Currency)
+	require.Equal(t, arg.AnnualRateBps, plan.AnnualRateBps)
+
+	got, err := testStore.GetInterestRatePlan(context.Background(), GetInterestRatePlanParams{
+		AccountType: arg.AccountType,
+		Currency:    arg.Currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, plan, got)
+
+	_, err = testStore.CreateInterestRatePlan(context.Background(), arg)
+	require.Equal(t, UniqueViolation, ErrorCode(err))
+}
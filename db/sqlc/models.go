@@ -6,6 +6,8 @@ package db
 
 import (
 	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Account struct {
@@ -14,25 +16,252 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// checking or savings
+	AccountType string `json:"account_type"`
+	// set when the owner is deleted. closed accounts cannot send or receive money
+	ClosedAt pgtype.Timestamptz `json:"closed_at"`
+}
+
+type AlertEvent struct {
+	ID         int64 `json:"id"`
+	RuleID     int64 `json:"rule_id"`
+	TransferID int64 `json:"transfer_id"`
+	// balance when the rule was evaluated for low_balance, amount received for large_incoming_transfer
+	Amount      int64              `json:"amount"`
+	DeliveredAt pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type AlertRule struct {
+	ID        int64  `json:"id"`
+	Owner     string `json:"owner"`
+	AccountID int64  `json:"account_id"`
+	// low_balance or large_incoming_transfer
+	Kind      string `json:"kind"`
+	Threshold int64  `json:"threshold"`
+	// true while the balance stays below the threshold of a low_balance rule
+	Triggered bool      `json:"triggered"`
+	CreatedAt time.Time `json:"created_at"`
+	// latest transfer evaluated for a low_balance rule, older transfers are skipped
+	LastTransferID int64 `json:"last_transfer_id"`
+}
+
+type AuditLog struct {
+	ID         int64     `json:"id"`
+	Actor      string    `json:"actor"`
+	Action     string    `json:"action"`
+	Resource   string    `json:"resource"`
+	ResourceID string    `json:"resource_id"`
+	Detail     string    `json:"detail"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Beneficiary struct {
+	ID        int64  `json:"id"`
+	Owner     string `json:"owner"`
+	Nickname  string `json:"nickname"`
+	AccountID int64  `json:"account_id"`
+	// whether the owner of the target account had a verified email when it was added
+	Verified  bool      `json:"verified"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Currency struct {
+	// ISO 4217 currency code
+	Code string `json:"code"`
+	// number of decimal places of the minor unit (2 for USD cents, 0 for JPY)
+	MinorUnit int32     `json:"minor_unit"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	// banker operations above this amount (in minor units) need a second banker''s approval. 0 disables approval
+	ApprovalThreshold int64 `json:"approval_threshold"`
+}
+
+type DataExport struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// pending, ready or failed
+	Status string `json:"status"`
+	// ZIP file of the user data, set when the status is ready
+	Archive     []byte             `json:"archive"`
+	Error       string             `json:"error"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	// the archive cannot be downloaded after this time
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+type EmailDelivery struct {
+	ID int64 `json:"id"`
+	// name of the email template, empty when the email was not rendered from a template
+	Template string `json:"template"`
+	// comma separated addresses the email was sent to
+	Recipient string `json:"recipient"`
+	Cc        string `json:"cc"`
+	Bcc       string `json:"bcc"`
+	Subject   string `json:"subject"`
+	// secret codes and signed links are replaced before the content is recorded
+	ContentHtml string `json:"content_html"`
+	// secret codes and signed links are replaced before the content is recorded
+	ContentText string `json:"content_text"`
+	MessageID   string `json:"message_id"`
+	// sent or failed
+	Status string `json:"status"`
+	// why the email could not be sent when the status is failed
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+	// values that are not secret and are used to render the template again when the email is resent
+	TemplateData []byte `json:"template_data"`
 }
 
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
 	// can be negative or positive
-	Amount    int64     `json:"amount"`
+	Amount     int64       `json:"amount"`
+	CreatedAt  time.Time   `json:"created_at"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+}
+
+type FeeSchedule struct {
+	ID          int64  `json:"id"`
+	Role        string `json:"role"`
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
+	FlatFee     int64  `json:"flat_fee"`
+	// percentage fee in basis points (1/100 of a percent)
+	PercentFeeBps int64     `json:"percent_fee_bps"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type FraudReview struct {
+	ID            int64  `json:"id"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	RequestedBy   string `json:"requested_by"`
+	// comma separated names of the rules that held the transfer
+	Rules string `json:"rules"`
+	// pending, approved, rejected or cancelled
+	Status     string             `json:"status"`
+	ReviewedBy pgtype.Text        `json:"reviewed_by"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+	TransferID pgtype.Int8        `json:"transfer_id"`
+	CreatedAt  time.Time          `json:"created_at"`
+	Memo       string             `json:"memo"`
+	Reference  string             `json:"reference"`
+	Category   string             `json:"category"`
+	// payment request paid by the held transfer
+	PaymentRequestID pgtype.Int8 `json:"payment_request_id"`
+}
+
+type InterestAccrual struct {
+	ID            int64       `json:"id"`
+	AccountID     int64       `json:"account_id"`
+	AccrualDate   pgtype.Date `json:"accrual_date"`
+	Balance       int64       `json:"balance"`
+	AnnualRateBps int64       `json:"annual_rate_bps"`
+	// whole units of interest accrued on this date
+	Amount int64 `json:"amount"`
+	// fractional interest carried forward to the next accrual
+	Remainder int64 `json:"remainder"`
+	// set when the accrual is posted to the account
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type InterestRatePlan struct {
+	ID          int64  `json:"id"`
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
+	// annual interest rate in basis points (1/100 of a percent)
+	AnnualRateBps int64     `json:"annual_rate_bps"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Notification struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Event    string `json:"event"`
+	Subject  string `json:"subject"`
+	Content  string `json:"content"`
+	// NULL until the user marks the notification as read
+	ReadAt    pgtype.Timestamptz `json:"read_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type NotificationPreference struct {
+	Username string `json:"username"`
+	// transfer_received or new_login
+	Event     string    `json:"event"`
+	Email     bool      `json:"email"`
+	Sms       bool      `json:"sms"`
+	InApp     bool      `json:"in_app"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type PaymentRequest struct {
+	ID        int64  `json:"id"`
+	Requester string `json:"requester"`
+	Payer     string `json:"payer"`
+	// account of the requester that receives the money
+	ToAccountID int64  `json:"to_account_id"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+	Memo        string `json:"memo"`
+	// pending, held, paid, declined, cancelled or expired
+	Status     string      `json:"status"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type PendingOperation struct {
+	ID int64 `json:"id"`
+	// transfer, deposit or withdraw
+	Kind          string      `json:"kind"`
+	FromAccountID pgtype.Int8 `json:"from_account_id"`
+	ToAccountID   pgtype.Int8 `json:"to_account_id"`
+	Amount        int64       `json:"amount"`
+	Currency      string      `json:"currency"`
+	RequestedBy   string      `json:"requested_by"`
+	// pending, approved, rejected, cancelled or expired
+	Status     string             `json:"status"`
+	ReviewedBy pgtype.Text        `json:"reviewed_by"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+	TransferID pgtype.Int8        `json:"transfer_id"`
+	// the operation can no longer be approved after this time
+	ExpiresAt time.Time `json:"expires_at"`
 	CreatedAt time.Time `json:"created_at"`
+	Memo      string    `json:"memo"`
+	Reference string    `json:"reference"`
+	Category  string    `json:"category"`
+}
+
+type ReconciliationReport struct {
+	ID    int64  `json:"id"`
+	RunID string `json:"run_id"`
+	// account_balance or transfer_imbalance
+	Kind       string      `json:"kind"`
+	AccountID  pgtype.Int8 `json:"account_id"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	Expected   int64       `json:"expected"`
+	Actual     int64       `json:"actual"`
+	CreatedAt  time.Time   `json:"created_at"`
 }
 
 type Session struct {
-	ID           string    `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	// hex encoded SHA-256 of the refresh token
+	RefreshTokenHash string    `json:"refresh_token_hash"`
+	UserAgent        string    `json:"user_agent"`
+	ClientIp         string    `json:"client_ip"`
+	IsBlocked        bool      `json:"is_blocked"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	CreatedAt        time.Time `json:"created_at"`
 }
 
 type Transfer struct {
@@ -42,6 +271,28 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	Fee       int64     `json:"fee"`
+	Memo      string    `json:"memo"`
+	// reference given by the sender, such as an invoice number
+	Reference string `json:"reference"`
+	Category  string `json:"category"`
+}
+
+type TransferLimit struct {
+	ID             int64       `json:"id"`
+	Role           string      `json:"role"`
+	AccountType    string      `json:"account_type"`
+	Currency       string      `json:"currency"`
+	PerTransaction pgtype.Int8 `json:"per_transaction"`
+	// total amount sent in the last 24 hours
+	DailyAmount pgtype.Int8 `json:"daily_amount"`
+	// total amount sent in the last 30 days
+	MonthlyAmount pgtype.Int8 `json:"monthly_amount"`
+	// number of transfers sent in the last 24 hours
+	DailyCount pgtype.Int8 `json:"daily_count"`
+	CreatedAt  time.Time   `json:"created_at"`
+	// total amount sent to a beneficiary in the first 24 hours after it was added
+	NewBeneficiaryAmount pgtype.Int8 `json:"new_beneficiary_amount"`
 }
 
 type User struct {
@@ -53,14 +304,40 @@ type User struct {
 	CreatedAt         time.Time `json:"created_at"`
 	IsEmailVerified   bool      `json:"is_email_verified"`
 	Role              string    `json:"role"`
+	// E.164 number used for SMS notifications
+	PhoneNumber string `json:"phone_number"`
+	// language of the emails sent to the user, en or ja
+	Locale string `json:"locale"`
+	// last time the user asked to resend the verification email
+	VerifyEmailRequestedAt pgtype.Timestamptz `json:"verify_email_requested_at"`
+	// incremented on every update and used as the etag for optimistic concurrency
+	Version int64 `json:"version"`
+	// when the user asked to delete the account. personal data is pseudonymized and ledger rows are kept
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type UserTransferLimit struct {
+	ID             int64       `json:"id"`
+	Username       string      `json:"username"`
+	Currency       string      `json:"currency"`
+	PerTransaction pgtype.Int8 `json:"per_transaction"`
+	DailyAmount    pgtype.Int8 `json:"daily_amount"`
+	MonthlyAmount  pgtype.Int8 `json:"monthly_amount"`
+	DailyCount     pgtype.Int8 `json:"daily_count"`
+	UpdatedBy      string      `json:"updated_by"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	CreatedAt      time.Time   `json:"created_at"`
 }
 
 type VerifyEmail struct {
-	ID         int64     `json:"id"`
-	Username   string    `json:"username"`
-	Email      string    `json:"email"`
-	SecretCode string    `json:"secret_code"`
-	IsUsed     bool      `json:"is_used"`
-	CreatedAt  time.Time `json:"created_at"`
-	ExpiresAt  time.Time `json:"expires_at"`
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	// hex encoded SHA-256 of the secret code
+	SecretCodeHash string    `json:"secret_code_hash"`
+	IsUsed         bool      `json:"is_used"`
+	CreatedAt      time.Time `json:"created_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	// number of wrong secret codes tried for this email
+	Attempts int32 `json:"attempts"`
 }
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomNotification(t *testing.T, user User) Notification {
+	arg := CreateNotificationParams{
+		Username: user.Username,
+		Event:    util.NotificationTransferReceived,
+		Subject:  util.RandomString(10),
+		Content:  util.RandomString(20),
+	}
+
+	notification, err := testStore.CreateNotification(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, notification.Username)
+	require.Equal(t, arg.Event, notification.Event)
+	require.Equal(t, arg.Subject, notification.Subject)
+	require.Equal(t, arg.Content, notification.Content)
+	require.False(t, notification.ReadAt.Valid)
+	require.NotZero(t, notification.CreatedAt)
+
+	return notification
+}
+
+func TestListAndMarkNotificationsRead(t *testing.T) {
+	user := createRandomUser(t)
+	other := createRandomUser(t)
+
+	notifications := make([]Notification, 3)
+
+	for i := range notifications {
+		notifications[i] = createRandomNotification(t, user)
+	}
+
+	otherNotification := createRandomNotification(t, other)
+
+	// 他のユーザーの通知は既読にならない
+	read, err := testStore.MarkNotificationsRead(context.Background(), MarkNotificationsReadParams{
+		Username: user.Username,
+		Ids:      []int64{notifications[0].ID, otherNotification.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, read, 1)
+	require.Equal(t, notifications[0].ID, read[0].ID)
+	require.True(t, read[0].ReadAt.Valid)
+
+	// 既読の通知は更新しない
+	read, err = testStore.MarkNotificationsRead(context.Background(), MarkNotificationsReadParams{
+		Username: user.Username,
+		Ids:      []int64{notifications[0].ID},
+	})
+	require.NoError(t, err)
+	require.Empty(t, read)
+
+	unread, err := testStore.CountUnreadNotifications(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), unread)
+
+	list, err := testStore.ListNotifications(context.Background(), ListNotificationsParams{
+		Username:   user.Username,
+		UnreadOnly: true,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	// 新しい順に返す
+	require.Equal(t, notifications[2].ID, list[0].ID)
+	require.Equal(t, notifications[1].ID, list[1].ID)
+
+	list, err = testStore.ListNotifications(context.Background(), ListNotificationsParams{
+		Username: user.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+}
+
+func TestUpsertNotificationPreference(t *testing.T) {
+	user := createRandomUser(t)
+
+	_, err := testStore.GetNotificationPreference(context.Background(), GetNotificationPreferenceParams{
+		Username: user.Username,
+		Event:    util.NotificationNewLogin,
+	})
+	require.True(t, errors.Is(err, ErrorRecordNotFound))
+
+	arg := UpsertNotificationPreferenceParams{
+		Username: user.Username,
+		Event:    util.NotificationNewLogin,
+		Email:    false,
+		Sms:      true,
+		InApp:    true,
+	}
+
+	preference, err := testStore.UpsertNotificationPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, preference.Email)
+	require.True(t, preference.Sms)
+
+	arg.Email = true
+	preference, err = testStore.UpsertNotificationPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, preference.Email)
+
+	preferences, err := testStore.ListNotificationPreferences(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, preferences, 1)
+	require.Equal(t, preference, preferences[0])
+}
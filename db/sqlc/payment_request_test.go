@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPaymentRequest(t *testing.T, requester Account, payer Account, expiresAt time.Time) PaymentRequest {
+	arg := CreatePaymentRequestTxParams{
+		CreatePaymentRequestParams: CreatePaymentRequestParams{
+			Requester:   requester.Owner,
+			Payer:       payer.Owner,
+			ToAccountID: requester.ID,
+			Amount:      10,
+			Currency:    requester.Currency,
+			Memo:        util.RandomString(10),
+			ExpiresAt:   expiresAt,
+		},
+		AfterCreate: func(request PaymentRequest) error { return nil },
+	}
+
+	result, err := testStore.CreatePaymentRequestTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	request := result.PaymentRequest
+	require.Equal(t, arg.Requester, request.Requester)
+	require.Equal(t, arg.Payer, request.Payer)
+	require.Equal(t, arg.Amount, request.Amount)
+	require.Equal(t, arg.Memo, request.Memo)
+	require.Equal(t, util.PaymentRequestPending, request.Status)
+	require.False(t, request.TransferID.Valid)
+
+	return request
+}
+
+func TestPayPaymentRequestTx(t *testing.T) {
+	requester := createRandomAccount(t)
+	payer := createRandomAccount(t)
+
+	request := createRandomPaymentRequest(t, requester, payer, time.Now().Add(time.Hour))
+
+	var notified PaymentRequest
+
+	var transferred TransferTxResult
+
+	result, err := testStore.UpdatePaymentRequestTx(context.Background(), UpdatePaymentRequestTxParams{
+		RequestID: request.ID,
+		Status:    util.PaymentRequestPaid,
+		Transfer: TransferTxParams{
+			FromAccountID: payer.ID,
+			AfterCreate: func(result TransferTxResult) error {
+				transferred = result
+				return nil
+			},
+		},
+		AfterUpdate: func(request PaymentRequest) error {
+			notified = request
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentRequestPaid, result.PaymentRequest.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.PaymentRequest.TransferID.Int64)
+	require.Equal(t, requester.Balance+request.Amount, result.Transfer.ToAccount.Balance)
+	require.Equal(t, result.PaymentRequest, notified)
+	require.Equal(t, result.Transfer.Transfer, transferred.Transfer)
+	require.Equal(t, request.Memo, result.Transfer.Transfer.Memo)
+
+	_, err = testStore.UpdatePaymentRequestTx(context.Background(), UpdatePaymentRequestTxParams{
+		RequestID:   request.ID,
+		Status:      util.PaymentRequestCancelled,
+		AfterUpdate: func(request PaymentRequest) error { return nil },
+	})
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+}
+
+func TestPayPaymentRequestTxHeld(t *testing.T) {
+	requester := createRandomAccount(t)
+	payer := createRandomAccount(t)
+
+	request := createRandomPaymentRequest(t, requester, payer, time.Now().Add(time.Hour))
+
+	var review FraudReview
+
+	result, err := testStore.UpdatePaymentRequestTx(context.Background(), UpdatePaymentRequestTxParams{
+		RequestID: request.ID,
+		Status:    util.PaymentRequestPaid,
+		Transfer: TransferTxParams{
+			FromAccountID: payer.ID,
+			Screen: func(q Querier) (bool, error) {
+				var err error
+
+				review, err = q.CreateFraudReview(context.Background(), CreateFraudReviewParams{
+					FromAccountID: payer.ID,
+					ToAccountID:   requester.ID,
+					Amount:        request.Amount,
+					Currency:      request.Currency,
+					RequestedBy:   payer.Owner,
+					Rules:         "test",
+					Memo:          request.Memo,
+					PaymentRequestID: pgtype.Int8{
+						Int64: request.ID,
+						Valid: true,
+					},
+				})
+
+				return false, err
+			},
+			AfterCreate: func(result TransferTxResult) error {
+				require.Fail(t, "held transfer must not be notified")
+				return nil
+			},
+		},
+		AfterUpdate: func(request PaymentRequest) error {
+			require.Fail(t, "held payment must not be notified")
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Transfer.Held)
+	require.Equal(t, util.PaymentRequestHeld, result.PaymentRequest.Status)
+
+	// 審査を待っている間は支払い直せない
+	_, err = testStore.UpdatePaymentRequestTx(context.Background(), UpdatePaymentRequestTxParams{
+		RequestID:   request.ID,
+		Status:      util.PaymentRequestPaid,
+		Transfer:    TransferTxParams{FromAccountID: payer.ID},
+		AfterUpdate: func(request PaymentRequest) error { return nil },
+	})
+	require.ErrorIs(t, err, ErrPaymentRequestNotPending)
+
+	_, err = testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     payer.ID,
+		Amount: 10000,
+	})
+	require.NoError(t, err)
+
+	banker := createRandomUser(t)
+
+	var paid PaymentRequest
+
+	approved, err := testStore.ReviewFraudTx(context.Background(), ReviewFraudTxParams{
+		ReviewID:   review.ID,
+		ReviewedBy: banker.Username,
+		Approve:    true,
+		AfterPay: func(request PaymentRequest) error {
+			paid = request
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentRequestPaid, approved.PaymentRequest.Status)
+	require.Equal(t, approved.Transfer.Transfer.ID, approved.PaymentRequest.TransferID.Int64)
+	require.Equal(t, request.Memo, approved.Transfer.Transfer.Memo)
+	require.Equal(t, approved.PaymentRequest, paid)
+}
+
+func TestExpirePaymentRequests(t *testing.T) {
+	requester := createRandomAccount(t)
+	payer := createRandomAccount(t)
+
+	request := createRandomPaymentRequest(t, requester, payer, time.Now().Add(-time.Minute))
+
+	_, err := testStore.UpdatePaymentRequestTx(context.Background(), UpdatePaymentRequestTxParams{
+		RequestID:   request.ID,
+		Status:      util.PaymentRequestPaid,
+		Transfer:    TransferTxParams{FromAccountID: payer.ID},
+		AfterUpdate: func(request PaymentRequest) error { return nil },
+	})
+	require.ErrorIs(t, err, ErrOperationExpired)
+
+	// 他のテストの請求も期限切れになるので、すべて処理するまで繰り返す
+	for {
+		expired, err := testStore.ExpirePaymentRequests(context.Background(), 100)
+		require.NoError(t, err)
+
+		if len(expired) < 100 {
+			break
+		}
+	}
+
+	request, err = testStore.GetPaymentRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentRequestExpired, request.Status)
+}
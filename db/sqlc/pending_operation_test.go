@@ -0,0 +1,185 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewPendingOperationTx(t *testing.T) {
+	account := createRandomAccount(t)
+	requester := createRandomUser(t)
+	approver := createRandomUser(t)
+	amount := int64(10)
+
+	operation, err := testStore.RequestOperationTx(context.Background(), CreatePendingOperationParams{
+		Kind: util.OperationDeposit,
+		ToAccountID: pgtype.Int8{
+			Int64: account.ID,
+			Valid: true,
+		},
+		Amount:      amount,
+		Currency:    account.Currency,
+		RequestedBy: requester.Username,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewPending, operation.Status)
+
+	// 依頼した銀行員は承認できない
+	_, err = testStore.ReviewPendingOperationTx(context.Background(), ReviewPendingOperationTxParams{
+		OperationID: operation.ID,
+		ReviewedBy:  requester.Username,
+		Approve:     true,
+	})
+	require.ErrorIs(t, err, ErrSameApprover)
+
+	result, err := testStore.ReviewPendingOperationTx(context.Background(), ReviewPendingOperationTxParams{
+		OperationID: operation.ID,
+		ReviewedBy:  approver.Username,
+		Approve:     true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewApproved, result.Operation.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Operation.TransferID.Int64)
+	require.Equal(t, account.Balance+amount, result.Transfer.ToAccount.Balance)
+
+	_, err = testStore.ReviewPendingOperationTx(context.Background(), ReviewPendingOperationTxParams{
+		OperationID: operation.ID,
+		ReviewedBy:  approver.Username,
+	})
+	require.ErrorIs(t, err, ErrReviewNotPending)
+
+	logs, err := testStore.ListAuditLogs(context.Background(), ListAuditLogsParams{
+		Resource:   util.AuditResourcePendingOperation,
+		ResourceID: strconv.FormatInt(operation.ID, 10),
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	require.Equal(t, util.AuditActionRequest, logs[0].Action)
+	require.Equal(t, requester.Username, logs[0].Actor)
+	require.Equal(t, util.AuditActionApprove, logs[1].Action)
+	require.Equal(t, approver.Username, logs[1].Actor)
+}
+
+func TestReviewPendingTransferKeepsDetails(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	requester := createRandomUser(t)
+	approver := createRandomUser(t)
+
+	operation, err := testStore.RequestOperationTx(context.Background(), CreatePendingOperationParams{
+		Kind: util.OperationTransfer,
+		FromAccountID: pgtype.Int8{
+			Int64: account1.ID,
+			Valid: true,
+		},
+		ToAccountID: pgtype.Int8{
+			Int64: account2.ID,
+			Valid: true,
+		},
+		Amount:      1,
+		Currency:    account1.Currency,
+		RequestedBy: requester.Username,
+		ExpiresAt:   time.Now().Add(time.Hour),
+		Memo:        "invoice 42",
+		Reference:   "INV-42",
+		Category:    "rent",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "invoice 42", operation.Memo)
+
+	result, err := testStore.ReviewPendingOperationTx(context.Background(), ReviewPendingOperationTxParams{
+		OperationID: operation.ID,
+		ReviewedBy:  approver.Username,
+		Approve:     true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewApproved, result.Operation.Status)
+	require.Equal(t, "invoice 42", result.Transfer.Transfer.Memo)
+	require.Equal(t, "INV-42", result.Transfer.Transfer.Reference)
+	require.Equal(t, "rent", result.Transfer.Transfer.Category)
+}
+
+func TestReviewExpiredPendingOperationTx(t *testing.T) {
+	account := createRandomAccount(t)
+	requester := createRandomUser(t)
+	approver := createRandomUser(t)
+
+	operation, err := testStore.RequestOperationTx(context.Background(), CreatePendingOperationParams{
+		Kind: util.OperationWithdraw,
+		FromAccountID: pgtype.Int8{
+			Int64: account.ID,
+			Valid: true,
+		},
+		Amount:      1,
+		Currency:    account.Currency,
+		RequestedBy: requester.Username,
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.ReviewPendingOperationTx(context.Background(), ReviewPendingOperationTxParams{
+		OperationID: operation.ID,
+		ReviewedBy:  approver.Username,
+		Approve:     true,
+	})
+	require.ErrorIs(t, err, ErrOperationExpired)
+
+	// 期限が切れていても却下はできる
+	result, err := testStore.ReviewPendingOperationTx(context.Background(), ReviewPendingOperationTxParams{
+		OperationID: operation.ID,
+		ReviewedBy:  approver.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewRejected, result.Operation.Status)
+	require.False(t, result.Operation.TransferID.Valid)
+}
+
+func TestExpirePendingOperations(t *testing.T) {
+	account := createRandomAccount(t)
+	requester := createRandomUser(t)
+
+	createOperation := func(expiresAt time.Time) PendingOperation {
+		operation, err := testStore.RequestOperationTx(context.Background(), CreatePendingOperationParams{
+			Kind: util.OperationDeposit,
+			ToAccountID: pgtype.Int8{
+				Int64: account.ID,
+				Valid: true,
+			},
+			Amount:      1,
+			Currency:    account.Currency,
+			RequestedBy: requester.Username,
+			ExpiresAt:   expiresAt,
+		})
+		require.NoError(t, err)
+
+		return operation
+	}
+
+	expiredOperation := createOperation(time.Now().Add(-time.Minute))
+	activeOperation := createOperation(time.Now().Add(time.Hour))
+
+	// 他のテストの操作も期限切れになるので、すべて処理するまで繰り返す
+	for {
+		expired, err := testStore.ExpirePendingOperations(context.Background(), 100)
+		require.NoError(t, err)
+
+		if len(expired) < 100 {
+			break
+		}
+	}
+
+	got, err := testStore.GetPendingOperation(context.Background(), expiredOperation.ID)
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewExpired, got.Status)
+
+	got, err = testStore.GetPendingOperation(context.Background(), activeOperation.ID)
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewPending, got.Status)
+}
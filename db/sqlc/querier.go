@@ -10,25 +10,142 @@ import (
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	BlockUserSessions(ctx context.Context, username string) error
+	CancelUserFraudReviews(ctx context.Context, arg CancelUserFraudReviewsParams) error
+	CancelUserPaymentRequests(ctx context.Context, username string) error
+	CancelUserPendingOperations(ctx context.Context, arg CancelUserPendingOperationsParams) error
+	CloseUserAccounts(ctx context.Context, owner string) error
+	CompleteDataExport(ctx context.Context, arg CompleteDataExportParams) (DataExport, error)
+	CountRecentNewPayees(ctx context.Context, arg CountRecentNewPayeesParams) (int64, error)
+	CountUnreadNotifications(ctx context.Context, username string) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAlertEvent(ctx context.Context, arg CreateAlertEventParams) (AlertEvent, error)
+	CreateAlertRule(ctx context.Context, arg CreateAlertRuleParams) (AlertRule, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
+	CreateBeneficiary(ctx context.Context, arg CreateBeneficiaryParams) (Beneficiary, error)
+	CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
+	CreateDataExport(ctx context.Context, username string) (DataExport, error)
+	CreateEmailDelivery(ctx context.Context, arg CreateEmailDeliveryParams) (EmailDelivery, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	CreateFraudReview(ctx context.Context, arg CreateFraudReviewParams) (FraudReview, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestRatePlan(ctx context.Context, arg CreateInterestRatePlanParams) (InterestRatePlan, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (PaymentRequest, error)
+	CreatePendingOperation(ctx context.Context, arg CreatePendingOperationParams) (PendingOperation, error)
+	CreateReconciliationReport(ctx context.Context, arg CreateReconciliationReportParams) (ReconciliationReport, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferLimit(ctx context.Context, arg CreateTransferLimitParams) (TransferLimit, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAlertRule(ctx context.Context, id int64) error
+	DeleteBeneficiary(ctx context.Context, id int64) error
+	DeleteFeeSchedule(ctx context.Context, id int64) error
+	DeleteUserAlertRules(ctx context.Context, owner string) error
+	DeleteUserBeneficiaries(ctx context.Context, arg DeleteUserBeneficiariesParams) error
+	DeleteUserDataExports(ctx context.Context, username string) error
+	DeleteUserEmailDeliveries(ctx context.Context, arg DeleteUserEmailDeliveriesParams) error
+	DeleteUserNotificationPreferences(ctx context.Context, username string) error
+	DeleteUserNotifications(ctx context.Context, username string) error
+	DeleteUserVerifyEmails(ctx context.Context, username string) ([]VerifyEmail, error)
+	ExpirePaymentRequests(ctx context.Context, limit int32) ([]PaymentRequest, error)
+	ExpirePendingOperations(ctx context.Context, limit int32) ([]PendingOperation, error)
+	FailDataExport(ctx context.Context, arg FailDataExportParams) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountByOwner(ctx context.Context, arg GetAccountByOwnerParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAlertEvent(ctx context.Context, arg GetAlertEventParams) (AlertEvent, error)
+	GetAlertRule(ctx context.Context, id int64) (AlertRule, error)
+	GetAverageTransferAmount(ctx context.Context, arg GetAverageTransferAmountParams) (GetAverageTransferAmountRow, error)
+	GetBeneficiary(ctx context.Context, id int64) (Beneficiary, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
+	GetDataExport(ctx context.Context, id int64) (DataExport, error)
+	GetEmailDelivery(ctx context.Context, id int64) (EmailDelivery, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetFeeSchedule(ctx context.Context, arg GetFeeScheduleParams) (FeeSchedule, error)
+	GetFeeScheduleForAccount(ctx context.Context, id int64) (FeeSchedule, error)
+	GetFraudReview(ctx context.Context, id int64) (FraudReview, error)
+	GetFraudReviewForUpdate(ctx context.Context, id int64) (FraudReview, error)
+	GetInterestRatePlan(ctx context.Context, arg GetInterestRatePlanParams) (InterestRatePlan, error)
+	GetLatestInterestAccrual(ctx context.Context, arg GetLatestInterestAccrualParams) (InterestAccrual, error)
+	GetNewBeneficiaryForTransfer(ctx context.Context, arg GetNewBeneficiaryForTransferParams) (Beneficiary, error)
+	GetNotificationPreference(ctx context.Context, arg GetNotificationPreferenceParams) (NotificationPreference, error)
+	GetPaymentRequest(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPaymentRequestForUpdate(ctx context.Context, id int64) (PaymentRequest, error)
+	GetPendingOperation(ctx context.Context, id int64) (PendingOperation, error)
+	GetPendingOperationForUpdate(ctx context.Context, id int64) (PendingOperation, error)
+	GetRecentLoginFromNewIP(ctx context.Context, arg GetRecentLoginFromNewIPParams) (Session, error)
 	GetSession(ctx context.Context, id string) (Session, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferLimitForAccount(ctx context.Context, id int64) (GetTransferLimitForAccountRow, error)
+	GetTransferTotalTo(ctx context.Context, arg GetTransferTotalToParams) (int64, error)
+	GetTransferTotals(ctx context.Context, fromAccountID int64) (GetTransferTotalsRow, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	GetUserTransferLimit(ctx context.Context, arg GetUserTransferLimitParams) (UserTransferLimit, error)
+	GetVerifyEmailForUpdate(ctx context.Context, id int64) (VerifyEmail, error)
+	HasTransferredTo(ctx context.Context, arg HasTransferredToParams) (bool, error)
+	IncrementVerifyEmailAttempts(ctx context.Context, id int64) (VerifyEmail, error)
+	InvalidateVerifyEmails(ctx context.Context, username string) error
+	IsNewPayee(ctx context.Context, arg IsNewPayeeParams) (bool, error)
+	IsUserDeleted(ctx context.Context, username string) (bool, error)
+	ListAccountEntryTotals(ctx context.Context, arg ListAccountEntryTotalsParams) ([]ListAccountEntryTotalsRow, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsByOwner(ctx context.Context, owner string) ([]Account, error)
+	ListAccountsByOwnerForUpdate(ctx context.Context, owner string) ([]Account, error)
+	ListAccountsDesc(ctx context.Context, arg ListAccountsDescParams) ([]Account, error)
+	ListAccountsForAccrual(ctx context.Context, arg ListAccountsForAccrualParams) ([]ListAccountsForAccrualRow, error)
+	ListAccountsWithUnpostedInterest(ctx context.Context, arg ListAccountsWithUnpostedInterestParams) ([]int64, error)
+	ListAlertRules(ctx context.Context, owner string) ([]AlertRule, error)
+	ListAlertRulesForTransferForUpdate(ctx context.Context, arg ListAlertRulesForTransferForUpdateParams) ([]AlertRule, error)
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error)
+	ListBeneficiaries(ctx context.Context, arg ListBeneficiariesParams) ([]Beneficiary, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListEntriesByOwner(ctx context.Context, owner string) ([]Entry, error)
+	ListEntriesDesc(ctx context.Context, arg ListEntriesDescParams) ([]Entry, error)
+	ListFeeSchedules(ctx context.Context) ([]FeeSchedule, error)
+	ListFraudReviews(ctx context.Context, arg ListFraudReviewsParams) ([]FraudReview, error)
+	ListIncomingPaymentRequests(ctx context.Context, arg ListIncomingPaymentRequestsParams) ([]PaymentRequest, error)
+	ListInterestRatePlans(ctx context.Context) ([]InterestRatePlan, error)
+	ListNotificationPreferences(ctx context.Context, username string) ([]NotificationPreference, error)
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	ListOutgoingPaymentRequests(ctx context.Context, arg ListOutgoingPaymentRequestsParams) ([]PaymentRequest, error)
+	ListPendingOperations(ctx context.Context, arg ListPendingOperationsParams) ([]PendingOperation, error)
+	ListReconciliationReports(ctx context.Context, arg ListReconciliationReportsParams) ([]ReconciliationReport, error)
+	ListSessionsByUsername(ctx context.Context, username string) ([]Session, error)
+	ListTransferEntryTotals(ctx context.Context, arg ListTransferEntryTotalsParams) ([]ListTransferEntryTotalsRow, error)
+	ListTransferLimits(ctx context.Context) ([]TransferLimit, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
-	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	ListTransfersByOwner(ctx context.Context, owner string) ([]Transfer, error)
+	ListTransfersDesc(ctx context.Context, arg ListTransfersDescParams) ([]Transfer, error)
+	ListUnpostedInterestAccrualsForUpdate(ctx context.Context, arg ListUnpostedInterestAccrualsForUpdateParams) ([]InterestAccrual, error)
+	ListUsersByRole(ctx context.Context, role string) ([]User, error)
+	ListVerifyEmailsByUsername(ctx context.Context, username string) ([]VerifyEmail, error)
+	LockAccountForTransferLimit(ctx context.Context, pgAdvisoryXactLock int64) error
+	LockOwnerForNewBeneficiaryLimit(ctx context.Context, id int64) error
+	MarkAlertEventDelivered(ctx context.Context, id int64) (AlertEvent, error)
+	MarkInterestAccrualsPosted(ctx context.Context, arg MarkInterestAccrualsPostedParams) error
+	MarkNotificationsRead(ctx context.Context, arg MarkNotificationsReadParams) ([]Notification, error)
+	PseudonymizeUser(ctx context.Context, arg PseudonymizeUserParams) (User, error)
+	RehashUserPassword(ctx context.Context, arg RehashUserPasswordParams) error
+	RequestVerifyEmail(ctx context.Context, arg RequestVerifyEmailParams) (User, error)
+	ResolveHeldPaymentRequest(ctx context.Context, arg ResolveHeldPaymentRequestParams) (PaymentRequest, error)
+	ScrubUserSessions(ctx context.Context, username string) error
+	SearchEmailDeliveries(ctx context.Context, arg SearchEmailDeliveriesParams) ([]EmailDelivery, error)
+	SearchTransactions(ctx context.Context, arg SearchTransactionsParams) ([]SearchTransactionsRow, error)
+	UpdateAlertRuleEvaluation(ctx context.Context, arg UpdateAlertRuleEvaluationParams) (AlertRule, error)
+	UpdateFraudReviewStatus(ctx context.Context, arg UpdateFraudReviewStatusParams) (FraudReview, error)
+	UpdatePaymentRequestStatus(ctx context.Context, arg UpdatePaymentRequestStatusParams) (PaymentRequest, error)
+	UpdatePendingOperationStatus(ctx context.Context, arg UpdatePendingOperationStatusParams) (PendingOperation, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
-	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpdateVerifyEmail(ctx context.Context, id int64) (VerifyEmail, error)
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (NotificationPreference, error)
+	UpsertUserTransferLimit(ctx context.Context, arg UpsertUserTransferLimitParams) (UserTransferLimit, error)
 }
 
 var _ Querier = (*Queries)(nil)
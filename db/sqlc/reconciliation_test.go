@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateReconciliationReport(t *testing.T) {
+	account := createRandomAccount(t)
+
+	arg := CreateReconciliationReportParams{
+		RunID: uuid.NewString(),
+		Kind:  "account_balance",
+		AccountID: pgtype.Int8{
+			Int64: account.ID,
+			Valid: true,
+		},
+		Expected: 0,
+		Actual:   account.Balance,
+	}
+
+	report, err := testStore.CreateReconciliationReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, report)
+
+	require.Equal(t, arg.RunID, report.RunID)
+	require.Equal(t, arg.Kind, report.Kind)
+	require.Equal(t, arg.AccountID, report.AccountID)
+	require.False(t, report.TransferID.Valid)
+	require.Equal(t, arg.Expected, report.Expected)
+	require.Equal(t, arg.Actual, report.Actual)
+	require.NotZero(t, report.CreatedAt)
+}
+
+func TestListAccountEntryTotals(t *testing.T) {
+	account := createRandomAccount(t)
+
+	entry1, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: account.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+
+	entry2, err := testStore.CreateEntry(context.Background(), CreateEntryParams{
+		AccountID: account.ID,
+		Amount:    -3,
+	})
+	require.NoError(t, err)
+
+	rows, err := testStore.ListAccountEntryTotals(context.Background(), ListAccountEntryTotalsParams{
+		AfterID: account.ID - 1,
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	require.Equal(t, account.ID, rows[0].ID)
+	require.Equal(t, account.Balance, rows[0].Balance)
+	require.Equal(t, entry1.Amount+entry2.Amount, rows[0].EntriesTotal)
+}
+
+func TestListTransferEntryTotals(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	rows, err := testStore.ListTransferEntryTotals(context.Background(), ListTransferEntryTotalsParams{
+		AfterID: result.Transfer.ID - 1,
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	require.Equal(t, result.Transfer.ID, rows[0].ID)
+	require.GreaterOrEqual(t, rows[0].EntryCount, int64(2))
+	require.Zero(t, rows[0].EntriesTotal)
+}
@@ -10,35 +10,48 @@ import (
 	"time"
 )
 
+const blockUserSessions = `-- name: BlockUserSessions :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1
+  AND is_blocked = false
+  AND expires_at > now()
+`
+
+func (q *Queries) BlockUserSessions(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, blockUserSessions, username)
+	return err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
     id,
     username,
-    refresh_token,
+    refresh_token_hash,
     user_agent,
     client_ip,
     is_blocked,
     expires_at
   )
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+RETURNING id, username, refresh_token_hash, user_agent, client_ip, is_blocked, expires_at, created_at
 `
 
 type CreateSessionParams struct {
-	ID           string    `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiresAt    time.Time `json:"expires_at"`
+	ID               string    `json:"id"`
+	Username         string    `json:"username"`
+	RefreshTokenHash string    `json:"refresh_token_hash"`
+	UserAgent        string    `json:"user_agent"`
+	ClientIp         string    `json:"client_ip"`
+	IsBlocked        bool      `json:"is_blocked"`
+	ExpiresAt        time.Time `json:"expires_at"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
 	row := q.db.QueryRow(ctx, createSession,
 		arg.ID,
 		arg.Username,
-		arg.RefreshToken,
+		arg.RefreshTokenHash,
 		arg.UserAgent,
 		arg.ClientIp,
 		arg.IsBlocked,
@@ -48,7 +61,7 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 	err := row.Scan(
 		&i.ID,
 		&i.Username,
-		&i.RefreshToken,
+		&i.RefreshTokenHash,
 		&i.UserAgent,
 		&i.ClientIp,
 		&i.IsBlocked,
@@ -59,7 +72,7 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, username, refresh_token, user_agent, client_ip, is_blocked, expires_at, created_at
+SELECT id, username, refresh_token_hash, user_agent, client_ip, is_blocked, expires_at, created_at
 FROM sessions
 WHERE id = $1
 LIMIT 1
@@ -71,7 +84,7 @@ func (q *Queries) GetSession(ctx context.Context, id string) (Session, error) {
 	err := row.Scan(
 		&i.ID,
 		&i.Username,
-		&i.RefreshToken,
+		&i.RefreshTokenHash,
 		&i.UserAgent,
 		&i.ClientIp,
 		&i.IsBlocked,
@@ -80,3 +93,53 @@ func (q *Queries) GetSession(ctx context.Context, id string) (Session, error) {
 	)
 	return i, err
 }
+
+const listSessionsByUsername = `-- name: ListSessionsByUsername :many
+SELECT id, username, refresh_token_hash, user_agent, client_ip, is_blocked, expires_at, created_at
+FROM sessions
+WHERE username = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListSessionsByUsername(ctx context.Context, username string) ([]Session, error) {
+	rows, err := q.db.Query(ctx, listSessionsByUsername, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.RefreshTokenHash,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.IsBlocked,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const scrubUserSessions = `-- name: ScrubUserSessions :exec
+UPDATE sessions
+SET is_blocked = true,
+  client_ip = '',
+  user_agent = ''
+WHERE username = $1
+`
+
+// 削除したユーザーのセッションを使えなくして、接続元の情報を消す
+func (q *Queries) ScrubUserSessions(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, scrubUserSessions, username)
+	return err
+}
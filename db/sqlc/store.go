@@ -16,6 +16,23 @@ type Store interface {
 		CreateUserTxResult, error)
 	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (
 		VerifyEmailTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (
+		UpdateUserTxResult, error)
+	ResendVerifyEmailTx(ctx context.Context, arg ResendVerifyEmailTxParams) (
+		ResendVerifyEmailTxResult, error)
+	DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error)
+	WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error)
+	AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (AccrueInterestTxResult, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	ReviewFraudTx(ctx context.Context, arg ReviewFraudTxParams) (ReviewFraudTxResult, error)
+	RequestOperationTx(ctx context.Context, arg CreatePendingOperationParams) (PendingOperation, error)
+	ReviewPendingOperationTx(ctx context.Context, arg ReviewPendingOperationTxParams) (ReviewPendingOperationTxResult, error)
+	CreateBeneficiaryTx(ctx context.Context, arg CreateBeneficiaryTxParams) (CreateBeneficiaryTxResult, error)
+	CreatePaymentRequestTx(ctx context.Context, arg CreatePaymentRequestTxParams) (CreatePaymentRequestTxResult, error)
+	UpdatePaymentRequestTx(ctx context.Context, arg UpdatePaymentRequestTxParams) (UpdatePaymentRequestTxResult, error)
+	EvaluateAlertsTx(ctx context.Context, arg EvaluateAlertsTxParams) (EvaluateAlertsTxResult, error)
+	CreateDataExportTx(ctx context.Context, arg CreateDataExportTxParams) (CreateDataExportTxResult, error)
+	DeleteUserTx(ctx context.Context, arg DeleteUserTxParams) (DeleteUserTxResult, error)
 }
 
 type SQLStore struct {
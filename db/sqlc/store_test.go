@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/shouta0715/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -53,6 +55,8 @@ func TestTransferTx(t *testing.T) {
 		require.Equal(t, account1.ID, transfer.FromAccountID)
 		require.Equal(t, account2.ID, transfer.ToAccountID)
 		require.Equal(t, amount, transfer.Amount)
+		require.Zero(t, transfer.Fee)
+		require.Zero(t, result.Fee)
 
 		require.NotZero(t, transfer.ID)
 		require.NotZero(t, transfer.CreatedAt)
@@ -166,3 +170,134 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxInsufficientBalance(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	// 呼び出し側が読んだ残高ではそれぞれ足りても、同時に送った合計は残高を超える
+	n := 2
+	amount := account1.Balance/2 + 1
+
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account1.ID,
+				ToAccountID:   account2.ID,
+				Amount:        amount,
+			})
+
+			errs <- err
+		}()
+	}
+
+	var failed int
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+
+		if err != nil {
+			require.ErrorIs(t, err, ErrInsufficientBalance)
+			failed++
+		}
+	}
+
+	require.Equal(t, 1, failed)
+
+	updatedAccount1, err := testStore.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, updatedAccount1.Balance, int64(0))
+	require.LessOrEqual(t, updatedAccount1.Balance, account1.Balance-amount)
+}
+
+func TestDepositAndWithdrawTx(t *testing.T) {
+	account := createRandomAccount(t)
+	amount := int64(10)
+
+	depositResult, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account.ID, depositResult.Account.ID)
+	require.Equal(t, account.Balance+amount, depositResult.Account.Balance)
+	require.Equal(t, account.ID, depositResult.Entry.AccountID)
+	require.Equal(t, amount, depositResult.Entry.Amount)
+	require.Equal(t, -amount, depositResult.VaultEntry.Amount)
+	require.Equal(t, depositResult.VaultEntry.AccountID, depositResult.Transfer.FromAccountID)
+	require.Equal(t, account.ID, depositResult.Transfer.ToAccountID)
+
+	withdrawResult, err := testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    amount,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account.Balance, withdrawResult.Account.Balance)
+	require.Equal(t, -amount, withdrawResult.Entry.Amount)
+	require.Equal(t, amount, withdrawResult.VaultEntry.Amount)
+
+	// 残高以上の出金はロールバックされる
+	_, err = testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    account.Balance + 1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientBalance)
+
+	updatedAccount, err := testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, updatedAccount.Balance)
+}
+
+func TestAccrueAndPostInterestTx(t *testing.T) {
+	account := createRandomAccount(t)
+	day1 := time.Date(2023, time.January, 30, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	arg := AccrueInterestTxParams{
+		AccountID:     account.ID,
+		AccrualDate:   day1,
+		Balance:       util.InterestRemainderUnit,
+		AnnualRateBps: 100,
+	}
+
+	accrued1, err := testStore.AccrueInterestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, accrued1.Created)
+	require.Equal(t, int64(100), accrued1.Accrual.Amount)
+
+	// 同じ日付の再実行では計上されない
+	rerun, err := testStore.AccrueInterestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, rerun.Created)
+
+	arg.AccrualDate = day2
+	accrued2, err := testStore.AccrueInterestTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, accrued2.Created)
+
+	posted, err := testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		PeriodEnd: day2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, accrued1.Accrual.Amount+accrued2.Accrual.Amount, posted.Amount)
+	require.Equal(t, account.Balance+posted.Amount, posted.Account.Balance)
+	require.Equal(t, posted.Amount, posted.Entry.Amount)
+	require.Equal(t, -posted.Amount, posted.ExpenseEntry.Amount)
+
+	// 支払い済みの利息は二重に支払われない
+	reposted, err := testStore.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: account.ID,
+		PeriodEnd: day2,
+	})
+	require.NoError(t, err)
+	require.Zero(t, reposted.Amount)
+
+	updatedAccount, err := testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, posted.Account.Balance, updatedAccount.Balance)
+}
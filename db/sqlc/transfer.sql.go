@@ -7,22 +7,44 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
-INSERT INTO transfers (from_account_id, to_account_id, amount)
-VALUES ($1, $2, $3)
-RETURNING id, from_account_id, to_account_id, amount, created_at
+INSERT INTO transfers (
+    from_account_id,
+    to_account_id,
+    amount,
+    fee,
+    memo,
+    reference,
+    category
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, from_account_id, to_account_id, amount, created_at, fee, memo, reference, category
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Fee           int64  `json:"fee"`
+	Memo          string `json:"memo"`
+	Reference     string `json:"reference"`
+	Category      string `json:"category"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRow(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Fee,
+		arg.Memo,
+		arg.Reference,
+		arg.Category,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -30,12 +52,16 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Fee,
+		&i.Memo,
+		&i.Reference,
+		&i.Category,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at
+SELECT id, from_account_id, to_account_id, amount, created_at, fee, memo, reference, category
 FROM transfers
 WHERE id = $1
 LIMIT 1
@@ -50,31 +76,160 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Fee,
+		&i.Memo,
+		&i.Reference,
+		&i.Category,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at
+SELECT id, from_account_id, to_account_id, amount, created_at, fee, memo, reference, category
 FROM transfers
-WHERE from_account_id = $1
-  OR to_account_id = $2
-LIMIT $3 OFFSET $4
+WHERE (
+    from_account_id = $1
+    OR to_account_id = $2
+  )
+  AND (
+    $3::timestamptz IS NULL
+    OR (created_at, id) > (
+      $3::timestamptz,
+      $4::bigint
+    )
+  )
+ORDER BY created_at ASC,
+  id ASC
+LIMIT $5
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	FromAccountID   int64              `json:"from_account_id"`
+	ToAccountID     int64              `json:"to_account_id"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
 }
 
+// 口座から送金した、または口座が受け取った送金を古い順に cursor の次から返す
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
 	rows, err := q.db.Query(ctx, listTransfers,
 		arg.FromAccountID,
 		arg.ToAccountID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Fee,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersByOwner = `-- name: ListTransfersByOwner :many
+SELECT id, from_account_id, to_account_id, amount, created_at, fee, memo, reference, category
+FROM transfers
+WHERE from_account_id IN (
+    SELECT id
+    FROM accounts
+    WHERE owner = $1
+  )
+  OR to_account_id IN (
+    SELECT id
+    FROM accounts
+    WHERE owner = $1
+  )
+ORDER BY id
+`
+
+// ユーザーの口座から送金した、またはユーザーの口座が受け取った送金を返す
+func (q *Queries) ListTransfersByOwner(ctx context.Context, owner string) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransfersByOwner, owner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.Fee,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransfersDesc = `-- name: ListTransfersDesc :many
+SELECT id, from_account_id, to_account_id, amount, created_at, fee, memo, reference, category
+FROM transfers
+WHERE (
+    from_account_id = $1
+    OR to_account_id = $2
+  )
+  AND (
+    $3::timestamptz IS NULL
+    OR (created_at, id) < (
+      $3::timestamptz,
+      $4::bigint
+    )
+  )
+ORDER BY created_at DESC,
+  id DESC
+LIMIT $5
+`
+
+type ListTransfersDescParams struct {
+	FromAccountID   int64              `json:"from_account_id"`
+	ToAccountID     int64              `json:"to_account_id"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	Limit           int32              `json:"limit"`
+}
+
+// 口座から送金した、または口座が受け取った送金を新しい順に cursor の次から返す
+func (q *Queries) ListTransfersDesc(ctx context.Context, arg ListTransfersDescParams) ([]Transfer, error) {
+	rows, err := q.db.Query(ctx, listTransfersDesc,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
 		arg.Limit,
-		arg.Offset,
 	)
 	if err != nil {
 		return nil, err
@@ -89,6 +244,10 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Fee,
+			&i.Memo,
+			&i.Reference,
+			&i.Category,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	LimitPerTransaction = "per_transaction"
+	LimitDailyAmount    = "daily_amount"
+	LimitMonthlyAmount  = "monthly_amount"
+	LimitDailyCount     = "daily_count"
+	// 追加してから24時間以内の受取人や、新しい送金先への送金の合計
+	LimitNewBeneficiary = "new_beneficiary_amount"
+)
+
+// TransferLimitError は送金が上限を超えたときに返すエラー
+type TransferLimitError struct {
+	// 超えた上限の種類 (LimitPerTransaction など)
+	Limit string
+	Max   int64
+	// 上限までの残り。daily_count の場合は送金回数、それ以外は金額
+	Remaining int64
+}
+
+func (e *TransferLimitError) Error() string {
+	return fmt.Sprintf("transfer exceeds %s limit of %d: remaining %d", e.Limit, e.Max, e.Remaining)
+}
+
+// checkTransferLimit は送金元の口座の role・口座種別・ユーザーごとの上限を超えていないか確認する
+// 直近の送金の集計は同じ口座からの送金を advisory lock で直列にしてから行う
+func checkTransferLimit(ctx context.Context, q *Queries, arg TransferTxParams) error {
+	accountID := arg.FromAccountID
+	amount := arg.Amount
+
+	limit, err := q.GetTransferLimitForAccount(ctx, accountID)
+
+	if err != nil {
+		if errors.Is(err, ErrorRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !limit.PerTransaction.Valid && !limit.DailyAmount.Valid &&
+		!limit.MonthlyAmount.Valid && !limit.DailyCount.Valid &&
+		!limit.NewBeneficiaryAmount.Valid {
+		return nil
+	}
+
+	if err := exceedsLimit(LimitPerTransaction, limit.PerTransaction, 0, amount); err != nil {
+		return err
+	}
+
+	// 口座の行ロックとは別に取るので、addMoney のロック順序には影響しない
+	err = q.LockAccountForTransferLimit(ctx, accountID)
+
+	if err != nil {
+		return err
+	}
+
+	totals, err := q.GetTransferTotals(ctx, accountID)
+
+	if err != nil {
+		return err
+	}
+
+	if err := exceedsLimit(LimitDailyAmount, limit.DailyAmount, totals.DailyAmount, amount); err != nil {
+		return err
+	}
+
+	if err := exceedsLimit(LimitMonthlyAmount, limit.MonthlyAmount, totals.MonthlyAmount, amount); err != nil {
+		return err
+	}
+
+	if err := exceedsLimit(LimitDailyCount, limit.DailyCount, totals.DailyCount, 1); err != nil {
+		return err
+	}
+
+	if !limit.NewBeneficiaryAmount.Valid {
+		return nil
+	}
+
+	return checkNewBeneficiaryLimit(ctx, q, arg, limit.NewBeneficiaryAmount)
+}
+
+// checkNewBeneficiaryLimit は送金先が追加したばかりの受取人か新しい送金先の場合に、直近の送金の合計を確認する
+// 受取人として登録せずに送金しても上限を回避できないように、24時間より前に送金したことがない口座は新しい送金先として扱う
+func checkNewBeneficiaryLimit(ctx context.Context, q *Queries, arg TransferTxParams, max pgtype.Int8) error {
+	since := time.Now().Add(-24 * time.Hour)
+
+	beneficiary, err := q.GetNewBeneficiaryForTransfer(ctx, GetNewBeneficiaryForTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+	})
+
+	switch {
+	case err == nil:
+		since = beneficiary.CreatedAt
+	case errors.Is(err, ErrorRecordNotFound):
+		isNewPayee, err := q.IsNewPayee(ctx, IsNewPayeeParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if !isNewPayee {
+			return nil
+		}
+	default:
+		return err
+	}
+
+	// 上限は口座ごとではなく持ち主ごとにかける
+	err = q.LockOwnerForNewBeneficiaryLimit(ctx, arg.FromAccountID)
+
+	if err != nil {
+		return err
+	}
+
+	total, err := q.GetTransferTotalTo(ctx, GetTransferTotalToParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Since:         since,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return exceedsLimit(LimitNewBeneficiary, max, total, arg.Amount)
+}
+
+func exceedsLimit(name string, max pgtype.Int8, used int64, requested int64) error {
+	if !max.Valid || used+requested <= max.Int64 {
+		return nil
+	}
+
+	remaining := max.Int64 - used
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &TransferLimitError{
+		Limit:     name,
+		Max:       max.Int64,
+		Remaining: remaining,
+	}
+}
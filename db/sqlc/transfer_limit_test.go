@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func setUserTransferLimit(t *testing.T, account Account, arg UpsertUserTransferLimitParams) UserTransferLimit {
+	banker := createRandomUser(t)
+
+	arg.Username = account.Owner
+	arg.Currency = account.Currency
+	arg.UpdatedBy = banker.Username
+
+	limit, err := testStore.UpsertUserTransferLimit(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.Username, limit.Username)
+	require.Equal(t, arg.Currency, limit.Currency)
+	require.Equal(t, arg.PerTransaction, limit.PerTransaction)
+	require.Equal(t, arg.DailyCount, limit.DailyCount)
+	require.Equal(t, arg.UpdatedBy, limit.UpdatedBy)
+
+	return limit
+}
+
+func TestTransferTxPerTransactionLimit(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	setUserTransferLimit(t, account1, UpsertUserTransferLimitParams{
+		PerTransaction: pgtype.Int8{Int64: 5, Valid: true},
+	})
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, LimitPerTransaction, limitErr.Limit)
+	require.Equal(t, int64(5), limitErr.Max)
+	require.Equal(t, int64(5), limitErr.Remaining)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        5,
+	})
+	require.NoError(t, err)
+}
+
+func TestTransferTxDailyLimits(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	setUserTransferLimit(t, account1, UpsertUserTransferLimitParams{
+		DailyAmount: pgtype.Int8{Int64: 15, Valid: true},
+		DailyCount:  pgtype.Int8{Int64: 2, Valid: true},
+	})
+
+	transfer := func(amount int64) error {
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		return err
+	}
+
+	require.NoError(t, transfer(10))
+
+	var limitErr *TransferLimitError
+	require.ErrorAs(t, transfer(10), &limitErr)
+	require.Equal(t, LimitDailyAmount, limitErr.Limit)
+	require.Equal(t, int64(5), limitErr.Remaining)
+
+	require.NoError(t, transfer(5))
+
+	require.ErrorAs(t, transfer(1), &limitErr)
+	require.Equal(t, LimitDailyCount, limitErr.Limit)
+	require.Zero(t, limitErr.Remaining)
+}
+
+func TestTransferTxLimitsIgnoreWithdrawals(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	setUserTransferLimit(t, account1, UpsertUserTransferLimitParams{
+		DailyAmount: pgtype.Int8{Int64: 15, Valid: true},
+		DailyCount:  pgtype.Int8{Int64: 1, Valid: true},
+	})
+
+	// 出金はシステム口座への送金として記録するが、送金上限には数えない
+	_, err := testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account1.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+
+	totals, err := testStore.GetTransferTotals(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Zero(t, totals.DailyAmount)
+	require.Zero(t, totals.DailyCount)
+	require.Zero(t, totals.MonthlyAmount)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        15,
+	})
+	require.NoError(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shouta0715/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
@@ -14,6 +15,10 @@ func createRandomTransfer(t *testing.T, account1, account2 Account) Transfer {
 		FromAccountID: account1.ID,
 		ToAccountID:   account2.ID,
 		Amount:        util.RandomMoney(),
+		Fee:           util.RandomMoney(),
+		Memo:          util.RandomString(10),
+		Reference:     util.RandomString(6),
+		Category:      util.CategoryOther,
 	}
 
 	transfer, err := testStore.CreateTransfer(context.Background(), arg)
@@ -24,6 +29,10 @@ func createRandomTransfer(t *testing.T, account1, account2 Account) Transfer {
 	require.Equal(t, arg.FromAccountID, transfer.FromAccountID)
 	require.Equal(t, arg.ToAccountID, transfer.ToAccountID)
 	require.Equal(t, arg.Amount, transfer.Amount)
+	require.Equal(t, arg.Fee, transfer.Fee)
+	require.Equal(t, arg.Memo, transfer.Memo)
+	require.Equal(t, arg.Reference, transfer.Reference)
+	require.Equal(t, arg.Category, transfer.Category)
 
 	require.NotZero(t, transfer.ID)
 	require.NotZero(t, transfer.CreatedAt)
@@ -69,7 +78,6 @@ func TestListTransfers(t *testing.T) {
 		FromAccountID: account1.ID,
 		ToAccountID:   account1.ID,
 		Limit:         5,
-		Offset:        5,
 	}
 
 	transfers, err := testStore.ListTransfers(context.Background(), arg)
@@ -80,6 +88,15 @@ func TestListTransfers(t *testing.T) {
 	for _, transfer := range transfers {
 		require.NotEmpty(t, transfer)
 		require.True(t, transfer.FromAccountID == account1.ID || transfer.ToAccountID == account1.ID)
-
 	}
+
+	last := transfers[len(transfers)-1]
+	arg.CursorCreatedAt = pgtype.Timestamptz{Time: last.CreatedAt, Valid: true}
+	arg.CursorID = pgtype.Int8{Int64: last.ID, Valid: true}
+
+	transfers, err = testStore.ListTransfers(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.Len(t, transfers, 5)
+	require.Greater(t, transfers[0].ID, last.ID)
 }
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shouta0715/simple-bank/util"
+)
+
+type EvaluateAlertsTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// 送金した直後の口座ごとの残高。入っていない口座は評価するときの残高を使う
+	Balances map[int64]int64 `json:"balances"`
+}
+
+// Alert は届ける通知と、その元になったルールと口座
+type Alert struct {
+	Rule    AlertRule  `json:"rule"`
+	Event   AlertEvent `json:"event"`
+	Account Account    `json:"account"`
+}
+
+type EvaluateAlertsTxResult struct {
+	Transfer Transfer `json:"transfer"`
+	// まだ届けていない通知。前回の処理で届けられなかった通知も含む
+	Alerts []Alert `json:"alerts"`
+}
+
+// EvaluateAlertsTx は送金元と送金先の口座のルールを評価し、届ける通知を記録する
+// 残高のルールは、評価するまでの間に他の送金で残高が変わっても、送金した直後の残高で評価する
+// 同じ送金で同じルールの通知は1回だけ記録する。残高のルールは閾値を下回ったときに1回だけ通知し、
+// 残高が閾値以上に戻るまで次の通知をしない。タスクの順番が入れ替わった場合は、
+// 最後に評価した送金より古い送金の残高ではルールの状態を変えない
+
+func (store *SQLStore) EvaluateAlertsTx(ctx context.Context, arg EvaluateAlertsTxParams) (EvaluateAlertsTxResult, error) {
+	var result EvaluateAlertsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Transfer, err = q.GetTransfer(ctx, arg.TransferID)
+
+		if err != nil {
+			return err
+		}
+
+		rules, err := q.ListAlertRulesForTransferForUpdate(ctx, ListAlertRulesForTransferForUpdateParams{
+			FromAccountID: result.Transfer.FromAccountID,
+			ToAccountID:   result.Transfer.ToAccountID,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, rule := range rules {
+			alert, fire, err := evaluateAlertRule(ctx, q, rule, result.Transfer, arg.Balances)
+
+			if err != nil {
+				return err
+			}
+
+			if fire {
+				result.Alerts = append(result.Alerts, alert)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// evaluateAlertRule は1つのルールを評価し、届ける通知がある場合は true を返す
+func evaluateAlertRule(ctx context.Context, q *Queries, rule AlertRule, transfer Transfer, balances map[int64]int64) (Alert, bool, error) {
+	alert := Alert{Rule: rule}
+
+	account, err := q.GetAccount(ctx, rule.AccountID)
+
+	if err != nil {
+		return alert, false, err
+	}
+
+	if balance, ok := balances[account.ID]; ok {
+		account.Balance = balance
+	}
+
+	alert.Account = account
+
+	// 再試行された場合は、前回記録した通知を届けていなければもう一度届ける
+	alert.Event, err = q.GetAlertEvent(ctx, GetAlertEventParams{
+		RuleID:     rule.ID,
+		TransferID: transfer.ID,
+	})
+
+	if err == nil {
+		return alert, !alert.Event.DeliveredAt.Valid, nil
+	}
+
+	if !errors.Is(err, ErrorRecordNotFound) {
+		return alert, false, err
+	}
+
+	var fire bool
+	var amount int64
+
+	switch rule.Kind {
+	case util.AlertLowBalance:
+		// 後の送金の残高で既に評価している
+		if transfer.ID <= rule.LastTransferID {
+			return alert, false, nil
+		}
+
+		below := account.Balance < rule.Threshold
+		amount = account.Balance
+
+		alert.Rule, err = q.UpdateAlertRuleEvaluation(ctx, UpdateAlertRuleEvaluationParams{
+			ID:             rule.ID,
+			Triggered:      below,
+			LastTransferID: transfer.ID,
+		})
+
+		if err != nil {
+			return alert, false, err
+		}
+
+		// 閾値以上に戻った場合は次に下回ったときに通知できるようにするだけ
+		fire = below && !rule.Triggered
+	case util.AlertLargeIncomingTransfer:
+		fire = account.ID == transfer.ToAccountID && transfer.Amount > rule.Threshold
+		amount = transfer.Amount
+	}
+
+	if !fire {
+		return alert, false, nil
+	}
+
+	alert.Event, err = q.CreateAlertEvent(ctx, CreateAlertEventParams{
+		RuleID:     rule.ID,
+		TransferID: transfer.ID,
+		Amount:     amount,
+	})
+
+	return alert, err == nil, err
+}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestAlertRule(t *testing.T, account Account, kind string, threshold int64) AlertRule {
+	rule, err := testStore.CreateAlertRule(context.Background(), CreateAlertRuleParams{
+		Owner:     account.Owner,
+		AccountID: account.ID,
+		Kind:      kind,
+		Threshold: threshold,
+	})
+	require.NoError(t, err)
+	require.False(t, rule.Triggered)
+
+	return rule
+}
+
+func evaluateAlerts(t *testing.T, transfer Transfer) []Alert {
+	result, err := testStore.EvaluateAlertsTx(context.Background(), EvaluateAlertsTxParams{
+		TransferID: transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, transfer.ID, result.Transfer.ID)
+
+	return result.Alerts
+}
+
+func TestEvaluateAlertsTx(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	// account1 の残高は既に閾値を下回っている
+	lowBalance := createTestAlertRule(t, account1, util.AlertLowBalance, account1.Balance+1)
+	largeIncoming := createTestAlertRule(t, account2, util.AlertLargeIncomingTransfer, 0)
+
+	transfer1 := createRandomTransfer(t, account1, account2)
+
+	alerts := evaluateAlerts(t, transfer1)
+	require.Len(t, alerts, 2)
+	require.Equal(t, lowBalance.ID, alerts[0].Rule.ID)
+	require.True(t, alerts[0].Rule.Triggered)
+	require.Equal(t, account1.Balance, alerts[0].Event.Amount)
+	require.Equal(t, largeIncoming.ID, alerts[1].Rule.ID)
+	require.Equal(t, transfer1.Amount, alerts[1].Event.Amount)
+
+	// 届けていない通知は再試行したときにもう一度返す
+	retried := evaluateAlerts(t, transfer1)
+	require.Len(t, retried, 2)
+	require.Equal(t, alerts[0].Event.ID, retried[0].Event.ID)
+
+	for _, alert := range alerts {
+		_, err := testStore.MarkAlertEventDelivered(context.Background(), alert.Event.ID)
+		require.NoError(t, err)
+	}
+
+	require.Empty(t, evaluateAlerts(t, transfer1))
+
+	// 閾値を下回ったままの間は残高の通知を送らない
+	transfer2 := createRandomTransfer(t, account1, account2)
+
+	alerts = evaluateAlerts(t, transfer2)
+	require.Len(t, alerts, 1)
+	require.Equal(t, largeIncoming.ID, alerts[0].Rule.ID)
+
+	// 閾値以上に戻ってから下回ると、もう一度通知する
+	_, err := testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{ID: account1.ID, Amount: 1})
+	require.NoError(t, err)
+
+	transfer3 := createRandomTransfer(t, account2, account1)
+	require.Empty(t, evaluateAlerts(t, transfer3))
+
+	_, err = testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{ID: account1.ID, Amount: -1})
+	require.NoError(t, err)
+
+	transfer4 := createRandomTransfer(t, account2, account1)
+
+	alerts = evaluateAlerts(t, transfer4)
+	require.Len(t, alerts, 1)
+	require.Equal(t, lowBalance.ID, alerts[0].Rule.ID)
+}
+
+func TestEvaluateAlertsTxBalanceAfterTransfer(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	lowBalance := createTestAlertRule(t, account1, util.AlertLowBalance, account1.Balance)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.NoError(t, err)
+
+	// 評価するまでの間に入金されても、送金した直後の残高で評価する
+	_, err = testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{ID: account1.ID, Amount: 10})
+	require.NoError(t, err)
+
+	alerts, err := testStore.EvaluateAlertsTx(context.Background(), EvaluateAlertsTxParams{
+		TransferID: result.Transfer.ID,
+		Balances:   result.Balances(),
+	})
+	require.NoError(t, err)
+	require.Len(t, alerts.Alerts, 1)
+	require.Equal(t, lowBalance.ID, alerts.Alerts[0].Rule.ID)
+	require.Equal(t, result.FromAccount.Balance, alerts.Alerts[0].Event.Amount)
+	require.Equal(t, result.FromAccount.Balance, alerts.Alerts[0].Account.Balance)
+}
+
+func TestEvaluateAlertsTxOutOfOrder(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	lowBalance := createTestAlertRule(t, account1, util.AlertLowBalance, account1.Balance)
+
+	// transfer1 の直後は閾値を下回り、transfer2 の直後は閾値以上に戻っている
+	transfer1 := createRandomTransfer(t, account1, account2)
+	transfer2 := createRandomTransfer(t, account2, account1)
+
+	evaluate := func(transfer Transfer, balance int64) []Alert {
+		result, err := testStore.EvaluateAlertsTx(context.Background(), EvaluateAlertsTxParams{
+			TransferID: transfer.ID,
+			Balances:   map[int64]int64{account1.ID: balance},
+		})
+		require.NoError(t, err)
+
+		return result.Alerts
+	}
+
+	// 後の送金を先に評価した場合は、古い送金の残高で通知しない
+	require.Empty(t, evaluate(transfer2, account1.Balance))
+	require.Empty(t, evaluate(transfer1, account1.Balance-1))
+
+	rule, err := testStore.GetAlertRule(context.Background(), lowBalance.ID)
+	require.NoError(t, err)
+	require.False(t, rule.Triggered)
+	require.Equal(t, transfer2.ID, rule.LastTransferID)
+
+	// 順番どおりに評価した場合は、下回ったときに通知して戻ったときに状態を戻す
+	transfer3 := createRandomTransfer(t, account1, account2)
+	transfer4 := createRandomTransfer(t, account2, account1)
+
+	alerts := evaluate(transfer3, account1.Balance-1)
+	require.Len(t, alerts, 1)
+	require.Equal(t, lowBalance.ID, alerts[0].Rule.ID)
+	require.True(t, alerts[0].Rule.Triggered)
+
+	require.Empty(t, evaluate(transfer4, account1.Balance))
+
+	rule, err = testStore.GetAlertRule(context.Background(), lowBalance.ID)
+	require.NoError(t, err)
+	require.False(t, rule.Triggered)
+	require.Equal(t, transfer4.ID, rule.LastTransferID)
+}
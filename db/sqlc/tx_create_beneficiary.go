@@ -0,0 +1,33 @@
+package db
+
+import "context"
+
+type CreateBeneficiaryTxParams struct {
+	CreateBeneficiaryParams
+	AfterCreate func(beneficiary Beneficiary) error
+}
+
+type CreateBeneficiaryTxResult struct {
+	Beneficiary Beneficiary `json:"beneficiary"`
+}
+
+// CreateBeneficiaryTx は受取人を追加し、同じトランザクションの中で通知のタスクを登録する
+
+func (store *SQLStore) CreateBeneficiaryTx(ctx context.Context, arg CreateBeneficiaryTxParams) (
+	CreateBeneficiaryTxResult, error) {
+	var result CreateBeneficiaryTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Beneficiary, err = q.CreateBeneficiary(ctx, arg.CreateBeneficiaryParams)
+
+		if err != nil {
+			return err
+		}
+
+		return arg.AfterCreate(result.Beneficiary)
+	})
+
+	return result, err
+}
@@ -0,0 +1,33 @@
+package db
+
+import "context"
+
+type CreateDataExportTxParams struct {
+	Username    string
+	AfterCreate func(dataExport DataExport) error
+}
+
+type CreateDataExportTxResult struct {
+	DataExport DataExport `json:"data_export"`
+}
+
+// CreateDataExportTx はユーザーのデータの書き出しを受け付け、同じトランザクションの中で書き出すタスクを登録する
+
+func (store *SQLStore) CreateDataExportTx(ctx context.Context, arg CreateDataExportTxParams) (
+	CreateDataExportTxResult, error) {
+	var result CreateDataExportTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.DataExport, err = q.CreateDataExport(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		return arg.AfterCreate(result.DataExport)
+	})
+
+	return result, err
+}
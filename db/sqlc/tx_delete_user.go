@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// 削除したユーザーの名前の代わりに入れる値
+const DeletedUserFullName = "Deleted User"
+
+type DeleteUserTxParams struct {
+	Username string
+}
+
+type DeleteUserTxResult struct {
+	User User `json:"user"`
+}
+
+// DeleteUserTx はユーザーの個人を特定できる値を置き換えて、アカウントを使えなくする
+// 規制で保存が必要な口座と台帳の行は残すので、username は変えない
+// 送ったメールの記録や通知、受取人や通知の設定など、個人を特定できる値を含む行は同じトランザクションで消し、
+// 終わっていない請求や審査、承認待ちの操作は取り消す
+// 口座は閉じて、入金や送金を受け取れないようにする
+// 残高のある口座がある場合は ErrNonZeroBalance を返す
+
+func (store *SQLStore) DeleteUserTx(ctx context.Context, arg DeleteUserTxParams) (
+	DeleteUserTxResult, error) {
+	var result DeleteUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		user, err := q.GetUserForUpdate(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		if user.DeletedAt.Valid {
+			return ErrUserDeleted
+		}
+
+		// 削除している間に入金や送金で残高が変わらないように、口座をロックしてから確かめる
+		accounts, err := q.ListAccountsByOwnerForUpdate(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		accountIDs := make([]int64, 0, len(accounts))
+
+		for _, account := range accounts {
+			if account.Balance != 0 {
+				return ErrNonZeroBalance
+			}
+
+			accountIDs = append(accountIDs, account.ID)
+		}
+
+		// 口座と台帳の行は残すが、お金を送ったり受け取ったりできないようにする
+		err = q.CloseUserAccounts(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		// 確認のメールは変える前のメールアドレスにも送っているので、そのアドレスに送った記録も消す
+		verifyEmails, err := q.DeleteUserVerifyEmails(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		emails := []string{strings.ToLower(user.Email)}
+
+		for _, verifyEmail := range verifyEmails {
+			emails = append(emails, strings.ToLower(verifyEmail.Email))
+		}
+
+		err = q.DeleteUserEmailDeliveries(ctx, DeleteUserEmailDeliveriesParams{
+			Username: arg.Username,
+			Emails:   emails,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		// email は一意なので、ほかのユーザーと重ならない値にする
+		result.User, err = q.PseudonymizeUser(ctx, PseudonymizeUserParams{
+			Username: arg.Username,
+			FullName: DeletedUserFullName,
+			Email:    fmt.Sprintf("deleted-%s@deleted.invalid", uuid.NewString()),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		err = q.ScrubUserSessions(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteUserNotifications(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteUserNotificationPreferences(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		// 受取人のニックネームには、登録したユーザーと送金先の持ち主のどちらかを特定できる値が入る
+		err = q.DeleteUserBeneficiaries(ctx, DeleteUserBeneficiariesParams{
+			Username:   arg.Username,
+			AccountIds: accountIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteUserAlertRules(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		err = q.CancelUserPaymentRequests(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		err = q.CancelUserPendingOperations(ctx, CancelUserPendingOperationsParams{
+			Username:   arg.Username,
+			AccountIds: accountIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		err = q.CancelUserFraudReviews(ctx, CancelUserFraudReviewsParams{
+			Username:   arg.Username,
+			AccountIds: accountIDs,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		// 書き出したデータにも個人を特定できる値が含まれる
+		return q.DeleteUserDataExports(ctx, arg.Username)
+	})
+
+	return result, err
+}
@@ -0,0 +1,227 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+type DepositTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+	// AfterCreate は入金を記録した後に同じトランザクションの中で呼ばれる
+	AfterCreate func(result TransferTxResult) error `json:"-"`
+}
+
+type DepositTxResult struct {
+	Transfer Transfer `json:"transfer"`
+	Account  Account  `json:"account"`
+	Entry    Entry    `json:"entry"`
+	// 銀行の現金口座のentry
+	VaultEntry Entry `json:"vault_entry"`
+}
+
+// transferResult は入金を銀行の現金口座から顧客の口座への送金として返す。現金口座の残高は入らない
+func (result DepositTxResult) transferResult() TransferTxResult {
+	return TransferTxResult{
+		Transfer:  result.Transfer,
+		ToAccount: result.Account,
+		FromEntry: result.VaultEntry,
+		ToEntry:   result.Entry,
+	}
+}
+
+// DepositTx は銀行の現金口座から顧客の口座へ入金する
+
+func (store *SQLStore) DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error) {
+	var result DepositTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = deposit(ctx, q, arg)
+
+		if err != nil || arg.AfterCreate == nil {
+			return err
+		}
+
+		return arg.AfterCreate(result.transferResult())
+	})
+
+	return result, err
+}
+
+// deposit は呼び出し側のトランザクションの中で入金を記録する
+func deposit(ctx context.Context, q *Queries, arg DepositTxParams) (DepositTxResult, error) {
+	var result DepositTxResult
+
+	account, err := q.GetAccount(ctx, arg.AccountID)
+
+	if err != nil {
+		return result, err
+	}
+
+	vault, err := q.GetAccountByOwner(ctx, GetAccountByOwnerParams{
+		Owner:       util.CashVaultOwner,
+		Currency:    account.Currency,
+		AccountType: util.CheckingAccount,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	movement, err := moveMoney(ctx, q, vault.ID, account.ID, arg.Amount)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Transfer = movement.Transfer
+	result.Account = movement.ToAccount
+	result.Entry = movement.ToEntry
+	result.VaultEntry = movement.FromEntry
+
+	return result, nil
+}
+
+type WithdrawTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+	// AfterCreate は出金を記録した後に同じトランザクションの中で呼ばれる
+	AfterCreate func(result TransferTxResult) error `json:"-"`
+}
+
+type WithdrawTxResult struct {
+	Transfer Transfer `json:"transfer"`
+	Account  Account  `json:"account"`
+	Entry    Entry    `json:"entry"`
+	// 銀行の現金口座のentry
+	VaultEntry Entry `json:"vault_entry"`
+}
+
+// transferResult は出金を顧客の口座から銀行の現金口座への送金として返す。現金口座の残高は入らない
+func (result WithdrawTxResult) transferResult() TransferTxResult {
+	return TransferTxResult{
+		Transfer:    result.Transfer,
+		FromAccount: result.Account,
+		FromEntry:   result.Entry,
+		ToEntry:     result.VaultEntry,
+	}
+}
+
+// WithdrawTx は顧客の口座から銀行の現金口座へ出金する
+
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error) {
+	var result WithdrawTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = withdraw(ctx, q, arg)
+
+		if err != nil || arg.AfterCreate == nil {
+			return err
+		}
+
+		return arg.AfterCreate(result.transferResult())
+	})
+
+	return result, err
+}
+
+// withdraw は呼び出し側のトランザクションの中で出金を記録する
+func withdraw(ctx context.Context, q *Queries, arg WithdrawTxParams) (WithdrawTxResult, error) {
+	var result WithdrawTxResult
+
+	account, err := q.GetAccount(ctx, arg.AccountID)
+
+	if err != nil {
+		return result, err
+	}
+
+	vault, err := q.GetAccountByOwner(ctx, GetAccountByOwnerParams{
+		Owner:       util.CashVaultOwner,
+		Currency:    account.Currency,
+		AccountType: util.CheckingAccount,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	movement, err := moveMoney(ctx, q, account.ID, vault.ID, arg.Amount)
+
+	if err != nil {
+		return result, err
+	}
+
+	// 残高の確認は行ロックを取った後に行う
+	if movement.FromAccount.Balance < 0 {
+		return result, ErrInsufficientBalance
+	}
+
+	result.Transfer = movement.Transfer
+	result.Account = movement.FromAccount
+	result.Entry = movement.FromEntry
+	result.VaultEntry = movement.ToEntry
+
+	return result, nil
+}
+
+// moveMoney は手数料なしで口座間の送金を記録し、両方の口座の残高を更新する
+func moveMoney(ctx context.Context, q *Queries, fromAccountID, toAccountID, amount int64) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
+
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: fromAccountID,
+		Amount:    -amount,
+		TransferID: pgtype.Int8{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: toAccountID,
+		Amount:    amount,
+		TransferID: pgtype.Int8{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	accounts, err := addMoney(ctx, q,
+		balanceChange{accountID: fromAccountID, amount: -amount},
+		balanceChange{accountID: toAccountID, amount: amount},
+	)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.FromAccount = accounts[fromAccountID]
+	result.ToAccount = accounts[toAccountID]
+
+	return result, nil
+}
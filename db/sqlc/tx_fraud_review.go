@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+type ReviewFraudTxParams struct {
+	ReviewID   int64  `json:"review_id"`
+	ReviewedBy string `json:"reviewed_by"`
+	Approve    bool   `json:"approve"`
+	// AfterCreate は承認した送金を記録した後に同じトランザクションの中で呼ばれる
+	AfterCreate func(result TransferTxResult) error `json:"-"`
+	// AfterPay は保留されていた請求の支払いを承認した後に同じトランザクションの中で呼ばれる
+	AfterPay func(request PaymentRequest) error `json:"-"`
+}
+
+type ReviewFraudTxResult struct {
+	Review FraudReview `json:"review"`
+	// 承認した場合に実行された送金
+	Transfer TransferTxResult `json:"transfer"`
+	// 請求の支払いが保留されていた場合の請求
+	PaymentRequest PaymentRequest `json:"payment_request"`
+}
+
+// ReviewFraudTx は保留された送金を承認または却下する
+// 承認した場合は同じトランザクションの中で送金を実行する
+// 請求の支払いが保留されていた場合は、承認すると請求を支払い済みにし、却下すると支払い待ちに戻す
+
+func (store *SQLStore) ReviewFraudTx(ctx context.Context, arg ReviewFraudTxParams) (ReviewFraudTxResult, error) {
+	var result ReviewFraudTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		review, err := q.GetFraudReviewForUpdate(ctx, arg.ReviewID)
+
+		if err != nil {
+			return err
+		}
+
+		if review.Status != util.ReviewPending {
+			return ErrReviewNotPending
+		}
+
+		update := UpdateFraudReviewStatusParams{
+			ID:     review.ID,
+			Status: util.ReviewRejected,
+			ReviewedBy: pgtype.Text{
+				String: arg.ReviewedBy,
+				Valid:  true,
+			},
+		}
+
+		if arg.Approve {
+			result.Transfer, err = transfer(ctx, q, TransferTxParams{
+				FromAccountID: review.FromAccountID,
+				ToAccountID:   review.ToAccountID,
+				Amount:        review.Amount,
+				Memo:          review.Memo,
+				Reference:     review.Reference,
+				Category:      review.Category,
+			})
+
+			// 保留している間に残高が減っている場合は ErrInsufficientBalance になる
+			if err != nil {
+				return err
+			}
+
+			if arg.AfterCreate != nil {
+				err = arg.AfterCreate(result.Transfer)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			update.Status = util.ReviewApproved
+			update.TransferID = pgtype.Int8{
+				Int64: result.Transfer.Transfer.ID,
+				Valid: true,
+			}
+		}
+
+		result.Review, err = q.UpdateFraudReviewStatus(ctx, update)
+
+		if err != nil || !review.PaymentRequestID.Valid {
+			return err
+		}
+
+		resolve := ResolveHeldPaymentRequestParams{
+			ID:     review.PaymentRequestID.Int64,
+			Status: util.PaymentRequestPending,
+		}
+
+		if arg.Approve {
+			resolve.Status = util.PaymentRequestPaid
+			resolve.TransferID = update.TransferID
+		}
+
+		result.PaymentRequest, err = q.ResolveHeldPaymentRequest(ctx, resolve)
+
+		if err != nil || !arg.Approve || arg.AfterPay == nil {
+			return err
+		}
+
+		return arg.AfterPay(result.PaymentRequest)
+	})
+
+	return result, err
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+type AccrueInterestTxParams struct {
+	AccountID     int64     `json:"account_id"`
+	AccrualDate   time.Time `json:"accrual_date"`
+	Balance       int64     `json:"balance"`
+	AnnualRateBps int64     `json:"annual_rate_bps"`
+}
+
+type AccrueInterestTxResult struct {
+	Accrual InterestAccrual `json:"accrual"`
+	// 同じ日付の利息がすでに計上されていた場合は false
+	Created bool `json:"created"`
+}
+
+// AccrueInterestTx は1日分の利息を計上する
+// 前回の計上で繰り越された端数を足して計算し、同じ日付で2回計上されることはない
+
+func (store *SQLStore) AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (AccrueInterestTxResult, error) {
+	var result AccrueInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		accrualDate := pgtype.Date{Time: arg.AccrualDate, Valid: true}
+
+		latest, err := q.GetLatestInterestAccrual(ctx, GetLatestInterestAccrualParams{
+			AccountID:   arg.AccountID,
+			AccrualDate: accrualDate,
+		})
+
+		if err != nil && !errors.Is(err, ErrorRecordNotFound) {
+			return err
+		}
+
+		amount, remainder := util.AccrueDailyInterest(arg.Balance, arg.AnnualRateBps, latest.Remainder)
+
+		result.Accrual, err = q.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+			AccountID:     arg.AccountID,
+			AccrualDate:   accrualDate,
+			Balance:       arg.Balance,
+			AnnualRateBps: arg.AnnualRateBps,
+			Amount:        amount,
+			Remainder:     remainder,
+		})
+
+		// ON CONFLICT DO NOTHING で行が返ってこない場合は計上済み
+		if errors.Is(err, ErrorRecordNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		result.Created = true
+
+		return nil
+	})
+
+	return result, err
+}
+
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// この日付までに計上された利息を支払う
+	PeriodEnd time.Time `json:"period_end"`
+	// AfterCreate は利息を支払う送金を記録した後に同じトランザクションの中で呼ばれる
+	AfterCreate func(result TransferTxResult) error `json:"-"`
+}
+
+type PostInterestTxResult struct {
+	Amount   int64    `json:"amount"`
+	Transfer Transfer `json:"transfer"`
+	Account  Account  `json:"account"`
+	Entry    Entry    `json:"entry"`
+	// 銀行の利息費用口座のentry
+	ExpenseEntry Entry `json:"expense_entry"`
+}
+
+// PostInterestTx は未払いの利息をまとめて銀行の利息費用口座から顧客の口座へ支払う
+// 支払った計上には transfer_id が記録されるので、再実行しても二重に支払われない
+
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		periodEnd := pgtype.Date{Time: arg.PeriodEnd, Valid: true}
+
+		accruals, err := q.ListUnpostedInterestAccrualsForUpdate(ctx, ListUnpostedInterestAccrualsForUpdateParams{
+			AccountID:   arg.AccountID,
+			AccrualDate: periodEnd,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, accrual := range accruals {
+			result.Amount += accrual.Amount
+		}
+
+		if result.Amount == 0 {
+			return nil
+		}
+
+		account, err := q.GetAccount(ctx, arg.AccountID)
+
+		if err != nil {
+			return err
+		}
+
+		expense, err := q.GetAccountByOwner(ctx, GetAccountByOwnerParams{
+			Owner:       util.InterestExpenseOwner,
+			Currency:    account.Currency,
+			AccountType: util.CheckingAccount,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		movement, err := moveMoney(ctx, q, expense.ID, account.ID, result.Amount)
+
+		if err != nil {
+			return err
+		}
+
+		result.Transfer = movement.Transfer
+		result.Account = movement.ToAccount
+		result.Entry = movement.ToEntry
+		result.ExpenseEntry = movement.FromEntry
+
+		err = q.MarkInterestAccrualsPosted(ctx, MarkInterestAccrualsPostedParams{
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
+			AccountID:   arg.AccountID,
+			AccrualDate: periodEnd,
+		})
+
+		if err != nil || arg.AfterCreate == nil {
+			return err
+		}
+
+		return arg.AfterCreate(movement)
+	})
+
+	return result, err
+}
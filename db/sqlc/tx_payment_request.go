@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+type CreatePaymentRequestTxParams struct {
+	CreatePaymentRequestParams
+	AfterCreate func(request PaymentRequest) error
+}
+
+type CreatePaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest `json:"payment_request"`
+}
+
+// CreatePaymentRequestTx は支払いの請求を作成し、同じトランザクションの中で通知のタスクを登録する
+
+func (store *SQLStore) CreatePaymentRequestTx(ctx context.Context, arg CreatePaymentRequestTxParams) (
+	CreatePaymentRequestTxResult, error) {
+	var result CreatePaymentRequestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.PaymentRequest, err = q.CreatePaymentRequest(ctx, arg.CreatePaymentRequestParams)
+
+		if err != nil {
+			return err
+		}
+
+		return arg.AfterCreate(result.PaymentRequest)
+	})
+
+	return result, err
+}
+
+type UpdatePaymentRequestTxParams struct {
+	RequestID int64  `json:"request_id"`
+	Status    string `json:"status"`
+	// 支払う場合の送金。送金先、金額、メモは請求の内容で上書きする
+	Transfer    TransferTxParams                   `json:"transfer"`
+	AfterUpdate func(request PaymentRequest) error `json:"-"`
+}
+
+type UpdatePaymentRequestTxResult struct {
+	PaymentRequest PaymentRequest `json:"payment_request"`
+	// 支払った場合に実行された送金
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// UpdatePaymentRequestTx は支払い待ちの請求を支払い、断り、または取り消す
+// 支払う場合は同じトランザクションの中で送金し、請求を支払い済みにする
+// 送金が保留された場合は請求を held にし、審査が終わるまで知らせない
+
+func (store *SQLStore) UpdatePaymentRequestTx(ctx context.Context, arg UpdatePaymentRequestTxParams) (
+	UpdatePaymentRequestTxResult, error) {
+	var result UpdatePaymentRequestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		request, err := q.GetPaymentRequestForUpdate(ctx, arg.RequestID)
+
+		if err != nil {
+			return err
+		}
+
+		if request.Status != util.PaymentRequestPending {
+			return ErrPaymentRequestNotPending
+		}
+
+		if time.Now().After(request.ExpiresAt) {
+			return ErrOperationExpired
+		}
+
+		update := UpdatePaymentRequestStatusParams{
+			ID:     request.ID,
+			Status: arg.Status,
+		}
+
+		if arg.Status == util.PaymentRequestPaid {
+			transferArg := arg.Transfer
+			transferArg.ToAccountID = request.ToAccountID
+			transferArg.Amount = request.Amount
+			transferArg.Memo = request.Memo
+
+			result.Transfer, err = transfer(ctx, q, transferArg)
+
+			if err != nil {
+				return err
+			}
+
+			if result.Transfer.Held {
+				update.Status = util.PaymentRequestHeld
+			} else {
+				update.TransferID = pgtype.Int8{
+					Int64: result.Transfer.Transfer.ID,
+					Valid: true,
+				}
+			}
+		}
+
+		result.PaymentRequest, err = q.UpdatePaymentRequestStatus(ctx, update)
+
+		if err != nil || result.Transfer.Held {
+			return err
+		}
+
+		if arg.Status == util.PaymentRequestPaid && arg.Transfer.AfterCreate != nil {
+			err = arg.Transfer.AfterCreate(result.Transfer)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return arg.AfterUpdate(result.PaymentRequest)
+	})
+
+	return result, err
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+// RequestOperationTx は二人目の銀行員の承認が必要な操作を作成し、監査ログに記録する
+
+func (store *SQLStore) RequestOperationTx(ctx context.Context, arg CreatePendingOperationParams) (PendingOperation, error) {
+	var operation PendingOperation
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		operation, err = q.CreatePendingOperation(ctx, arg)
+
+		if err != nil {
+			return err
+		}
+
+		return auditPendingOperation(ctx, q, operation, arg.RequestedBy, util.AuditActionRequest,
+			fmt.Sprintf("%s %d %s", operation.Kind, operation.Amount, operation.Currency))
+	})
+
+	return operation, err
+}
+
+type ReviewPendingOperationTxParams struct {
+	OperationID int64  `json:"operation_id"`
+	ReviewedBy  string `json:"reviewed_by"`
+	Approve     bool   `json:"approve"`
+	// AfterCreate は承認した操作を実行した後に同じトランザクションの中で呼ばれる
+	AfterCreate func(result TransferTxResult) error `json:"-"`
+}
+
+type ReviewPendingOperationTxResult struct {
+	Operation PendingOperation `json:"operation"`
+	// 承認した場合に実行された送金。入金と出金の場合は片方の口座だけが入る
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// ReviewPendingOperationTx は承認待ちの操作を承認または却下する
+// 承認は依頼した銀行員以外が期限内に行う必要があり、操作は同じトランザクションの中で実行する
+
+func (store *SQLStore) ReviewPendingOperationTx(ctx context.Context, arg ReviewPendingOperationTxParams) (ReviewPendingOperationTxResult, error) {
+	var result ReviewPendingOperationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		operation, err := q.GetPendingOperationForUpdate(ctx, arg.OperationID)
+
+		if err != nil {
+			return err
+		}
+
+		if operation.Status != util.ReviewPending {
+			return ErrReviewNotPending
+		}
+
+		update := UpdatePendingOperationStatusParams{
+			ID:     operation.ID,
+			Status: util.ReviewRejected,
+			ReviewedBy: pgtype.Text{
+				String: arg.ReviewedBy,
+				Valid:  true,
+			},
+		}
+		action := util.AuditActionReject
+		detail := ""
+
+		if arg.Approve {
+			if operation.RequestedBy == arg.ReviewedBy {
+				return ErrSameApprover
+			}
+
+			if time.Now().After(operation.ExpiresAt) {
+				return ErrOperationExpired
+			}
+
+			result.Transfer, err = executeOperation(ctx, q, operation)
+
+			if err != nil {
+				return err
+			}
+
+			if arg.AfterCreate != nil {
+				err = arg.AfterCreate(result.Transfer)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			update.Status = util.ReviewApproved
+			update.TransferID = pgtype.Int8{
+				Int64: result.Transfer.Transfer.ID,
+				Valid: true,
+			}
+			action = util.AuditActionApprove
+			detail = fmt.Sprintf("transfer %d", result.Transfer.Transfer.ID)
+		}
+
+		result.Operation, err = q.UpdatePendingOperationStatus(ctx, update)
+
+		if err != nil {
+			return err
+		}
+
+		return auditPendingOperation(ctx, q, result.Operation, arg.ReviewedBy, action, detail)
+	})
+
+	return result, err
+}
+
+// executeOperation は承認された操作を種類に応じて実行する
+func executeOperation(ctx context.Context, q *Queries, operation PendingOperation) (TransferTxResult, error) {
+	switch operation.Kind {
+	case util.OperationTransfer:
+		// 残高が足りない場合は transfer が ErrInsufficientBalance を返す
+		return transfer(ctx, q, TransferTxParams{
+			FromAccountID: operation.FromAccountID.Int64,
+			ToAccountID:   operation.ToAccountID.Int64,
+			Amount:        operation.Amount,
+			Memo:          operation.Memo,
+			Reference:     operation.Reference,
+			Category:      operation.Category,
+		})
+
+	case util.OperationDeposit:
+		result, err := deposit(ctx, q, DepositTxParams{
+			AccountID: operation.ToAccountID.Int64,
+			Amount:    operation.Amount,
+		})
+
+		return result.transferResult(), err
+
+	case util.OperationWithdraw:
+		result, err := withdraw(ctx, q, WithdrawTxParams{
+			AccountID: operation.FromAccountID.Int64,
+			Amount:    operation.Amount,
+		})
+
+		return result.transferResult(), err
+	}
+
+	return TransferTxResult{}, fmt.Errorf("unsupported operation kind: %s", operation.Kind)
+}
+
+func auditPendingOperation(ctx context.Context, q *Queries, operation PendingOperation, actor, action, detail string) error {
+	_, err := q.CreateAuditLog(ctx, CreateAuditLogParams{
+		Actor:      actor,
+		Action:     action,
+		Resource:   util.AuditResourcePendingOperation,
+		ResourceID: strconv.FormatInt(operation.ID, 10),
+		Detail:     detail,
+	})
+
+	return err
+}
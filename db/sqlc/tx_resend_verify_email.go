@@ -0,0 +1,34 @@
+package db
+
+import "context"
+
+type ResendVerifyEmailTxParams struct {
+	RequestVerifyEmailParams
+	AfterRequest func(user User) error
+}
+
+type ResendVerifyEmailTxResult struct {
+	User User `json:"user"`
+}
+
+// ResendVerifyEmailTx は確認メールを送り直す要求を記録し、同じトランザクションの中でメールを送るタスクを登録する
+// 前回の要求から間がない場合は ErrorRecordNotFound を返す
+
+func (store *SQLStore) ResendVerifyEmailTx(ctx context.Context, arg ResendVerifyEmailTxParams) (
+	ResendVerifyEmailTxResult, error) {
+	var result ResendVerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.RequestVerifyEmail(ctx, arg.RequestVerifyEmailParams)
+
+		if err != nil {
+			return err
+		}
+
+		return arg.AfterRequest(result.User)
+	})
+
+	return result, err
+}
@@ -1,11 +1,26 @@
 package db
 
-import "context"
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
+)
 
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Memo          string `json:"memo"`
+	Reference     string `json:"reference"`
+	Category      string `json:"category"`
+	// Screen は送金上限の確認の後、送金を記録する前に同じトランザクションの中で呼ばれる
+	// false を返すと送金せずにコミットする。エラーを返すとロールバックする
+	Screen func(q Querier) (bool, error) `json:"-"`
+	// AfterCreate は送金を記録した後に同じトランザクションの中で呼ばれる。保留された場合は呼ばれない
+	AfterCreate func(result TransferTxResult) error `json:"-"`
 }
 
 type TransferTxResult struct {
@@ -14,6 +29,26 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	Fee         int64    `json:"fee"`
+	// 送金元から引かれた手数料のentry
+	FeeEntry Entry `json:"fee_entry"`
+	// 手数料を受け取った銀行の口座のentry
+	RevenueEntry Entry `json:"revenue_entry"`
+	// Screen によって送金が保留された場合は true
+	Held bool `json:"held"`
+}
+
+// Balances は送金した直後の口座ごとの残高を返す。結果に入っていない口座は含まない
+func (result TransferTxResult) Balances() map[int64]int64 {
+	balances := make(map[int64]int64, 2)
+
+	for _, account := range []Account{result.FromAccount, result.ToAccount} {
+		if account.ID != 0 {
+			balances[account.ID] = account.Balance
+		}
+	}
+
+	return balances
 }
 
 // TransferTx は口座から口座への送金を行う
@@ -25,75 +60,210 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
 
-		// 1. transfer tableにInsert
+		result, err = transfer(ctx, q, arg)
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams(arg))
-		if err != nil {
+		if err != nil || result.Held || arg.AfterCreate == nil {
 			return err
 		}
 
-		// 2. 口座Aに 送信した料金分マイナスの entryをInsert
+		return arg.AfterCreate(result)
+	})
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
+	return result, err
+}
+
+// transfer は呼び出し側のトランザクションの中で送金を記録する
+// 手数料を含めて送金元の残高が足りない場合は ErrInsufficientBalance を返す
+func transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	// 0. 送金元の口座の送金上限を確認し、適用される手数料を計算する
+
+	err := checkTransferLimit(ctx, q, arg)
+
+	if err != nil {
+		return result, err
+	}
+
+	if arg.Screen != nil {
+		proceed, err := arg.Screen(q)
 
 		if err != nil {
-			return err
+			return result, err
+		}
+
+		if !proceed {
+			result.Held = true
+			return result, nil
 		}
+	}
+
+	schedule, err := q.GetFeeScheduleForAccount(ctx, arg.FromAccountID)
+
+	if err != nil && !errors.Is(err, ErrorRecordNotFound) {
+		return result, err
+	}
+
+	result.Fee = util.CalculateFee(arg.Amount, schedule.FlatFee, schedule.PercentFeeBps)
+
+	// 1. transfer tableにInsert
+
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Fee:           result.Fee,
+		Memo:          arg.Memo,
+		Reference:     arg.Reference,
+		Category:      arg.Category,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// 2. 口座Aに 送信した料金分マイナスの entryをInsert
+
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.FromAccountID,
+		Amount:    -arg.Amount,
+		TransferID: pgtype.Int8{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		},
+	})
 
-		// 3. 口座Bに受け取った料金分プラスするentryをInsert
+	if err != nil {
+		return result, err
+	}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
+	// 3. 口座Bに受け取った料金分プラスするentryをInsert
+
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.ToAccountID,
+		Amount:    arg.Amount,
+		TransferID: pgtype.Int8{
+			Int64: result.Transfer.ID,
+			Valid: true,
+		},
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	changes := []balanceChange{
+		{accountID: arg.FromAccountID, amount: -arg.Amount},
+		{accountID: arg.ToAccountID, amount: arg.Amount},
+	}
+
+	// 4. 手数料がある場合は、口座Aから銀行の手数料口座へのentryをInsert
+
+	if result.Fee > 0 {
+		revenueAccount, err := q.GetAccountByOwner(ctx, GetAccountByOwnerParams{
+			Owner:       util.FeeRevenueOwner,
+			Currency:    schedule.Currency,
+			AccountType: util.CheckingAccount,
 		})
 
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q,
-				arg.FromAccountID, -arg.Amount,
-				arg.ToAccountID, arg.Amount)
+		result.FeeEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: arg.FromAccountID,
+			Amount:    -result.Fee,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
+		})
+
+		if err != nil {
+			return result, err
+		}
 
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q,
-				arg.ToAccountID, arg.Amount,
-				arg.FromAccountID, -arg.Amount)
+		result.RevenueEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: revenueAccount.ID,
+			Amount:    result.Fee,
+			TransferID: pgtype.Int8{
+				Int64: result.Transfer.ID,
+				Valid: true,
+			},
+		})
 
+		if err != nil {
+			return result, err
 		}
 
-		return err
-	})
+		changes = append(changes,
+			balanceChange{accountID: arg.FromAccountID, amount: -result.Fee},
+			balanceChange{accountID: revenueAccount.ID, amount: result.Fee},
+		)
+	}
 
-	return result, err
+	accounts, err := addMoney(ctx, q, changes...)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.FromAccount = accounts[arg.FromAccountID]
+	result.ToAccount = accounts[arg.ToAccountID]
+
+	// 呼び出し側が読んだ残高は古い場合があるので、口座をロックして更新した後の残高で確かめる
+	// 同時に送金しても、手数料を含めて残高より多くは送れない
+	if result.FromAccount.Balance < 0 {
+		return result, ErrInsufficientBalance
+	}
+
+	return result, nil
 }
 
+type balanceChange struct {
+	accountID int64
+	amount    int64
+}
+
+// addMoney はデッドロックを防ぐために、常に口座IDの小さい順に残高を更新する
+// 閉じた口座が含まれる場合は ErrAccountClosed を返す
 func addMoney(
 	ctx context.Context,
 	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1, account2 Account, err error) {
-
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID1,
-		Amount: amount1,
-	})
+	changes ...balanceChange,
+) (map[int64]Account, error) {
+	amounts := make(map[int64]int64)
+	accountIDs := make([]int64, 0, len(changes))
 
-	if err != nil {
-		return
+	for _, change := range changes {
+		if _, ok := amounts[change.accountID]; !ok {
+			accountIDs = append(accountIDs, change.accountID)
+		}
+		amounts[change.accountID] += change.amount
 	}
 
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
+	sort.Slice(accountIDs, func(i, j int) bool {
+		return accountIDs[i] < accountIDs[j]
 	})
 
-	return
+	accounts := make(map[int64]Account, len(accountIDs))
+
+	for _, accountID := range accountIDs {
+		account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     accountID,
+			Amount: amounts[accountID],
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		// 削除したユーザーの口座にはお金を送れない
+		if account.ClosedAt.Valid {
+			return nil, ErrAccountClosed
+		}
+
+		accounts[accountID] = account
+	}
+
+	return accounts, nil
 }
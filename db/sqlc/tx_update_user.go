@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type UpdateUserTxParams struct {
+	UpdateUserParams
+	// 0 でない場合は、ユーザーの version が一致するときだけ更新する
+	ExpectedVersion int64
+	// メールアドレスが変わった場合だけ、同じトランザクションの中で呼ぶ。nil の場合は呼ばない
+	AfterEmailChange func(user User, oldEmail string) error
+	// パスワードが変わった場合だけ、同じトランザクションの中で呼ぶ。nil の場合は呼ばない
+	AfterPasswordChange func(user User) error
+}
+
+type UpdateUserTxResult struct {
+	User User `json:"user"`
+	// 変わる前のメールアドレス。変わっていない場合は空
+	OldEmail string `json:"old_email"`
+}
+
+// UpdateUserTx はユーザーの情報を更新する
+// メールアドレスが変わった場合は確認済みを取り消し、古いアドレスに送った確認コードを使えなくする
+// パスワードが変わった場合は、ユーザーのすべてのセッションを使えなくする
+// 読んだ後にほかの更新があった場合は ErrVersionMismatch を、削除したユーザーの場合は ErrUserDeleted を返す
+
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (
+	UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		user, err := q.GetUserForUpdate(ctx, arg.Username)
+
+		if err != nil {
+			return err
+		}
+
+		// 削除したユーザーの個人を特定できる値を書き戻さない
+		if user.DeletedAt.Valid {
+			return ErrUserDeleted
+		}
+
+		if arg.ExpectedVersion != 0 && arg.ExpectedVersion != user.Version {
+			return ErrVersionMismatch
+		}
+
+		emailChanged := arg.Email.Valid && arg.Email.String != user.Email
+
+		if emailChanged {
+			arg.IsEmailVerified = pgtype.Bool{
+				Bool:  false,
+				Valid: true,
+			}
+
+			err = q.InvalidateVerifyEmails(ctx, arg.Username)
+
+			if err != nil {
+				return err
+			}
+
+			result.OldEmail = user.Email
+		}
+
+		result.User, err = q.UpdateUser(ctx, arg.UpdateUserParams)
+
+		if err != nil {
+			return err
+		}
+
+		if arg.HashedPassword.Valid {
+			err = q.BlockUserSessions(ctx, arg.Username)
+
+			if err != nil {
+				return err
+			}
+
+			if arg.AfterPasswordChange != nil {
+				err = arg.AfterPasswordChange(result.User)
+
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if emailChanged && arg.AfterEmailChange != nil {
+			return arg.AfterEmailChange(result.User, result.OldEmail)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
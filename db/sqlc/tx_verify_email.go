@@ -2,10 +2,16 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/util"
 )
 
+// MaxVerifyEmailAttempts は1つの確認メールで間違った確認コードを試せる回数
+// 超えた場合は正しいコードでも確認できないので、確認メールを送り直す
+const MaxVerifyEmailAttempts = 5
+
 type VerifyEmailTxParams struct {
 	EmailId    int64
 	SecretCode string
@@ -16,19 +22,36 @@ type VerifyEmailTxResult struct {
 	VerifyEmail VerifyEmail
 }
 
-// VerifyEmailTx は口座から口座への送金を行う
+// VerifyEmailTx は確認コードのハッシュを比べて、ユーザーのメールアドレスを確認済みにする
+// 間違ったコードの場合も試した回数は残すので、トランザクションはコミットしてから ErrInvalidSecretCode を返す
 
 func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (
 	VerifyEmailTxResult, error) {
 	var result VerifyEmailTxResult
+	var mismatch bool
 
 	err := store.execTx(ctx, func(q *Queries) error {
-		var err error
+		verifyEmail, err := q.GetVerifyEmailForUpdate(ctx, arg.EmailId)
 
-		result.VerifyEmail, err = q.UpdateVerifyEmail(ctx, UpdateVerifyEmailParams{
-			ID:         arg.EmailId,
-			SecretCode: arg.SecretCode,
-		})
+		if err != nil {
+			return err
+		}
+
+		if verifyEmail.IsUsed || time.Now().After(verifyEmail.ExpiresAt) {
+			return ErrInvalidSecretCode
+		}
+
+		if verifyEmail.Attempts >= MaxVerifyEmailAttempts {
+			return ErrTooManyAttempts
+		}
+
+		if !util.CheckSecret(arg.SecretCode, verifyEmail.SecretCodeHash) {
+			mismatch = true
+			_, err = q.IncrementVerifyEmailAttempts(ctx, verifyEmail.ID)
+			return err
+		}
+
+		result.VerifyEmail, err = q.UpdateVerifyEmail(ctx, verifyEmail.ID)
 
 		if err != nil {
 			return err
@@ -46,5 +69,9 @@ func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParam
 
 	})
 
+	if err == nil && mismatch {
+		return result, ErrInvalidSecretCode
+	}
+
 	return result, err
 }
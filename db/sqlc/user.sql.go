@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -19,7 +20,7 @@ INSERT INTO users (
     email
   )
 VALUES ($1, $2, $3, $4)
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, phone_number, locale, verify_email_requested_at, version, deleted_at
 `
 
 type CreateUserParams struct {
@@ -46,12 +47,17 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.PhoneNumber,
+		&i.Locale,
+		&i.VerifyEmailRequestedAt,
+		&i.Version,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, phone_number, locale, verify_email_requested_at, version, deleted_at
 FROM users
 WHERE username = $1
 LIMIT 1
@@ -69,6 +75,224 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.PhoneNumber,
+		&i.Locale,
+		&i.VerifyEmailRequestedAt,
+		&i.Version,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, phone_number, locale, verify_email_requested_at, version, deleted_at
+FROM users
+WHERE email = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.PhoneNumber,
+		&i.Locale,
+		&i.VerifyEmailRequestedAt,
+		&i.Version,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, phone_number, locale, verify_email_requested_at, version, deleted_at
+FROM users
+WHERE username = $1
+LIMIT 1 FOR NO KEY
+UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.PhoneNumber,
+		&i.Locale,
+		&i.VerifyEmailRequestedAt,
+		&i.Version,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const isUserDeleted = `-- name: IsUserDeleted :one
+SELECT deleted_at IS NOT NULL AS is_deleted
+FROM users
+WHERE username = $1
+LIMIT 1
+`
+
+// トークンを確かめるたびに呼ぶので、削除したかどうかだけを読む
+func (q *Queries) IsUserDeleted(ctx context.Context, username string) (bool, error) {
+	row := q.db.QueryRow(ctx, isUserDeleted, username)
+	var isDeleted bool
+	err := row.Scan(&isDeleted)
+	return isDeleted, err
+}
+
+const listUsersByRole = `-- name: ListUsersByRole :many
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, phone_number, locale, verify_email_requested_at, version, deleted_at
+FROM users
+WHERE role = $1
+ORDER BY username
+`
+
+func (q *Queries) ListUsersByRole(ctx context.Context, role string) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersByRole, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Username,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Email,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.IsEmailVerified,
+			&i.Role,
+			&i.PhoneNumber,
+			&i.Locale,
+			&i.VerifyEmailRequestedAt,
+			&i.Version,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const pseudonymizeUser = `-- name: PseudonymizeUser :one
+UPDATE users
+SET full_name = $1,
+  email = $2,
+  hashed_password = '',
+  phone_number = '',
+  is_email_verified = false,
+  verify_email_requested_at = NULL,
+  deleted_at = now(),
+  version = version + 1
+WHERE username = $3
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, phone_number, locale, verify_email_requested_at, version, deleted_at
+`
+
+type PseudonymizeUserParams struct {
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// 口座と台帳の行は残すために username はそのままにして、個人を特定できる値を置き換える
+func (q *Queries) PseudonymizeUser(ctx context.Context, arg PseudonymizeUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, pseudonymizeUser, arg.FullName, arg.Email, arg.Username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.PhoneNumber,
+		&i.Locale,
+		&i.VerifyEmailRequestedAt,
+		&i.Version,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const rehashUserPassword = `-- name: RehashUserPassword :exec
+UPDATE users
+SET hashed_password = $1
+WHERE username = $2
+  AND hashed_password = $3
+`
+
+type RehashUserPasswordParams struct {
+	NewHashedPassword string `json:"new_hashed_password"`
+	Username          string `json:"username"`
+	OldHashedPassword string `json:"old_hashed_password"`
+}
+
+// ログインのときにハッシュを作り直す。同じパスワードなので version は変えない
+// 読んだ後にパスワードが変わった場合は、新しいパスワードを上書きしないように何もしない
+func (q *Queries) RehashUserPassword(ctx context.Context, arg RehashUserPasswordParams) error {
+	_, err := q.db.Exec(ctx, rehashUserPassword, arg.NewHashedPassword, arg.Username, arg.OldHashedPassword)
+	return err
+}
+
+const requestVerifyEmail = `-- name: RequestVerifyEmail :one
+UPDATE users
+SET verify_email_requested_at = now()
+WHERE username = $1
+  AND (
+    verify_email_requested_at IS NULL
+    OR verify_email_requested_at <= $2
+  )
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, phone_number, locale, verify_email_requested_at, version, deleted_at
+`
+
+type RequestVerifyEmailParams struct {
+	Username        string    `json:"username"`
+	RequestedBefore time.Time `json:"requested_before"`
+}
+
+// 前回の要求が requested_before より前の場合だけ要求した時刻を記録する。短い間に何度も送らないために使う
+func (q *Queries) RequestVerifyEmail(ctx context.Context, arg RequestVerifyEmailParams) (User, error) {
+	row := q.db.QueryRow(ctx, requestVerifyEmail, arg.Username, arg.RequestedBefore)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.IsEmailVerified,
+		&i.Role,
+		&i.PhoneNumber,
+		&i.Locale,
+		&i.VerifyEmailRequestedAt,
+		&i.Version,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -82,9 +306,12 @@ SET hashed_password = COALESCE($1, hashed_password),
   ),
   full_name = COALESCE($3, full_name),
   email = COALESCE($4, email),
-  is_email_verified = COALESCE($5, is_email_verified)
-WHERE username = $6
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role
+  is_email_verified = COALESCE($5, is_email_verified),
+  phone_number = COALESCE($6, phone_number),
+  locale = COALESCE($7, locale),
+  version = version + 1
+WHERE username = $8
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, is_email_verified, role, phone_number, locale, verify_email_requested_at, version, deleted_at
 `
 
 type UpdateUserParams struct {
@@ -93,6 +320,8 @@ type UpdateUserParams struct {
 	FullName          pgtype.Text        `json:"full_name"`
 	Email             pgtype.Text        `json:"email"`
 	IsEmailVerified   pgtype.Bool        `json:"is_email_verified"`
+	PhoneNumber       pgtype.Text        `json:"phone_number"`
+	Locale            pgtype.Text        `json:"locale"`
 	Username          string             `json:"username"`
 }
 
@@ -103,6 +332,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.FullName,
 		arg.Email,
 		arg.IsEmailVerified,
+		arg.PhoneNumber,
+		arg.Locale,
 		arg.Username,
 	)
 	var i User
@@ -115,6 +346,11 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.IsEmailVerified,
 		&i.Role,
+		&i.PhoneNumber,
+		&i.Locale,
+		&i.VerifyEmailRequestedAt,
+		&i.Version,
+		&i.DeletedAt,
 	)
 	return i, err
 }
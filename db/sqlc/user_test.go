@@ -2,9 +2,11 @@ package db
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shouta0715/simple-bank/util"
 	"github.com/stretchr/testify/require"
@@ -57,6 +59,17 @@ func TestGetUser(t *testing.T) {
 	require.WithinDuration(t, user1.PasswordChangedAt, user2.PasswordChangedAt, time.Second)
 }
 
+func TestGetUserByEmail(t *testing.T) {
+	user1 := createRandomUser(t)
+	user2, err := testStore.GetUserByEmail(context.Background(), user1.Email)
+
+	require.NoError(t, err)
+	require.Equal(t, user1.Username, user2.Username)
+
+	_, err = testStore.GetUserByEmail(context.Background(), util.RandomEmail())
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+}
+
 func TestUpdateUserOnlyFullName(t *testing.T) {
 	oldUser := createRandomUser(t)
 	newFullName := util.RandomOwner()
@@ -126,6 +139,46 @@ func TestUpdateUserOnlyPassword(t *testing.T) {
 	require.WithinDuration(t, oldUser.PasswordChangedAt, updateUser.PasswordChangedAt, time.Second)
 }
 
+func TestRehashUserPassword(t *testing.T) {
+	user := createRandomUser(t)
+	rehashed, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	// 読んだ後にパスワードが変わった場合は上書きしない
+	changed, err := testStore.UpdateUser(context.Background(), UpdateUserParams{
+		Username: user.Username,
+		HashedPassword: pgtype.Text{
+			String: rehashed + "changed",
+			Valid:  true,
+		},
+	})
+	require.NoError(t, err)
+
+	err = testStore.RehashUserPassword(context.Background(), RehashUserPasswordParams{
+		Username:          user.Username,
+		OldHashedPassword: user.HashedPassword,
+		NewHashedPassword: rehashed,
+	})
+	require.NoError(t, err)
+
+	got, err := testStore.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, changed.HashedPassword, got.HashedPassword)
+
+	// 読んだときのハッシュのままなら作り直し、version は変えない
+	err = testStore.RehashUserPassword(context.Background(), RehashUserPasswordParams{
+		Username:          user.Username,
+		OldHashedPassword: changed.HashedPassword,
+		NewHashedPassword: rehashed,
+	})
+	require.NoError(t, err)
+
+	got, err = testStore.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, rehashed, got.HashedPassword)
+	require.Equal(t, changed.Version, got.Version)
+}
+
 func TestUpdateUserAllFields(t *testing.T) {
 	oldUser := createRandomUser(t)
 	newFullName := util.RandomOwner()
@@ -160,3 +213,309 @@ func TestUpdateUserAllFields(t *testing.T) {
 	require.WithinDuration(t, oldUser.CreatedAt, updateUser.CreatedAt, time.Second)
 	require.WithinDuration(t, oldUser.PasswordChangedAt, updateUser.PasswordChangedAt, time.Second)
 }
+
+func TestUpdateUserTxChangePassword(t *testing.T) {
+	user := createRandomUser(t)
+
+	session, err := testStore.CreateSession(context.Background(), CreateSessionParams{
+		ID:               uuid.NewString(),
+		Username:         user.Username,
+		RefreshTokenHash: util.HashSecret(util.RandomString(32)),
+		ExpiresAt:        time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.False(t, session.IsBlocked)
+
+	hashedPassword, err := util.HashPassword(util.RandomString(12))
+	require.NoError(t, err)
+
+	var called []string
+
+	result, err := testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username:       user.Username,
+			HashedPassword: pgtype.Text{String: hashedPassword, Valid: true},
+		},
+		AfterPasswordChange: func(user User) error {
+			called = append(called, user.Username)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, hashedPassword, result.User.HashedPassword)
+	require.Equal(t, []string{user.Username}, called)
+
+	// パスワードを変える前のセッションは使えない
+	session, err = testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+}
+
+func TestUpdateUserTxVersion(t *testing.T) {
+	user := createRandomUser(t)
+	require.Equal(t, int64(1), user.Version)
+
+	arg := UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			FullName: pgtype.Text{String: util.RandomOwner(), Valid: true},
+		},
+		ExpectedVersion: user.Version,
+	}
+
+	result, err := testStore.UpdateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, user.Version+1, result.User.Version)
+
+	// 読んだ後にほかの更新があった場合は更新しない
+	_, err = testStore.UpdateUserTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	got, err := testStore.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, result.User, got)
+}
+
+func TestUpdateUserTxDeletedUser(t *testing.T) {
+	account := createRandomAccount(t)
+	account, err := testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: -account.Balance,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.DeleteUserTx(context.Background(), DeleteUserTxParams{Username: account.Owner})
+	require.NoError(t, err)
+
+	// 削除したユーザーの個人を特定できる値を書き戻さない
+	_, err = testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: account.Owner,
+			FullName: pgtype.Text{String: util.RandomOwner(), Valid: true},
+		},
+	})
+	require.ErrorIs(t, err, ErrUserDeleted)
+
+	got, err := testStore.GetUser(context.Background(), account.Owner)
+	require.NoError(t, err)
+	require.Equal(t, result.User, got)
+
+	deleted, err := testStore.IsUserDeleted(context.Background(), account.Owner)
+	require.NoError(t, err)
+	require.True(t, deleted)
+}
+
+func TestDeleteUserTx(t *testing.T) {
+	account := createRandomAccount(t)
+
+	// 残高のある口座があるうちは削除しない
+	account, err := testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: 1 - account.Balance,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.DeleteUserTx(context.Background(), DeleteUserTxParams{Username: account.Owner})
+	require.ErrorIs(t, err, ErrNonZeroBalance)
+
+	user, err := testStore.GetUser(context.Background(), account.Owner)
+	require.NoError(t, err)
+	require.False(t, user.DeletedAt.Valid)
+
+	account, err = testStore.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: -account.Balance,
+	})
+	require.NoError(t, err)
+
+	session, err := testStore.CreateSession(context.Background(), CreateSessionParams{
+		ID:               uuid.NewString(),
+		Username:         user.Username,
+		RefreshTokenHash: util.HashSecret(util.RandomString(32)),
+		UserAgent:        "Mozilla/5.0",
+		ClientIp:         "203.0.113.1",
+		ExpiresAt:        time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	dataExport, err := testStore.CreateDataExport(context.Background(), user.Username)
+	require.NoError(t, err)
+
+	// 変える前のメールアドレスに送った確認のメール
+	oldEmail := util.RandomEmail()
+
+	_, err = testStore.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		Username:       user.Username,
+		Email:          oldEmail,
+		SecretCodeHash: util.HashSecret(util.RandomString(32)),
+	})
+	require.NoError(t, err)
+
+	createDelivery := func(recipient string, cc string, templateData string) EmailDelivery {
+		delivery, err := testStore.CreateEmailDelivery(context.Background(), CreateEmailDeliveryParams{
+			Recipient:    recipient,
+			Cc:           cc,
+			Subject:      util.RandomString(10),
+			ContentHtml:  util.RandomString(20),
+			ContentText:  util.RandomString(20),
+			Status:       util.EmailDeliverySent,
+			TemplateData: []byte(templateData),
+		})
+		require.NoError(t, err)
+
+		return delivery
+	}
+
+	deliveries := []EmailDelivery{
+		createDelivery(strings.ToUpper(user.Email), "", "{}"),
+		createDelivery(util.RandomEmail(), oldEmail, "{}"),
+		createDelivery(util.RandomEmail(), "", `{"username": "`+user.Username+`"}`),
+	}
+	otherDelivery := createDelivery(util.RandomEmail(), "", "{}")
+
+	createRandomNotification(t, user)
+
+	other := createRandomAccount(t)
+	request := createRandomPaymentRequest(t, account, other, time.Now().Add(time.Hour))
+
+	review, err := testStore.CreateFraudReview(context.Background(), CreateFraudReviewParams{
+		FromAccountID: other.ID,
+		ToAccountID:   account.ID,
+		Amount:        10,
+		Currency:      other.Currency,
+		RequestedBy:   other.Owner,
+		Rules:         "test",
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.UpsertNotificationPreference(context.Background(), UpsertNotificationPreferenceParams{
+		Username: user.Username,
+		Event:    util.NotificationTransferReceived,
+		Email:    true,
+	})
+	require.NoError(t, err)
+
+	alertRule := createTestAlertRule(t, account, util.AlertLowBalance, 10)
+
+	createBeneficiary := func(owner string, accountID int64) Beneficiary {
+		beneficiary, err := testStore.CreateBeneficiary(context.Background(), CreateBeneficiaryParams{
+			Owner:     owner,
+			Nickname:  util.RandomOwner(),
+			AccountID: accountID,
+		})
+		require.NoError(t, err)
+
+		return beneficiary
+	}
+
+	// ユーザーが登録した受取人と、ほかのユーザーがユーザーの口座に付けた受取人
+	beneficiaries := []Beneficiary{
+		createBeneficiary(user.Username, other.ID),
+		createBeneficiary(other.Owner, account.ID),
+	}
+	otherBeneficiary := createBeneficiary(other.Owner, createRandomAccount(t).ID)
+
+	operation, err := testStore.RequestOperationTx(context.Background(), CreatePendingOperationParams{
+		Kind: util.OperationDeposit,
+		ToAccountID: pgtype.Int8{
+			Int64: account.ID,
+			Valid: true,
+		},
+		Amount:      10,
+		Currency:    account.Currency,
+		RequestedBy: other.Owner,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.DeleteUserTx(context.Background(), DeleteUserTxParams{Username: user.Username})
+	require.NoError(t, err)
+
+	deleted := result.User
+	require.Equal(t, user.Username, deleted.Username)
+	require.Equal(t, DeletedUserFullName, deleted.FullName)
+	require.NotEqual(t, user.Email, deleted.Email)
+	require.Empty(t, deleted.HashedPassword)
+	require.False(t, deleted.IsEmailVerified)
+	require.True(t, deleted.DeletedAt.Valid)
+
+	// 台帳のために口座は残すが、閉じてお金を受け取れないようにする
+	got, err := testStore.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, got.Balance)
+	require.True(t, got.ClosedAt.Valid)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: other.ID,
+		ToAccountID:   account.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	_, err = testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account.ID,
+		Amount:    1,
+	})
+	require.ErrorIs(t, err, ErrAccountClosed)
+
+	session, err = testStore.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+	require.Empty(t, session.UserAgent)
+	require.Empty(t, session.ClientIp)
+
+	verifyEmails, err := testStore.ListVerifyEmailsByUsername(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, verifyEmails)
+
+	// 今と前のメールアドレスに送ったメールの記録を消し、ほかのユーザーの記録は残す
+	for _, delivery := range deliveries {
+		_, err = testStore.GetEmailDelivery(context.Background(), delivery.ID)
+		require.ErrorIs(t, err, ErrorRecordNotFound)
+	}
+
+	_, err = testStore.GetEmailDelivery(context.Background(), otherDelivery.ID)
+	require.NoError(t, err)
+
+	notifications, err := testStore.ListNotifications(context.Background(), ListNotificationsParams{
+		Username: user.Username,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+
+	preferences, err := testStore.ListNotificationPreferences(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, preferences)
+
+	_, err = testStore.GetAlertRule(context.Background(), alertRule.ID)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+
+	for _, beneficiary := range beneficiaries {
+		_, err = testStore.GetBeneficiary(context.Background(), beneficiary.ID)
+		require.ErrorIs(t, err, ErrorRecordNotFound)
+	}
+
+	_, err = testStore.GetBeneficiary(context.Background(), otherBeneficiary.ID)
+	require.NoError(t, err)
+
+	// 終わっていない請求、審査、承認待ちの操作は取り消す
+	request, err = testStore.GetPaymentRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, util.PaymentRequestCancelled, request.Status)
+
+	review, err = testStore.GetFraudReview(context.Background(), review.ID)
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewCancelled, review.Status)
+
+	operation, err = testStore.GetPendingOperation(context.Background(), operation.ID)
+	require.NoError(t, err)
+	require.Equal(t, util.ReviewCancelled, operation.Status)
+
+	// 書き出したデータにも個人を特定できる値が含まれるので消す
+	_, err = testStore.GetDataExport(context.Background(), dataExport.ID)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+
+	_, err = testStore.DeleteUserTx(context.Background(), DeleteUserTxParams{Username: user.Username})
+	require.ErrorIs(t, err, ErrUserDeleted)
+}
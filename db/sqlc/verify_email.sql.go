@@ -10,58 +10,188 @@ import (
 )
 
 const createVerifyEmail = `-- name: CreateVerifyEmail :one
-INSERT INTO verify_emails (username, email, secret_code)
+INSERT INTO verify_emails (username, email, secret_code_hash)
 VALUES ($1, $2, $3)
-RETURNING id, username, email, secret_code, is_used, created_at, expires_at
+RETURNING id, username, email, secret_code_hash, is_used, created_at, expires_at, attempts
 `
 
 type CreateVerifyEmailParams struct {
-	Username   string `json:"username"`
-	Email      string `json:"email"`
-	SecretCode string `json:"secret_code"`
+	Username       string `json:"username"`
+	Email          string `json:"email"`
+	SecretCodeHash string `json:"secret_code_hash"`
 }
 
 func (q *Queries) CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error) {
-	row := q.db.QueryRow(ctx, createVerifyEmail, arg.Username, arg.Email, arg.SecretCode)
+	row := q.db.QueryRow(ctx, createVerifyEmail, arg.Username, arg.Email, arg.SecretCodeHash)
 	var i VerifyEmail
 	err := row.Scan(
 		&i.ID,
 		&i.Username,
 		&i.Email,
-		&i.SecretCode,
+		&i.SecretCodeHash,
 		&i.IsUsed,
 		&i.CreatedAt,
 		&i.ExpiresAt,
+		&i.Attempts,
 	)
 	return i, err
 }
 
+const deleteUserVerifyEmails = `-- name: DeleteUserVerifyEmails :many
+DELETE FROM verify_emails
+WHERE username = $1
+RETURNING id, username, email, secret_code_hash, is_used, created_at, expires_at, attempts
+`
+
+// 削除したユーザーの確認コードを消す。確認のメールを送ったアドレスを返す
+func (q *Queries) DeleteUserVerifyEmails(ctx context.Context, username string) ([]VerifyEmail, error) {
+	rows, err := q.db.Query(ctx, deleteUserVerifyEmails, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []VerifyEmail{}
+	for rows.Next() {
+		var i VerifyEmail
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.SecretCodeHash,
+			&i.IsUsed,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.Attempts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVerifyEmailForUpdate = `-- name: GetVerifyEmailForUpdate :one
+SELECT id, username, email, secret_code_hash, is_used, created_at, expires_at, attempts
+FROM verify_emails
+WHERE id = $1
+LIMIT 1 FOR NO KEY
+UPDATE
+`
+
+func (q *Queries) GetVerifyEmailForUpdate(ctx context.Context, id int64) (VerifyEmail, error) {
+	row := q.db.QueryRow(ctx, getVerifyEmailForUpdate, id)
+	var i VerifyEmail
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCodeHash,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.Attempts,
+	)
+	return i, err
+}
+
+const incrementVerifyEmailAttempts = `-- name: IncrementVerifyEmailAttempts :one
+UPDATE verify_emails
+SET attempts = attempts + 1
+WHERE id = $1
+RETURNING id, username, email, secret_code_hash, is_used, created_at, expires_at, attempts
+`
+
+// 間違った確認コードを試した回数を数える
+func (q *Queries) IncrementVerifyEmailAttempts(ctx context.Context, id int64) (VerifyEmail, error) {
+	row := q.db.QueryRow(ctx, incrementVerifyEmailAttempts, id)
+	var i VerifyEmail
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.SecretCodeHash,
+		&i.IsUsed,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.Attempts,
+	)
+	return i, err
+}
+
+const invalidateVerifyEmails = `-- name: InvalidateVerifyEmails :exec
+UPDATE verify_emails
+SET is_used = TRUE
+WHERE username = $1
+  AND is_used = FALSE
+`
+
+// まだ使っていない確認コードをすべて使えなくする。メールアドレスを変えたときに古いアドレスに送ったコードを無効にする
+func (q *Queries) InvalidateVerifyEmails(ctx context.Context, username string) error {
+	_, err := q.db.Exec(ctx, invalidateVerifyEmails, username)
+	return err
+}
+
+const listVerifyEmailsByUsername = `-- name: ListVerifyEmailsByUsername :many
+SELECT id, username, email, secret_code_hash, is_used, created_at, expires_at, attempts
+FROM verify_emails
+WHERE username = $1
+ORDER BY id
+`
+
+func (q *Queries) ListVerifyEmailsByUsername(ctx context.Context, username string) ([]VerifyEmail, error) {
+	rows, err := q.db.Query(ctx, listVerifyEmailsByUsername, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []VerifyEmail{}
+	for rows.Next() {
+		var i VerifyEmail
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.SecretCodeHash,
+			&i.IsUsed,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.Attempts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateVerifyEmail = `-- name: UpdateVerifyEmail :one
 UPDATE verify_emails
 SET is_used = TRUE
 WHERE id = $1
-  AND secret_code = $2
   AND is_used = FALSE
   AND expires_at > now()
-RETURNING id, username, email, secret_code, is_used, created_at, expires_at
+RETURNING id, username, email, secret_code_hash, is_used, created_at, expires_at, attempts
 `
 
-type UpdateVerifyEmailParams struct {
-	ID         int64  `json:"id"`
-	SecretCode string `json:"secret_code"`
-}
-
-func (q *Queries) UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error) {
-	row := q.db.QueryRow(ctx, updateVerifyEmail, arg.ID, arg.SecretCode)
+// 確認コードはハッシュを一定時間で比べてから使ったことにする
+func (q *Queries) UpdateVerifyEmail(ctx context.Context, id int64) (VerifyEmail, error) {
+	row := q.db.QueryRow(ctx, updateVerifyEmail, id)
 	var i VerifyEmail
 	err := row.Scan(
 		&i.ID,
 		&i.Username,
 		&i.Email,
-		&i.SecretCode,
+		&i.SecretCodeHash,
 		&i.IsUsed,
 		&i.CreatedAt,
 		&i.ExpiresAt,
+		&i.Attempts,
 	)
 	return i, err
 }
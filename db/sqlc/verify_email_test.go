@@ -2,18 +2,22 @@ package db
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shouta0715/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
 
-func createRandomVerifyEmail(t *testing.T) VerifyEmail {
+func createRandomVerifyEmail(t *testing.T) (VerifyEmail, string) {
 	user := createRandomUser(t)
+	secretCode := util.RandomString(32)
 	arg := CreateVerifyEmailParams{
-		Username:   user.Username,
-		Email:      util.RandomEmail(),
-		SecretCode: util.RandomString(6),
+		Username:       user.Username,
+		Email:          util.RandomEmail(),
+		SecretCodeHash: util.HashSecret(secretCode),
 	}
 
 	verifyEmail, err := testStore.CreateVerifyEmail(context.Background(), arg)
@@ -22,11 +26,143 @@ func createRandomVerifyEmail(t *testing.T) VerifyEmail {
 
 	require.Equal(t, arg.Username, verifyEmail.Username)
 	require.Equal(t, arg.Email, verifyEmail.Email)
-	require.Equal(t, arg.SecretCode, verifyEmail.SecretCode)
+	require.Equal(t, arg.SecretCodeHash, verifyEmail.SecretCodeHash)
+	require.Zero(t, verifyEmail.Attempts)
 
-	return verifyEmail
+	return verifyEmail, secretCode
 }
 
 func TestCreateVerifyEmail(t *testing.T) {
 	createRandomVerifyEmail(t)
 }
+
+func TestVerifyEmailTx(t *testing.T) {
+	verifyEmail, secretCode := createRandomVerifyEmail(t)
+
+	result, err := testStore.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailId:    verifyEmail.ID,
+		SecretCode: secretCode,
+	})
+	require.NoError(t, err)
+	require.True(t, result.VerifyEmail.IsUsed)
+	require.True(t, result.User.IsEmailVerified)
+
+	// 一度使ったコードはもう使えない
+	_, err = testStore.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailId:    verifyEmail.ID,
+		SecretCode: secretCode,
+	})
+	require.ErrorIs(t, err, ErrInvalidSecretCode)
+}
+
+func TestVerifyEmailTxTooManyAttempts(t *testing.T) {
+	verifyEmail, secretCode := createRandomVerifyEmail(t)
+
+	for i := 0; i < MaxVerifyEmailAttempts; i++ {
+		_, err := testStore.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+			EmailId:    verifyEmail.ID,
+			SecretCode: util.RandomString(32),
+		})
+		require.ErrorIs(t, err, ErrInvalidSecretCode)
+	}
+
+	// 間違えた回数はトランザクションを失敗させても残る
+	verifyEmail, err := testStore.GetVerifyEmailForUpdate(context.Background(), verifyEmail.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(MaxVerifyEmailAttempts), verifyEmail.Attempts)
+
+	// 回数を超えた後は正しいコードでも確認できない
+	_, err = testStore.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailId:    verifyEmail.ID,
+		SecretCode: secretCode,
+	})
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+}
+
+func TestUpdateUserTxChangeEmail(t *testing.T) {
+	verifyEmail, secretCode := createRandomVerifyEmail(t)
+
+	_, err := testStore.UpdateUser(context.Background(), UpdateUserParams{
+		Username:        verifyEmail.Username,
+		IsEmailVerified: pgtype.Bool{Bool: true, Valid: true},
+	})
+	require.NoError(t, err)
+
+	oldUser, err := testStore.GetUser(context.Background(), verifyEmail.Username)
+	require.NoError(t, err)
+
+	newEmail := util.RandomEmail()
+	var called []string
+
+	result, err := testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: oldUser.Username,
+			Email:    pgtype.Text{String: newEmail, Valid: true},
+		},
+		AfterEmailChange: func(user User, oldEmail string) error {
+			called = append(called, oldEmail)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, newEmail, result.User.Email)
+	require.False(t, result.User.IsEmailVerified)
+	require.Equal(t, oldUser.Email, result.OldEmail)
+	require.Equal(t, []string{oldUser.Email}, called)
+
+	// 古いアドレスに送ったコードは使えない
+	_, err = testStore.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailId:    verifyEmail.ID,
+		SecretCode: secretCode,
+	})
+	require.ErrorIs(t, err, ErrInvalidSecretCode)
+
+	// 同じアドレスの場合は何もしない
+	result, err = testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: oldUser.Username,
+			Email:    pgtype.Text{String: newEmail, Valid: true},
+		},
+		AfterEmailChange: func(user User, oldEmail string) error {
+			return errors.New("must not be called")
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.OldEmail)
+}
+
+func TestResendVerifyEmailTx(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := ResendVerifyEmailTxParams{
+		RequestVerifyEmailParams: RequestVerifyEmailParams{
+			Username:        user.Username,
+			RequestedBefore: time.Now().Add(-time.Minute),
+		},
+		AfterRequest: func(user User) error {
+			return nil
+		},
+	}
+
+	result, err := testStore.ResendVerifyEmailTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.User.VerifyEmailRequestedAt.Valid)
+
+	// 1分以内にもう一度送ることはできない
+	_, err = testStore.ResendVerifyEmailTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrorRecordNotFound)
+
+	// タスクを登録できなければ記録も残さない
+	other := createRandomUser(t)
+	arg.Username = other.Username
+	arg.AfterRequest = func(user User) error {
+		return errors.New("cannot distribute task")
+	}
+
+	_, err = testStore.ResendVerifyEmailTx(context.Background(), arg)
+	require.Error(t, err)
+
+	other, err = testStore.GetUser(context.Background(), other.Username)
+	require.NoError(t, err)
+	require.False(t, other.VerifyEmailRequestedAt.Valid)
+}
@@ -0,0 +1,176 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+)
+
+// アーカイブに入れるファイルの名前
+const (
+	ProfileFile      = "profile.json"
+	AccountsFile     = "accounts.json"
+	EntriesFile      = "entries.json"
+	TransfersFile    = "transfers.json"
+	SessionsFile     = "sessions.json"
+	VerifyEmailsFile = "verify_emails.json"
+)
+
+// Profile はユーザーの情報。パスワードのハッシュは含めない
+type Profile struct {
+	Username          string    `json:"username"`
+	FullName          string    `json:"full_name"`
+	Email             string    `json:"email"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
+	PhoneNumber       string    `json:"phone_number"`
+	Locale            string    `json:"locale"`
+	Role              string    `json:"role"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// Session はログインの記録。リフレッシュトークンのハッシュは含めない
+type Session struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	IsBlocked bool      `json:"is_blocked"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// VerifyEmail はメールアドレスの確認の記録。確認コードのハッシュは含めない
+type VerifyEmail struct {
+	ID        int64     `json:"id"`
+	Email     string    `json:"email"`
+	IsUsed    bool      `json:"is_used"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// BuildArchive はユーザーについて保存しているデータを JSON にして ZIP にまとめる
+func BuildArchive(ctx context.Context, store db.Store, username string) ([]byte, error) {
+	user, err := store.GetUser(ctx, username)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot get user: %w", err)
+	}
+
+	accounts, err := store.ListAccountsByOwner(ctx, username)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot list accounts: %w", err)
+	}
+
+	entries, err := store.ListEntriesByOwner(ctx, username)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot list entries: %w", err)
+	}
+
+	transfers, err := store.ListTransfersByOwner(ctx, username)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot list transfers: %w", err)
+	}
+
+	sessions, err := store.ListSessionsByUsername(ctx, username)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot list sessions: %w", err)
+	}
+
+	verifyEmails, err := store.ListVerifyEmailsByUsername(ctx, username)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot list verify emails: %w", err)
+	}
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{ProfileFile, convertProfile(user)},
+		{AccountsFile, accounts},
+		{EntriesFile, entries},
+		{TransfersFile, transfers},
+		{SessionsFile, convertSessions(sessions)},
+		{VerifyEmailsFile, convertVerifyEmails(verifyEmails)},
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		f, err := w.Create(file.name)
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot create %s: %w", file.name, err)
+		}
+
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, fmt.Errorf("cannot write %s: %w", file.name, err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("cannot close archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func convertProfile(user db.User) Profile {
+	return Profile{
+		Username:          user.Username,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		IsEmailVerified:   user.IsEmailVerified,
+		PhoneNumber:       user.PhoneNumber,
+		Locale:            user.Locale,
+		Role:              user.Role,
+		PasswordChangedAt: user.PasswordChangedAt,
+		CreatedAt:         user.CreatedAt,
+	}
+}
+
+func convertSessions(sessions []db.Session) []Session {
+	result := make([]Session, 0, len(sessions))
+
+	for _, session := range sessions {
+		result = append(result, Session{
+			ID:        session.ID,
+			UserAgent: session.UserAgent,
+			ClientIP:  session.ClientIp,
+			IsBlocked: session.IsBlocked,
+			ExpiresAt: session.ExpiresAt,
+			CreatedAt: session.CreatedAt,
+		})
+	}
+
+	return result
+}
+
+func convertVerifyEmails(verifyEmails []db.VerifyEmail) []VerifyEmail {
+	result := make([]VerifyEmail, 0, len(verifyEmails))
+
+	for _, verifyEmail := range verifyEmails {
+		result = append(result, VerifyEmail{
+			ID:        verifyEmail.ID,
+			Email:     verifyEmail.Email,
+			IsUsed:    verifyEmail.IsUsed,
+			CreatedAt: verifyEmail.CreatedAt,
+			ExpiresAt: verifyEmail.ExpiresAt,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,136 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBuildArchive(t *testing.T) {
+	user := db.User{
+		Username:       util.RandomOwner(),
+		FullName:       util.RandomOwner(),
+		Email:          util.RandomEmail(),
+		HashedPassword: "hashed-password",
+	}
+
+	account := db.Account{
+		ID:       int64(util.RandomInt(1, 1000)),
+		Owner:    user.Username,
+		Balance:  util.RandomMoney(),
+		Currency: util.RandomCurrency(),
+	}
+
+	session := db.Session{
+		ID:               uuid.NewString(),
+		Username:         user.Username,
+		RefreshTokenHash: "refresh-token-hash",
+	}
+
+	verifyEmail := db.VerifyEmail{
+		ID:             int64(util.RandomInt(1, 1000)),
+		Username:       user.Username,
+		Email:          user.Email,
+		SecretCodeHash: "secret-code-hash",
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, archive []byte, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListAccountsByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return([]db.Account{account}, nil)
+				store.EXPECT().ListEntriesByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return([]db.Entry{}, nil)
+				store.EXPECT().ListTransfersByOwner(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return([]db.Transfer{}, nil)
+				store.EXPECT().ListSessionsByUsername(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return([]db.Session{session}, nil)
+				store.EXPECT().ListVerifyEmailsByUsername(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return([]db.VerifyEmail{verifyEmail}, nil)
+			},
+			checkResponse: func(t *testing.T, archive []byte, err error) {
+				require.NoError(t, err)
+
+				files := readArchive(t, archive)
+				require.Len(t, files, 6)
+
+				var profile Profile
+				require.NoError(t, json.Unmarshal(files[ProfileFile], &profile))
+				require.Equal(t, user.Username, profile.Username)
+				require.Equal(t, user.Email, profile.Email)
+
+				var accounts []db.Account
+				require.NoError(t, json.Unmarshal(files[AccountsFile], &accounts))
+				require.Equal(t, []db.Account{account}, accounts)
+
+				var sessions []Session
+				require.NoError(t, json.Unmarshal(files[SessionsFile], &sessions))
+				require.Len(t, sessions, 1)
+				require.Equal(t, session.ID, sessions[0].ID)
+
+				// パスワードやトークンのハッシュは含めない
+				for _, data := range files {
+					require.NotContains(t, string(data), user.HashedPassword)
+					require.NotContains(t, string(data), session.RefreshTokenHash)
+					require.NotContains(t, string(data), verifyEmail.SecretCodeHash)
+				}
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ListAccountsByOwner(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("connection refused"))
+				store.EXPECT().ListEntriesByOwner(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, archive []byte, err error) {
+				require.Error(t, err)
+				require.Nil(t, archive)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			archive, err := BuildArchive(context.Background(), store, user.Username)
+			tc.checkResponse(t, archive, err)
+		})
+	}
+}
+
+func readArchive(t *testing.T, archive []byte) map[string][]byte {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+
+	for _, file := range r.File {
+		f, err := file.Open()
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		files[file.Name] = data
+	}
+
+	return files
+}
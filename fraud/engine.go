@@ -0,0 +1,132 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+)
+
+var ErrBlocked = errors.New("transfer blocked by fraud rules")
+
+// Engine は送金が記録される前に、設定されたルールで送金を評価する
+type Engine struct {
+	rules []Rule
+	now   func() time.Time
+}
+
+type Transfer struct {
+	Username      string
+	FromAccountID int64
+	ToAccountID   int64
+	Amount        int64
+}
+
+type Decision struct {
+	Action Action
+	// 当てはまったルールの名前
+	Rules []string
+}
+
+func NewEngine(rules []Rule) (*Engine, error) {
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Engine{
+		rules: rules,
+		now:   time.Now,
+	}, nil
+}
+
+// Evaluate は当てはまったルールのうち、最も重い Action を返す
+// 送金と同じトランザクションの Querier を渡す
+func (engine *Engine) Evaluate(ctx context.Context, q db.Querier, transfer Transfer) (Decision, error) {
+	decision := Decision{Action: ActionAllow}
+
+	for _, rule := range engine.rules {
+		matched, err := engine.match(ctx, q, rule, transfer)
+
+		if err != nil {
+			return decision, fmt.Errorf("cannot evaluate rule %s: %w", rule.Name, err)
+		}
+
+		if !matched {
+			continue
+		}
+
+		decision.Rules = append(decision.Rules, rule.Name)
+
+		if rule.Action.severity() > decision.Action.severity() {
+			decision.Action = rule.Action
+		}
+	}
+
+	return decision, nil
+}
+
+func (engine *Engine) match(ctx context.Context, q db.Querier, rule Rule, transfer Transfer) (bool, error) {
+	since := engine.now().Add(-rule.Window)
+
+	switch rule.Type {
+	case RuleNewPayeeVelocity:
+		transferred, err := q.HasTransferredTo(ctx, db.HasTransferredToParams{
+			FromAccountID: transfer.FromAccountID,
+			ToAccountID:   transfer.ToAccountID,
+		})
+
+		if err != nil || transferred {
+			return false, err
+		}
+
+		count, err := q.CountRecentNewPayees(ctx, db.CountRecentNewPayeesParams{
+			FromAccountID: transfer.FromAccountID,
+			Since:         since,
+		})
+
+		if err != nil {
+			return false, err
+		}
+
+		// 今回の送金先も初めての送金先として数える
+		return count+1 >= rule.Threshold, nil
+
+	case RuleAmountAboveAverage:
+		average, err := q.GetAverageTransferAmount(ctx, db.GetAverageTransferAmountParams{
+			FromAccountID: transfer.FromAccountID,
+			Since:         since,
+		})
+
+		if err != nil {
+			return false, err
+		}
+
+		if average.TransferCount < rule.MinHistory || average.TransferCount == 0 {
+			return false, nil
+		}
+
+		return transfer.Amount > average.AverageAmount*rule.Multiplier, nil
+
+	case RuleLoginFromNewIP:
+		_, err := q.GetRecentLoginFromNewIP(ctx, db.GetRecentLoginFromNewIPParams{
+			Username: transfer.Username,
+			Since:    since,
+		})
+
+		if err != nil {
+			if errors.Is(err, db.ErrorRecordNotFound) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,147 @@
+package fraud
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEngineEvaluate(t *testing.T) {
+	now := time.Date(2023, time.March, 15, 13, 0, 0, 0, time.UTC)
+
+	rules := []Rule{
+		{Name: "new_payees", Type: RuleNewPayeeVelocity, Window: 10 * time.Minute, Threshold: 3, Action: ActionHold},
+		{Name: "large_amount", Type: RuleAmountAboveAverage, Window: 90 * 24 * time.Hour, Multiplier: 5, MinHistory: 3, Action: ActionBlock},
+		{Name: "new_ip", Type: RuleLoginFromNewIP, Window: 15 * time.Minute, Action: ActionHold},
+	}
+
+	transfer := Transfer{
+		Username:      "alice",
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        1000,
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, decision Decision)
+	}{
+		{
+			name: "Allow",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				store.EXPECT().CountRecentNewPayees(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					GetAverageTransferAmount(gomock.Any(), gomock.Eq(db.GetAverageTransferAmountParams{
+						FromAccountID: 1,
+						Since:         now.Add(-90 * 24 * time.Hour),
+					})).
+					Times(1).
+					Return(db.GetAverageTransferAmountRow{TransferCount: 10, AverageAmount: 500}, nil)
+				store.EXPECT().GetRecentLoginFromNewIP(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, db.ErrorRecordNotFound)
+			},
+			check: func(t *testing.T, decision Decision) {
+				require.Equal(t, ActionAllow, decision.Action)
+				require.Empty(t, decision.Rules)
+			},
+		},
+		{
+			name: "Hold",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				store.EXPECT().
+					CountRecentNewPayees(gomock.Any(), gomock.Eq(db.CountRecentNewPayeesParams{
+						FromAccountID: 1,
+						Since:         now.Add(-10 * time.Minute),
+					})).
+					Times(1).
+					Return(int64(2), nil)
+				// 履歴が少ないので評価しない
+				store.EXPECT().
+					GetAverageTransferAmount(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetAverageTransferAmountRow{TransferCount: 2, AverageAmount: 10}, nil)
+				store.EXPECT().
+					GetRecentLoginFromNewIP(gomock.Any(), gomock.Eq(db.GetRecentLoginFromNewIPParams{
+						Username: "alice",
+						Since:    now.Add(-15 * time.Minute),
+					})).
+					Times(1).
+					Return(db.Session{Username: "alice"}, nil)
+			},
+			check: func(t *testing.T, decision Decision) {
+				require.Equal(t, ActionHold, decision.Action)
+				require.Equal(t, []string{"new_payees", "new_ip"}, decision.Rules)
+			},
+		},
+		{
+			name: "Block",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().HasTransferredTo(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				store.EXPECT().CountRecentNewPayees(gomock.Any(), gomock.Any()).Times(1).Return(int64(5), nil)
+				store.EXPECT().
+					GetAverageTransferAmount(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetAverageTransferAmountRow{TransferCount: 10, AverageAmount: 100}, nil)
+				store.EXPECT().GetRecentLoginFromNewIP(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, db.ErrorRecordNotFound)
+			},
+			check: func(t *testing.T, decision Decision) {
+				require.Equal(t, ActionBlock, decision.Action)
+				require.Equal(t, []string{"new_payees", "large_amount"}, decision.Rules)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			engine, err := NewEngine(rules)
+			require.NoError(t, err)
+			engine.now = func() time.Time { return now }
+
+			decision, err := engine.Evaluate(context.Background(), store, transfer)
+			require.NoError(t, err)
+
+			tc.check(t, decision)
+		})
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fraud_rules.yaml")
+
+	err := os.WriteFile(path, []byte(`rules:
+  - name: new_payees
+    type: new_payee_velocity
+    window: 10m
+    threshold: 3
+    action: hold
+`), 0o600)
+	require.NoError(t, err)
+
+	rules, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Equal(t, []Rule{
+		{Name: "new_payees", Type: RuleNewPayeeVelocity, Window: 10 * time.Minute, Threshold: 3, Action: ActionHold},
+	}, rules)
+
+	_, err = NewEngine(rules)
+	require.NoError(t, err)
+
+	_, err = NewEngine([]Rule{{Name: "unknown", Type: "unknown", Window: time.Minute, Action: ActionHold}})
+	require.Error(t, err)
+}
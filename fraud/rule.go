@@ -0,0 +1,99 @@
+package fraud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Action はルールに当てはまった送金をどう扱うか
+type Action string
+
+const (
+	ActionAllow Action = "allow"
+	ActionHold  Action = "hold"
+	ActionBlock Action = "block"
+)
+
+// severity は複数のルールに当てはまったときに、より重い Action を選ぶために使う
+func (action Action) severity() int {
+	switch action {
+	case ActionHold:
+		return 1
+	case ActionBlock:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// ルールの種類
+const (
+	// window 内に初めての送金先へ threshold 回以上送金した
+	RuleNewPayeeVelocity = "new_payee_velocity"
+	// 金額が window 内の平均の multiplier 倍を超えた。送金が min_history 件未満の場合は評価しない
+	RuleAmountAboveAverage = "amount_above_average"
+	// window 内にそれまで使われたことのないIPからログインした
+	RuleLoginFromNewIP = "login_from_new_ip"
+)
+
+type Rule struct {
+	Name       string        `mapstructure:"name"`
+	Type       string        `mapstructure:"type"`
+	Window     time.Duration `mapstructure:"window"`
+	Threshold  int64         `mapstructure:"threshold"`
+	Multiplier int64         `mapstructure:"multiplier"`
+	MinHistory int64         `mapstructure:"min_history"`
+	Action     Action        `mapstructure:"action"`
+}
+
+// LoadRules は YAML などの設定ファイルの rules からルールを読み込む
+func LoadRules(path string) ([]Rule, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("cannot read fraud rules: %w", err)
+	}
+
+	var rules []Rule
+
+	if err := v.UnmarshalKey("rules", &rules); err != nil {
+		return nil, fmt.Errorf("cannot decode fraud rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (rule Rule) validate() error {
+	if rule.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+
+	switch rule.Action {
+	case ActionAllow, ActionHold, ActionBlock:
+	default:
+		return fmt.Errorf("rule %s: unsupported action %q", rule.Name, rule.Action)
+	}
+
+	if rule.Window <= 0 {
+		return fmt.Errorf("rule %s: window must be positive", rule.Name)
+	}
+
+	switch rule.Type {
+	case RuleNewPayeeVelocity:
+		if rule.Threshold <= 0 {
+			return fmt.Errorf("rule %s: threshold must be positive", rule.Name)
+		}
+	case RuleAmountAboveAverage:
+		if rule.Multiplier <= 0 {
+			return fmt.Errorf("rule %s: multiplier must be positive", rule.Name)
+		}
+	case RuleLoginFromNewIP:
+	default:
+		return fmt.Errorf("rule %s: unsupported type %q", rule.Name, rule.Type)
+	}
+
+	return nil
+}
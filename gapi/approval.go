@@ -0,0 +1,61 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requiresApproval は銀行員の操作に二人目の銀行員の承認が必要かどうかを返す
+// 閾値は通貨ごとに currencies テーブルで設定し、0 の場合は承認を求めない
+func (server *Server) requiresApproval(amount int64, currency string) bool {
+	c, ok := util.GetCurrency(currency)
+	return ok && c.ApprovalThreshold > 0 && amount > c.ApprovalThreshold
+}
+
+type operationRequest struct {
+	kind          string
+	fromAccountID int64
+	toAccountID   int64
+	amount        int64
+	currency      string
+	requestedBy   string
+	// 承認した送金に記録するメモ、参照番号、カテゴリー
+	memo      string
+	reference string
+	category  string
+}
+
+// requestOperation は操作を実行せずに承認待ちとして記録する
+func (server *Server) requestOperation(ctx context.Context, req operationRequest) (*pb.PendingOperation, error) {
+	operation, err := server.store.RequestOperationTx(ctx, db.CreatePendingOperationParams{
+		Kind: req.kind,
+		FromAccountID: pgtype.Int8{
+			Int64: req.fromAccountID,
+			Valid: req.fromAccountID != 0,
+		},
+		ToAccountID: pgtype.Int8{
+			Int64: req.toAccountID,
+			Valid: req.toAccountID != 0,
+		},
+		Amount:      req.amount,
+		Currency:    req.currency,
+		RequestedBy: req.requestedBy,
+		ExpiresAt:   time.Now().Add(server.config.ApprovalTTL),
+		Memo:        req.memo,
+		Reference:   req.reference,
+		Category:    req.category,
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to request approval: %v", err)
+	}
+
+	return convertPendingOperation(operation), nil
+}
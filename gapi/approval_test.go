@@ -0,0 +1,34 @@
+package gapi
+
+import (
+	"testing"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiresApproval(t *testing.T) {
+	server := newTestServer(t, nil, nil)
+
+	testCases := []struct {
+		name     string
+		amount   int64
+		currency string
+		expected bool
+	}{
+		{name: "BelowThreshold", amount: 1000000, currency: util.USD, expected: false},
+		{name: "AboveThreshold", amount: 1000001, currency: util.USD, expected: true},
+		// JPY は補助単位がないので USD とは別の閾値になる
+		{name: "PerCurrencyThreshold", amount: 1000001, currency: util.JPY, expected: false},
+		{name: "AboveCurrencyThreshold", amount: 1500001, currency: util.JPY, expected: true},
+		{name: "UnknownCurrency", amount: 1000001, currency: "XXX", expected: false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, server.requiresApproval(tc.amount, tc.currency))
+		})
+	}
+}
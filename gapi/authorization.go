@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	db "github.com/shouta0715/simple-bank/db/sqlc"
 	"github.com/shouta0715/simple-bank/token"
 	"google.golang.org/grpc/metadata"
 )
@@ -53,6 +54,17 @@ func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []strin
 		return nil, fmt.Errorf("access denied")
 	}
 
+	// 削除したユーザーのトークンは、期限が切れる前でも使えないようにする
+	deleted, err := server.store.IsUserDeleted(ctx, payload.Username)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot check user: %w", err)
+	}
+
+	if deleted {
+		return nil, fmt.Errorf("invalid access token: %w", db.ErrUserDeleted)
+	}
+
 	return payload, nil
 }
 
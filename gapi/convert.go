@@ -1,6 +1,8 @@
 package gapi
 
 import (
+	"strings"
+
 	db "github.com/shouta0715/simple-bank/db/sqlc"
 	"github.com/shouta0715/simple-bank/pb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -13,6 +15,249 @@ func convertUser(user db.User) *pb.User {
 		Email:             user.Email,
 		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
 		CreatedAt:         timestamppb.New(user.CreatedAt),
+		PhoneNumber:       user.PhoneNumber,
+		Locale:            user.Locale,
+		Etag:              formatETag(user.Version),
+	}
+
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:          account.ID,
+		Owner:       account.Owner,
+		Balance:     convertMoney(account.Balance, account.Currency),
+		Currency:    account.Currency,
+		CreatedAt:   timestamppb.New(account.CreatedAt),
+		AccountType: account.AccountType,
+	}
+}
+
+func convertEntry(entry db.Entry, currency string) *pb.Entry {
+	return &pb.Entry{
+		Id:        entry.ID,
+		AccountId: entry.AccountID,
+		Amount:    convertMoney(entry.Amount, currency),
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+}
+
+// convertTransferEntry は送金の entry に送金のメモ、参照番号、分類と相手の口座を付ける
+func convertTransferEntry(entry db.Entry, transfer db.Transfer, currency string) *pb.Entry {
+	rsp := convertEntry(entry, currency)
+	rsp.TransferId = &transfer.ID
+	rsp.Memo = transfer.Memo
+	rsp.Reference = transfer.Reference
+	rsp.Category = transfer.Category
+
+	counterparty := transfer.FromAccountID
+	if entry.AccountID == transfer.FromAccountID {
+		counterparty = transfer.ToAccountID
+	}
+	rsp.CounterpartyAccountId = &counterparty
+
+	return rsp
+}
+
+func convertTransfer(transfer db.Transfer, currency string) *pb.Transfer {
+	return &pb.Transfer{
+		Id:            transfer.ID,
+		FromAccountId: transfer.FromAccountID,
+		ToAccountId:   transfer.ToAccountID,
+		Amount:        convertMoney(transfer.Amount, currency),
+		Fee:           convertMoney(transfer.Fee, currency),
+		CreatedAt:     timestamppb.New(transfer.CreatedAt),
+		Memo:          transfer.Memo,
+		Reference:     transfer.Reference,
+		Category:      transfer.Category,
+	}
+}
+
+func convertReconciliationReport(report db.ReconciliationReport) *pb.ReconciliationReport {
+	rsp := &pb.ReconciliationReport{
+		Id:        report.ID,
+		RunId:     report.RunID,
+		Kind:      report.Kind,
+		Expected:  report.Expected,
+		Actual:    report.Actual,
+		CreatedAt: timestamppb.New(report.CreatedAt),
+	}
+
+	if report.AccountID.Valid {
+		rsp.AccountId = &report.AccountID.Int64
+	}
+
+	if report.TransferID.Valid {
+		rsp.TransferId = &report.TransferID.Int64
+	}
+
+	return rsp
+}
+
+func convertFraudReview(review db.FraudReview) *pb.FraudReview {
+	rsp := &pb.FraudReview{
+		Id:            review.ID,
+		FromAccountId: review.FromAccountID,
+		ToAccountId:   review.ToAccountID,
+		Amount:        convertMoney(review.Amount, review.Currency),
+		RequestedBy:   review.RequestedBy,
+		Rules:         strings.Split(review.Rules, ","),
+		Status:        review.Status,
+		CreatedAt:     timestamppb.New(review.CreatedAt),
+		Memo:          review.Memo,
+		Reference:     review.Reference,
+		Category:      review.Category,
+	}
+
+	if review.ReviewedBy.Valid {
+		rsp.ReviewedBy = &review.ReviewedBy.String
+	}
+
+	if review.ReviewedAt.Valid {
+		rsp.ReviewedAt = timestamppb.New(review.ReviewedAt.Time)
+	}
+
+	if review.TransferID.Valid {
+		rsp.TransferId = &review.TransferID.Int64
+	}
+
+	return rsp
+}
+
+func convertPendingOperation(operation db.PendingOperation) *pb.PendingOperation {
+	rsp := &pb.PendingOperation{
+		Id:          operation.ID,
+		Kind:        operation.Kind,
+		Amount:      convertMoney(operation.Amount, operation.Currency),
+		RequestedBy: operation.RequestedBy,
+		Status:      operation.Status,
+		ExpiresAt:   timestamppb.New(operation.ExpiresAt),
+		CreatedAt:   timestamppb.New(operation.CreatedAt),
+		Memo:        operation.Memo,
+		Reference:   operation.Reference,
+		Category:    operation.Category,
+	}
+
+	if operation.FromAccountID.Valid {
+		rsp.FromAccountId = &operation.FromAccountID.Int64
 	}
 
+	if operation.ToAccountID.Valid {
+		rsp.ToAccountId = &operation.ToAccountID.Int64
+	}
+
+	if operation.ReviewedBy.Valid {
+		rsp.ReviewedBy = &operation.ReviewedBy.String
+	}
+
+	if operation.ReviewedAt.Valid {
+		rsp.ReviewedAt = timestamppb.New(operation.ReviewedAt.Time)
+	}
+
+	if operation.TransferID.Valid {
+		rsp.TransferId = &operation.TransferID.Int64
+	}
+
+	return rsp
+}
+
+func convertBeneficiary(beneficiary db.Beneficiary) *pb.Beneficiary {
+	return &pb.Beneficiary{
+		Id:        beneficiary.ID,
+		Owner:     beneficiary.Owner,
+		Nickname:  beneficiary.Nickname,
+		AccountId: beneficiary.AccountID,
+		Verified:  beneficiary.Verified,
+		CreatedAt: timestamppb.New(beneficiary.CreatedAt),
+	}
+}
+
+func convertPaymentRequest(request db.PaymentRequest) *pb.PaymentRequest {
+	rsp := &pb.PaymentRequest{
+		Id:        request.ID,
+		Requester: request.Requester,
+		Payer:     request.Payer,
+		Amount:    convertMoney(request.Amount, request.Currency),
+		Memo:      request.Memo,
+		Status:    request.Status,
+		ExpiresAt: timestamppb.New(request.ExpiresAt),
+		UpdatedAt: timestamppb.New(request.UpdatedAt),
+		CreatedAt: timestamppb.New(request.CreatedAt),
+	}
+
+	if request.TransferID.Valid {
+		rsp.TransferId = &request.TransferID.Int64
+	}
+
+	return rsp
+}
+
+func convertTransaction(transaction db.SearchTransactionsRow) *pb.Entry {
+	rsp := &pb.Entry{
+		Id:                    transaction.ID,
+		AccountId:             transaction.AccountID,
+		Amount:                convertMoney(transaction.Amount, transaction.Currency),
+		CreatedAt:             timestamppb.New(transaction.CreatedAt),
+		Memo:                  transaction.Memo,
+		Reference:             transaction.Reference,
+		Category:              transaction.Category,
+		CounterpartyAccountId: &transaction.CounterpartyAccountID,
+	}
+
+	if transaction.TransferID.Valid {
+		rsp.TransferId = &transaction.TransferID.Int64
+	}
+
+	return rsp
+}
+
+func convertNotification(notification db.Notification) *pb.Notification {
+	rsp := &pb.Notification{
+		Id:        notification.ID,
+		Event:     notification.Event,
+		Subject:   notification.Subject,
+		Content:   notification.Content,
+		CreatedAt: timestamppb.New(notification.CreatedAt),
+	}
+
+	if notification.ReadAt.Valid {
+		rsp.ReadAt = timestamppb.New(notification.ReadAt.Time)
+	}
+
+	return rsp
+}
+
+func convertNotificationPreference(preference db.NotificationPreference) *pb.NotificationPreference {
+	return &pb.NotificationPreference{
+		Event: preference.Event,
+		Email: preference.Email,
+		Sms:   preference.Sms,
+		InApp: preference.InApp,
+	}
+}
+
+func convertAlertRule(rule db.AlertRule, currency string) *pb.AlertRule {
+	return &pb.AlertRule{
+		Id:        rule.ID,
+		AccountId: rule.AccountID,
+		Kind:      rule.Kind,
+		Threshold: convertMoney(rule.Threshold, currency),
+		Triggered: rule.Triggered,
+		CreatedAt: timestamppb.New(rule.CreatedAt),
+	}
+}
+
+func convertEmailDelivery(delivery db.EmailDelivery) *pb.EmailDelivery {
+	return &pb.EmailDelivery{
+		Id:        delivery.ID,
+		Template:  delivery.Template,
+		Recipient: delivery.Recipient,
+		Cc:        delivery.Cc,
+		Bcc:       delivery.Bcc,
+		Subject:   delivery.Subject,
+		MessageId: delivery.MessageID,
+		Status:    delivery.Status,
+		Error:     delivery.Error,
+		CreatedAt: timestamppb.New(delivery.CreatedAt),
+	}
 }
@@ -0,0 +1,72 @@
+package gapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/link"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+// DownloadDataExport はメールで送った署名付きのリンクから、書き出したデータの ZIP を返す
+// ZIP は protobuf のメッセージにできないので、ゲートウェイとは別の HTTP のハンドラーにする
+func (server *Server) DownloadDataExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+
+	if err := server.links.Verify(link.DataExportPath, params); err != nil {
+		if errors.Is(err, link.ErrExpiredLink) {
+			http.Error(w, "download link has expired", http.StatusGone)
+			return
+		}
+
+		http.Error(w, "invalid download link", http.StatusForbidden)
+		return
+	}
+
+	exportID, err := strconv.ParseInt(params.Get("export_id"), 10, 64)
+
+	if err != nil {
+		http.Error(w, "invalid download link", http.StatusForbidden)
+		return
+	}
+
+	dataExport, err := server.store.GetDataExport(r.Context(), exportID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			http.Error(w, "data export not found", http.StatusNotFound)
+			return
+		}
+
+		http.Error(w, "cannot get data export", http.StatusInternalServerError)
+		return
+	}
+
+	if dataExport.Status != util.DataExportReady {
+		http.Error(w, "data export is not ready", http.StatusNotFound)
+		return
+	}
+
+	if time.Now().After(dataExport.ExpiresAt.Time) {
+		http.Error(w, "data export has expired", http.StatusGone)
+		return
+	}
+
+	filename := fmt.Sprintf("simple-bank-%s-%d.zip", dataExport.Username, dataExport.ID)
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(dataExport.Archive)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(dataExport.Archive)
+}
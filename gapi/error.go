@@ -1,9 +1,14 @@
 package gapi
 
 import (
+	"fmt"
+	"time"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func filedViolation(filed string, err error) *errdetails.BadRequest_FieldViolation {
@@ -33,3 +38,67 @@ func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) er
 func unauthenticatedError(err error) error {
 	return status.Errorf(codes.Unauthenticated, "authentication failed: %v", err)
 }
+
+// transferLimitError は上限までの残りを QuotaFailure の詳細に含めて返す
+func transferLimitError(err *db.TransferLimitError) error {
+	quotaFailure := &errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{
+				Subject:     err.Limit,
+				Description: fmt.Sprintf("limit %d, remaining %d", err.Max, err.Remaining),
+			},
+		},
+	}
+
+	statusExhausted := status.New(codes.ResourceExhausted, err.Error())
+
+	statusDetails, detailErr := statusExhausted.WithDetails(quotaFailure)
+
+	if detailErr != nil {
+		return statusExhausted.Err()
+	}
+
+	return statusDetails.Err()
+}
+
+// rateLimitError は次に呼べるまでの時間を RetryInfo の詳細に含めて返す
+func rateLimitError(message string, retryDelay time.Duration) error {
+	retryInfo := &errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryDelay),
+	}
+
+	statusExhausted := status.New(codes.ResourceExhausted, message)
+
+	statusDetails, err := statusExhausted.WithDetails(retryInfo)
+
+	if err != nil {
+		return statusExhausted.Err()
+	}
+
+	return statusDetails.Err()
+}
+
+func stepUpViolation(factor string, description string) *errdetails.PreconditionFailure_Violation {
+	return &errdetails.PreconditionFailure_Violation{
+		Type:        "STEP_UP",
+		Subject:     factor,
+		Description: description,
+	}
+}
+
+// stepUpRequiredError は足りない認証の要素を PreconditionFailure の詳細に含めて返す
+func stepUpRequiredError(message string, violations ...*errdetails.PreconditionFailure_Violation) error {
+	preconditionFailure := &errdetails.PreconditionFailure{
+		Violations: violations,
+	}
+
+	statusUnauthenticated := status.New(codes.Unauthenticated, message)
+
+	statusDetails, err := statusUnauthenticated.WithDetails(preconditionFailure)
+
+	if err != nil {
+		return statusUnauthenticated.Err()
+	}
+
+	return statusDetails.Err()
+}
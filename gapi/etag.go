@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// anyETag は If-Match で今の版に関係なく更新するときに使う
+const anyETag = "*"
+
+// formatETag はレコードの version を ETag にする
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseETag は ETag からレコードの version を取り出す。空か * の場合は版を確かめないので 0 を返す
+// 弱い ETag は更新の条件に使えないので受け付けない
+func parseETag(etag string) (int64, error) {
+	etag = strings.TrimSpace(etag)
+
+	if etag == "" || etag == anyETag {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(etag)
+
+	if err != nil {
+		// 引用符を付けずに送るクライアントもある
+		unquoted = etag
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid etag: %s", etag)
+	}
+
+	return version, nil
+}
+
+// requestETag はリクエストの etag を返す。空の場合は gateway から渡された If-Match ヘッダーを使う
+func requestETag(ctx context.Context, etag string) string {
+	if etag != "" {
+		return etag
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(grpcGatewayIfMatchHeader); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// validateUpdateMask は update_mask に更新できないフィールドが含まれていないかを確認する
+func validateUpdateMask(mask *fieldmaskpb.FieldMask, updatable []string) error {
+	for _, path := range mask.GetPaths() {
+		if !slices.Contains(updatable, path) {
+			return fmt.Errorf("cannot update %s, must be one of %v", path, updatable)
+		}
+	}
+
+	return nil
+}
+
+// updateFields は更新するフィールドを返す
+// update_mask がある場合はマスクのフィールドを、ない場合は値を設定したフィールドを更新する
+func updateFields(mask *fieldmaskpb.FieldMask, present map[string]bool) map[string]bool {
+	if len(mask.GetPaths()) == 0 {
+		return present
+	}
+
+	fields := make(map[string]bool, len(mask.GetPaths()))
+
+	for _, path := range mask.GetPaths() {
+		fields[path] = true
+	}
+
+	return fields
+}
+
+// updateText は更新するフィールドの値を返す。マスクにないフィールドの値は渡さない
+func updateText(value string, update bool) pgtype.Text {
+	if !update {
+		return pgtype.Text{}
+	}
+
+	return pgtype.Text{
+		String: value,
+		Valid:  true,
+	}
+}
@@ -6,18 +6,26 @@ import (
 	"testing"
 	"time"
 
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
 	"github.com/shouta0715/simple-bank/token"
 	"github.com/shouta0715/simple-bank/util"
 	"github.com/shouta0715/simple-bank/worker"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/metadata"
 )
 
 func newTestServer(t *testing.T, store db.Store, taskDistributor worker.TaskDistributor) *Server {
 	config := util.Config{
-		TokenSymmetricKey:   util.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmetricKey:         util.RandomString(32),
+		AccessTokenDuration:       time.Minute,
+		PageTokenKey:              util.RandomString(32),
+		PublicBaseURL:             "http://localhost:8080",
+		LinkSigningKey:            util.RandomString(32),
+		SignedLinkDuration:        time.Minute,
+		VerifyEmailResendInterval: time.Minute,
+		StepUpTokenDuration:       5 * time.Minute,
 	}
 
 	server, err := NewServer(config, store, taskDistributor)
@@ -42,3 +50,9 @@ func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username st
 	}
 	return metadata.NewIncomingContext(context.Background(), md)
 }
+
+// expectActiveUser は authorizeUser がトークンのユーザーを確かめたときに、削除されていないと返す
+// テストケースで先に IsUserDeleted を設定した場合は、そちらを使う
+func expectActiveUser(store *mockdb.MockStore) {
+	store.EXPECT().IsUserDeleted(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+}
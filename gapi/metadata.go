@@ -11,6 +11,8 @@ const (
 	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
 	userAgentHeader            = "user-agent"
 	xForwardedForHeader        = "x-forwarded-for"
+	// gateway は If-Match ヘッダーをこの名前の metadata にして渡す
+	grpcGatewayIfMatchHeader = "grpcgateway-if-match"
 )
 
 type Metadata struct {
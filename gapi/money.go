@@ -0,0 +1,32 @@
+package gapi
+
+import (
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func convertMoney(units int64, currency string) *pb.Money {
+	c, _ := util.GetCurrency(currency)
+
+	return &pb.Money{
+		Currency:  currency,
+		Units:     units,
+		MinorUnit: c.MinorUnit,
+		Formatted: util.FormatMoney(units, currency),
+	}
+}
+
+// validateMoney はリクエストの金額が正の数で、対応している通貨かどうかを検証する
+func validateMoney(field string, money *pb.Money) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAmount(money.GetUnits()); err != nil {
+		violations = append(violations, filedViolation(field+".units", err))
+	}
+
+	if err := validator.ValidateCurrency(money.GetCurrency()); err != nil {
+		violations = append(violations, filedViolation(field+".currency", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,115 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/worker"
+)
+
+// notifyTransfer は送金を受け取った人に知らせるタスクと、口座の通知のルールを評価するタスクを登録する関数を返す
+func (server *Server) notifyTransfer(ctx context.Context) func(result db.TransferTxResult) error {
+	return func(result db.TransferTxResult) error {
+		// トランザクションがコミットされてから処理されるように遅らせる
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueDefault),
+		}
+
+		err := server.taskDistributor.DistributeTaskNotifyTransfer(ctx, &worker.PayloadNotifyTransfer{
+			TransferID: result.Transfer.ID,
+		}, opts...)
+
+		if err != nil {
+			return err
+		}
+
+		return server.taskDistributor.DistributeTaskEvaluateAlerts(ctx, &worker.PayloadEvaluateAlerts{
+			TransferID: result.Transfer.ID,
+			Balances:   result.Balances(),
+		}, opts...)
+	}
+}
+
+// notifyLogin は新しいログインがあったことを知らせるタスクを登録する
+func (server *Server) notifyLogin(ctx context.Context, session db.Session) error {
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	return server.taskDistributor.DistributeTaskNotifyLogin(ctx, &worker.PayloadNotifyLogin{
+		SessionID: session.ID,
+	}, opts...)
+}
+
+// sendVerifyEmail はメールアドレスを確認するメールを送るタスクを登録する
+// トランザクションの中で呼ぶので、コミットされてから処理されるように遅らせる
+func (server *Server) sendVerifyEmail(ctx context.Context, username string) error {
+	opts := []asynq.Option{
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(10 * time.Second),
+		asynq.Queue(worker.QueueCritical),
+	}
+
+	return server.taskDistributor.DistributeTaskSendVerifyEmail(ctx, &worker.PayloadSendVerifyEmail{
+		Username: username,
+	}, opts...)
+}
+
+// notifyEmailChanged はメールアドレスが変わったときに、新しいアドレスに確認のメールを送り、
+// 古いアドレスに変わったことを知らせるタスクを登録する関数を返す
+func (server *Server) notifyEmailChanged(ctx context.Context) func(user db.User, oldEmail string) error {
+	return func(user db.User, oldEmail string) error {
+		err := server.sendVerifyEmail(ctx, user.Username)
+
+		if err != nil {
+			return err
+		}
+
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueCritical),
+		}
+
+		return server.taskDistributor.DistributeTaskSendEmailChangedEmail(ctx, &worker.PayloadSendEmailChangedEmail{
+			Username:  user.Username,
+			OldEmail:  oldEmail,
+			ChangedAt: time.Now(),
+		}, opts...)
+	}
+}
+
+// notifyPasswordChanged はパスワードが変わったことをユーザーに知らせるタスクを登録する関数を返す
+func (server *Server) notifyPasswordChanged(ctx context.Context) func(user db.User) error {
+	return func(user db.User) error {
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueCritical),
+		}
+
+		return server.taskDistributor.DistributeTaskSendPasswordChangedEmail(ctx, &worker.PayloadSendPasswordChangedEmail{
+			Username:  user.Username,
+			ChangedAt: user.PasswordChangedAt,
+		}, opts...)
+	}
+}
+
+// exportUserData はユーザーのデータを書き出すタスクを登録する
+// トランザクションの中で呼ぶので、コミットされてから処理されるように遅らせる
+func (server *Server) exportUserData(ctx context.Context, dataExport db.DataExport) error {
+	opts := []asynq.Option{
+		asynq.MaxRetry(5),
+		asynq.ProcessIn(10 * time.Second),
+		asynq.Queue(worker.QueueDefault),
+	}
+
+	return server.taskDistributor.DistributeTaskExportUserData(ctx, &worker.PayloadExportUserData{
+		ExportID: dataExport.ID,
+	}, opts...)
+}
@@ -0,0 +1,80 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/worker"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotifyTransfer(t *testing.T) {
+	result := db.TransferTxResult{
+		Transfer:    db.Transfer{ID: 1, FromAccountID: 1, ToAccountID: 2, Amount: 100},
+		FromAccount: db.Account{ID: 1, Balance: 50},
+		ToAccount:   db.Account{ID: 2, Balance: 300},
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(taskDistributor *mockwk.MockTaskDistributor)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(taskDistributor *mockwk.MockTaskDistributor) {
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyTransfer(gomock.Any(), gomock.Eq(&worker.PayloadNotifyTransfer{TransferID: result.Transfer.ID}), gomock.Any()).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskEvaluateAlerts(gomock.Any(), gomock.Eq(&worker.PayloadEvaluateAlerts{
+						TransferID: result.Transfer.ID,
+						// 評価するときではなく、送金した直後の残高で評価する
+						Balances: map[int64]int64{1: 50, 2: 300},
+					}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			// 送金のトランザクションをロールバックさせる
+			name: "DistributeError",
+			buildStubs: func(taskDistributor *mockwk.MockTaskDistributor) {
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyTransfer(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("redis is down"))
+				taskDistributor.EXPECT().
+					DistributeTaskEvaluateAlerts(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(taskDistributor)
+
+			server := newTestServer(t, nil, taskDistributor)
+
+			err := server.notifyTransfer(context.Background())(result)
+			tc.checkError(t, err)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package gapi
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shouta0715/simple-bank/pagination"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// pageScope はページトークンをユーザーと検索条件に紐づけるための文字列を返す
+// AIP-158 に合わせて page_size を変えても同じトークンを使えるように、page_size と page_token は含めない
+func pageScope(username string, req proto.Message) (string, error) {
+	req = proto.Clone(req)
+	message := req.ProtoReflect()
+
+	for _, name := range []protoreflect.Name{"page_size", "page_token"} {
+		if field := message.Descriptor().Fields().ByName(name); field != nil {
+			message.Clear(field)
+		}
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to create page scope: %v", err)
+	}
+
+	return username + ":" + string(message.Descriptor().FullName()) + ":" +
+		base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// pageCursor はページトークンから次のページの位置を取り出す
+// トークンが空の場合は最初のページなので、どちらも NULL になる
+func (server *Server) pageCursor(scope string, pageToken string) (pgtype.Timestamptz, pgtype.Int8, error) {
+	if pageToken == "" {
+		return pgtype.Timestamptz{}, pgtype.Int8{}, nil
+	}
+
+	cursor, err := server.pageTokens.Decode(scope, pageToken)
+
+	if err != nil {
+		return pgtype.Timestamptz{}, pgtype.Int8{}, invalidArgumentError(
+			[]*errdetails.BadRequest_FieldViolation{filedViolation("page_token", err)},
+		)
+	}
+
+	return pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}, pgtype.Int8{Int64: cursor.ID, Valid: true}, nil
+}
+
+// nextPageToken は最後に返した行の次から読むためのトークンを返す
+func (server *Server) nextPageToken(scope string, createdAt time.Time, id int64) (string, error) {
+	token, err := server.pageTokens.Encode(scope, pagination.Cursor{
+		CreatedAt: createdAt,
+		ID:        id,
+	})
+
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to create page token: %v", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/fraud"
+	"github.com/shouta0715/simple-bank/worker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PAYMENT_REQUEST_TTL が設定されていない場合の請求の期限
+const defaultPaymentRequestTTL = 7 * 24 * time.Hour
+
+func (server *Server) paymentRequestTTL() time.Duration {
+	if server.config.PaymentRequestTTL > 0 {
+		return server.config.PaymentRequestTTL
+	}
+
+	return defaultPaymentRequestTTL
+}
+
+// notifyPaymentRequest は請求の状態が変わったことを知らせるタスクを登録する関数を返す
+func (server *Server) notifyPaymentRequest(ctx context.Context) func(request db.PaymentRequest) error {
+	return func(request db.PaymentRequest) error {
+		taskPayload := &worker.PayloadSendPaymentRequestEmail{
+			PaymentRequestID: request.ID,
+		}
+
+		opts := []asynq.Option{
+			asynq.MaxRetry(10),
+			asynq.ProcessIn(10 * time.Second),
+			asynq.Queue(worker.QueueDefault),
+		}
+
+		return server.taskDistributor.DistributeTaskSendPaymentRequestEmail(ctx, taskPayload, opts...)
+	}
+}
+
+func (server *Server) getPaymentRequest(ctx context.Context, requestID int64) (db.PaymentRequest, error) {
+	request, err := server.store.GetPaymentRequest(ctx, requestID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return request, status.Errorf(codes.NotFound, "payment request [%d] not found", requestID)
+		}
+
+		return request, status.Errorf(codes.Internal, "cannot get payment request: %v", err)
+	}
+
+	return request, nil
+}
+
+// paymentRequestError は請求の状態を変えられなかったときのエラーを返す
+func paymentRequestError(requestID int64, err error) error {
+	if errors.Is(err, db.ErrorRecordNotFound) {
+		return status.Errorf(codes.NotFound, "payment request [%d] not found", requestID)
+	}
+
+	if errors.Is(err, db.ErrPaymentRequestNotPending) {
+		return status.Errorf(codes.FailedPrecondition, "payment request [%d] is no longer pending", requestID)
+	}
+
+	if errors.Is(err, db.ErrOperationExpired) {
+		return status.Errorf(codes.FailedPrecondition, "payment request [%d] has expired", requestID)
+	}
+
+	var limitErr *db.TransferLimitError
+	if errors.As(err, &limitErr) {
+		return transferLimitError(limitErr)
+	}
+
+	if errors.Is(err, db.ErrInsufficientBalance) {
+		return status.Errorf(codes.FailedPrecondition, "account doesn't have enough balance")
+	}
+
+	if errors.Is(err, db.ErrAccountClosed) {
+		return status.Errorf(codes.FailedPrecondition, "account is closed")
+	}
+
+	if errors.Is(err, fraud.ErrBlocked) {
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	}
+
+	return status.Errorf(codes.Internal, "failed to update payment request: %v", err)
+}
@@ -0,0 +1,75 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const recipientAccountsPageSize = 10
+
+// resolveRecipient はユーザー名またはメールアドレスから、受取人とその通貨の普通口座を探す
+// メールアドレスを確認していない受取人には送金できない
+func (server *Server) resolveRecipient(ctx context.Context, recipient string, currency string) (db.User, db.Account, error) {
+	var user db.User
+	var err error
+
+	if strings.Contains(recipient, "@") {
+		user, err = server.store.GetUserByEmail(ctx, recipient)
+	} else {
+		user, err = server.store.GetUser(ctx, recipient)
+	}
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return user, db.Account{}, status.Errorf(codes.NotFound, "recipient not found")
+		}
+
+		return user, db.Account{}, status.Errorf(codes.Internal, "cannot get recipient: %v", err)
+	}
+
+	// 削除したユーザーの口座は台帳を残すためだけに残している
+	// システムの口座には手数料や現金の入出金の記帳以外でお金を動かさない
+	if user.DeletedAt.Valid || user.Role == util.SystemRole || util.IsSystemAccountOwner(user.Username) {
+		return user, db.Account{}, status.Errorf(codes.NotFound, "recipient not found")
+	}
+
+	if !user.IsEmailVerified {
+		return user, db.Account{}, status.Errorf(codes.FailedPrecondition, "recipient has not verified their email")
+	}
+
+	arg := db.ListAccountsParams{
+		Owner: user.Username,
+		Limit: recipientAccountsPageSize,
+	}
+
+	for {
+		accounts, err := server.store.ListAccounts(ctx, arg)
+
+		if err != nil {
+			return user, db.Account{}, status.Errorf(codes.Internal, "cannot list recipient accounts: %v", err)
+		}
+
+		for _, account := range accounts {
+			if account.Currency == currency && account.AccountType == util.CheckingAccount {
+				return user, account, nil
+			}
+		}
+
+		if len(accounts) < recipientAccountsPageSize {
+			break
+		}
+
+		last := accounts[len(accounts)-1]
+		arg.CursorCreatedAt = pgtype.Timestamptz{Time: last.CreatedAt, Valid: true}
+		arg.CursorID = pgtype.Int8{Int64: last.ID, Valid: true}
+	}
+
+	return user, db.Account{}, status.Errorf(codes.NotFound, "recipient has no %s account", currency)
+}
@@ -0,0 +1,41 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/shouta0715/simple-bank/link"
+	"github.com/shouta0715/simple-bank/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// VerifyEmailRedirect はメールのリンクから VerifyEmail を呼んだときに、結果に応じてフロントエンドのページにリダイレクトするゲートウェイの設定を返す
+// URL が空の場合はリダイレクトせずに JSON を返す
+func VerifyEmailRedirect(successURL string, failureURL string) []runtime.ServeMuxOption {
+	var opts []runtime.ServeMuxOption
+
+	if successURL != "" {
+		opts = append(opts, runtime.WithForwardResponseOption(func(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+			if rsp, ok := resp.(*pb.VerifyEmailResponse); ok && rsp.GetIsVerified() {
+				w.Header().Set("Location", successURL)
+				w.WriteHeader(http.StatusFound)
+			}
+
+			return nil
+		}))
+	}
+
+	if failureURL != "" {
+		opts = append(opts, runtime.WithErrorHandler(func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+			if r.Method == http.MethodGet && r.URL.Path == link.VerifyEmailPath {
+				http.Redirect(w, r, failureURL, http.StatusFound)
+				return
+			}
+
+			runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+		}))
+	}
+
+	return opts
+}
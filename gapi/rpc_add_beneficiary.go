@@ -0,0 +1,96 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"github.com/shouta0715/simple-bank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) AddBeneficiary(ctx context.Context, req *pb.AddBeneficiaryRequest) (*pb.AddBeneficiaryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAddBeneficiaryRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", req.GetAccountId())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get account: %v", err)
+	}
+
+	if util.IsSystemAccountOwner(account.Owner) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot add system account [%d] as a beneficiary", account.ID)
+	}
+
+	arg := db.CreateBeneficiaryTxParams{
+		CreateBeneficiaryParams: db.CreateBeneficiaryParams{
+			Owner:     authPayload.Username,
+			Nickname:  req.GetNickname(),
+			AccountID: account.ID,
+		},
+		AfterCreate: func(beneficiary db.Beneficiary) error {
+			taskPayload := &worker.PayloadSendBeneficiaryEmail{
+				BeneficiaryID: beneficiary.ID,
+			}
+
+			opts := []asynq.Option{
+				asynq.MaxRetry(10),
+				asynq.ProcessIn(10 * time.Second),
+				asynq.Queue(worker.QueueCritical),
+			}
+
+			return server.taskDistributor.DistributeTaskSendBeneficiaryEmail(ctx, taskPayload, opts...)
+		},
+	}
+
+	result, err := server.store.CreateBeneficiaryTx(ctx, arg)
+
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "account [%d] is already a beneficiary", account.ID)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to add beneficiary: %v", err)
+	}
+
+	rsp := &pb.AddBeneficiaryResponse{
+		Beneficiary: convertBeneficiary(result.Beneficiary),
+	}
+
+	return rsp, nil
+}
+
+func validateAddBeneficiaryRequest(req *pb.AddBeneficiaryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateNickname(req.GetNickname()); err != nil {
+		violations = append(violations, filedViolation("nickname", err))
+	}
+
+	if err := validator.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, filedViolation("account_id", err))
+	}
+
+	return violations
+}
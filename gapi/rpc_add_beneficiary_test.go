@@ -0,0 +1,159 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/worker"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAddBeneficiaryAPI(t *testing.T) {
+	user, _ := randomUser()
+	payee, _ := randomUser()
+
+	account := randomAccount(payee.Username, util.USD)
+	nickname := "landlord"
+
+	beneficiary := db.Beneficiary{
+		ID:        int64(util.RandomInt(1, 1000)),
+		Owner:     user.Username,
+		Nickname:  nickname,
+		AccountID: account.ID,
+		Verified:  true,
+		CreatedAt: time.Now(),
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.AddBeneficiaryRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.AddBeneficiaryResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.AddBeneficiaryRequest{
+				Nickname:  nickname,
+				AccountId: account.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				store.EXPECT().
+					CreateBeneficiaryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateBeneficiaryTxParams) (db.CreateBeneficiaryTxResult, error) {
+						require.Equal(t, db.CreateBeneficiaryParams{
+							Owner:     user.Username,
+							Nickname:  nickname,
+							AccountID: account.ID,
+						}, arg.CreateBeneficiaryParams)
+
+						// CreateBeneficiaryTx は mock なので、ここで通知のタスクを登録する
+						return db.CreateBeneficiaryTxResult{Beneficiary: beneficiary}, arg.AfterCreate(beneficiary)
+					})
+
+				taskPayload := &worker.PayloadSendBeneficiaryEmail{
+					BeneficiaryID: beneficiary.ID,
+				}
+				taskDistributor.EXPECT().
+					DistributeTaskSendBeneficiaryEmail(gomock.Any(), taskPayload, gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.AddBeneficiaryResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, beneficiary.ID, res.GetBeneficiary().GetId())
+				require.Equal(t, nickname, res.GetBeneficiary().GetNickname())
+				require.True(t, res.GetBeneficiary().GetVerified())
+			},
+		},
+		{
+			name: "AlreadyExists",
+			req: &pb.AddBeneficiaryRequest{
+				Nickname:  nickname,
+				AccountId: account.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					CreateBeneficiaryTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CreateBeneficiaryTxResult{}, db.ErrorUniqueViolation)
+				taskDistributor.EXPECT().DistributeTaskSendBeneficiaryEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.AddBeneficiaryResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+		{
+			name: "AccountNotFound",
+			req: &pb.AddBeneficiaryRequest{
+				Nickname:  nickname,
+				AccountId: account.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, db.ErrorRecordNotFound)
+				store.EXPECT().CreateBeneficiaryTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.AddBeneficiaryResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidNickname",
+			req: &pb.AddBeneficiaryRequest{
+				Nickname:  "",
+				AccountId: account.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateBeneficiaryTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.AddBeneficiaryResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, taskDistributor)
+
+			ctx := newContextWithBearerToken(t, server.maker, user.Username, user.Role, time.Minute)
+			res, err := server.AddBeneficiary(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
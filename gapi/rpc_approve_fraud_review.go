@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ApproveFraudReview(ctx context.Context, req *pb.ApproveFraudReviewRequest) (*pb.ApproveFraudReviewResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateApproveFraudReviewRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ReviewFraudTx(ctx, db.ReviewFraudTxParams{
+		ReviewID:    req.GetReviewId(),
+		ReviewedBy:  authPayload.Username,
+		Approve:     true,
+		AfterCreate: server.notifyTransfer(ctx),
+		AfterPay:    server.notifyPaymentRequest(ctx),
+	})
+
+	if err != nil {
+		var limitErr *db.TransferLimitError
+		if errors.As(err, &limitErr) {
+			return nil, transferLimitError(limitErr)
+		}
+
+		if errors.Is(err, db.ErrInsufficientBalance) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account doesn't have enough balance")
+		}
+
+		if errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account is closed")
+		}
+
+		return nil, fraudReviewError(req.GetReviewId(), err)
+	}
+
+	currency := result.Review.Currency
+
+	rsp := &pb.ApproveFraudReviewResponse{
+		Review:      convertFraudReview(result.Review),
+		Transfer:    convertTransfer(result.Transfer.Transfer, currency),
+		FromAccount: convertAccount(result.Transfer.FromAccount),
+		ToAccount:   convertAccount(result.Transfer.ToAccount),
+	}
+
+	return rsp, nil
+}
+
+// fraudReviewError は審査を承認または却下できなかったときのエラーを返す
+func fraudReviewError(reviewID int64, err error) error {
+	if errors.Is(err, db.ErrorRecordNotFound) {
+		return status.Errorf(codes.NotFound, "fraud review [%d] not found", reviewID)
+	}
+
+	if errors.Is(err, db.ErrReviewNotPending) {
+		return status.Errorf(codes.FailedPrecondition, "fraud review [%d] is already reviewed", reviewID)
+	}
+
+	return status.Errorf(codes.Internal, "failed to review fraud review: %v", err)
+}
+
+func validateApproveFraudReviewRequest(req *pb.ApproveFraudReviewRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateReviewID(req.GetReviewId()); err != nil {
+		violations = append(violations, filedViolation("review_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,223 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestApproveFraudReviewAPI(t *testing.T) {
+	user, _ := randomUser()
+	banker, _ := randomUser()
+	banker.Role = util.BankerRole
+
+	account1 := randomAccount(user.Username, util.USD)
+	account2 := randomAccount(banker.Username, util.USD)
+	account2.ID = account1.ID + 1
+
+	amount := int64(10)
+	reviewID := int64(util.RandomInt(1, 1000))
+
+	review := db.FraudReview{
+		ID:            reviewID,
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		Currency:      util.USD,
+		RequestedBy:   user.Username,
+		Rules:         "new_payee_burst,transfer_after_new_ip_login",
+		Status:        util.ReviewApproved,
+		ReviewedBy: pgtype.Text{
+			String: banker.Username,
+			Valid:  true,
+		},
+		TransferID: pgtype.Int8{
+			Int64: 1,
+			Valid: true,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ApproveFraudReviewRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ApproveFraudReviewResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ApproveFraudReviewRequest{
+				ReviewId: reviewID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewFraudTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ReviewFraudTxParams) (db.ReviewFraudTxResult, error) {
+						require.Equal(t, reviewID, arg.ReviewID)
+						require.Equal(t, banker.Username, arg.ReviewedBy)
+						require.True(t, arg.Approve)
+						// 送金の通知とアラートの評価のタスクを登録する
+						require.NotNil(t, arg.AfterCreate)
+						// 保留された請求の支払いを承認した場合は請求した人に知らせる
+						require.NotNil(t, arg.AfterPay)
+
+						return db.ReviewFraudTxResult{
+							Review: review,
+							Transfer: db.TransferTxResult{
+								Transfer: db.Transfer{
+									ID:            1,
+									FromAccountID: account1.ID,
+									ToAccountID:   account2.ID,
+									Amount:        amount,
+								},
+								FromAccount: account1,
+								ToAccount:   account2,
+							},
+						}, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApproveFraudReviewResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.ReviewApproved, res.GetReview().GetStatus())
+				require.Equal(t, banker.Username, res.GetReview().GetReviewedBy())
+				require.Equal(t, []string{"new_payee_burst", "transfer_after_new_ip_login"}, res.GetReview().GetRules())
+				require.Equal(t, res.GetReview().GetTransferId(), res.GetTransfer().GetId())
+				require.Equal(t, amount, res.GetTransfer().GetAmount().GetUnits())
+			},
+		},
+		{
+			name: "NotBanker",
+			req: &pb.ApproveFraudReviewRequest{
+				ReviewId: reviewID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewFraudTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApproveFraudReviewResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.ApproveFraudReviewRequest{
+				ReviewId: reviewID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewFraudTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewFraudTxResult{}, db.ErrorRecordNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApproveFraudReviewResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "AlreadyReviewed",
+			req: &pb.ApproveFraudReviewRequest{
+				ReviewId: reviewID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewFraudTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewFraudTxResult{}, db.ErrReviewNotPending)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApproveFraudReviewResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "InsufficientBalance",
+			req: &pb.ApproveFraudReviewRequest{
+				ReviewId: reviewID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewFraudTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewFraudTxResult{}, db.ErrInsufficientBalance)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApproveFraudReviewResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "InvalidReviewID",
+			req: &pb.ApproveFraudReviewRequest{
+				ReviewId: 0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewFraudTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApproveFraudReviewResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.maker)
+			res, err := server.ApproveFraudReview(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
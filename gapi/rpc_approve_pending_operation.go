@@ -0,0 +1,99 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ApprovePendingOperation(ctx context.Context, req *pb.ApprovePendingOperationRequest) (*pb.ApprovePendingOperationResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateApprovePendingOperationRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ReviewPendingOperationTx(ctx, db.ReviewPendingOperationTxParams{
+		OperationID: req.GetOperationId(),
+		ReviewedBy:  authPayload.Username,
+		Approve:     true,
+		AfterCreate: server.notifyTransfer(ctx),
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrSameApprover) {
+			return nil, status.Errorf(codes.PermissionDenied, "operation [%d] must be approved by another banker", req.GetOperationId())
+		}
+
+		if errors.Is(err, db.ErrOperationExpired) {
+			return nil, status.Errorf(codes.FailedPrecondition, "operation [%d] has expired", req.GetOperationId())
+		}
+
+		var limitErr *db.TransferLimitError
+		if errors.As(err, &limitErr) {
+			return nil, transferLimitError(limitErr)
+		}
+
+		if errors.Is(err, db.ErrInsufficientBalance) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account doesn't have enough balance")
+		}
+
+		if errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account is closed")
+		}
+
+		return nil, pendingOperationError(req.GetOperationId(), err)
+	}
+
+	currency := result.Operation.Currency
+
+	rsp := &pb.ApprovePendingOperationResponse{
+		Operation: convertPendingOperation(result.Operation),
+		Transfer:  convertTransfer(result.Transfer.Transfer, currency),
+	}
+
+	// 入金と出金の場合は片方の口座だけを返す
+	if result.Operation.FromAccountID.Valid {
+		rsp.FromAccount = convertAccount(result.Transfer.FromAccount)
+	}
+
+	if result.Operation.ToAccountID.Valid {
+		rsp.ToAccount = convertAccount(result.Transfer.ToAccount)
+	}
+
+	return rsp, nil
+}
+
+// pendingOperationError は承認待ちの操作を承認または却下できなかったときのエラーを返す
+func pendingOperationError(operationID int64, err error) error {
+	if errors.Is(err, db.ErrorRecordNotFound) {
+		return status.Errorf(codes.NotFound, "operation [%d] not found", operationID)
+	}
+
+	if errors.Is(err, db.ErrReviewNotPending) {
+		return status.Errorf(codes.FailedPrecondition, "operation [%d] is already reviewed", operationID)
+	}
+
+	return status.Errorf(codes.Internal, "failed to review operation: %v", err)
+}
+
+func validateApprovePendingOperationRequest(req *pb.ApprovePendingOperationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateOperationID(req.GetOperationId()); err != nil {
+		violations = append(violations, filedViolation("operation_id", err))
+	}
+
+	return violations
+}
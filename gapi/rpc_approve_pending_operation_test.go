@@ -0,0 +1,203 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestApprovePendingOperationAPI(t *testing.T) {
+	user, _ := randomUser()
+	banker, _ := randomUser()
+	banker.Role = util.BankerRole
+
+	account := randomAccount(user.Username, util.USD)
+	amount := int64(2000)
+	operationID := int64(util.RandomInt(1, 1000))
+
+	operation := db.PendingOperation{
+		ID:   operationID,
+		Kind: util.OperationDeposit,
+		ToAccountID: pgtype.Int8{
+			Int64: account.ID,
+			Valid: true,
+		},
+		Amount:      amount,
+		Currency:    util.USD,
+		RequestedBy: util.RandomOwner(),
+		Status:      util.ReviewApproved,
+		ReviewedBy: pgtype.Text{
+			String: banker.Username,
+			Valid:  true,
+		},
+		TransferID: pgtype.Int8{
+			Int64: 1,
+			Valid: true,
+		},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ApprovePendingOperationRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ApprovePendingOperationResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ApprovePendingOperationRequest{
+				OperationId: operationID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				updatedAccount := account
+				updatedAccount.Balance += amount
+
+				store.EXPECT().
+					ReviewPendingOperationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ReviewPendingOperationTxParams) (db.ReviewPendingOperationTxResult, error) {
+						require.Equal(t, operationID, arg.OperationID)
+						require.Equal(t, banker.Username, arg.ReviewedBy)
+						require.True(t, arg.Approve)
+						// 送金の通知とアラートの評価のタスクを登録する
+						require.NotNil(t, arg.AfterCreate)
+
+						return db.ReviewPendingOperationTxResult{
+							Operation: operation,
+							Transfer: db.TransferTxResult{
+								Transfer: db.Transfer{
+									ID:          1,
+									ToAccountID: account.ID,
+									Amount:      amount,
+								},
+								ToAccount: updatedAccount,
+							},
+						}, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApprovePendingOperationResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.ReviewApproved, res.GetOperation().GetStatus())
+				require.Equal(t, res.GetOperation().GetTransferId(), res.GetTransfer().GetId())
+				require.Nil(t, res.GetFromAccount())
+				require.Equal(t, account.Balance+amount, res.GetToAccount().GetBalance().GetUnits())
+			},
+		},
+		{
+			name: "SameApprover",
+			req: &pb.ApprovePendingOperationRequest{
+				OperationId: operationID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewPendingOperationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewPendingOperationTxResult{}, db.ErrSameApprover)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApprovePendingOperationResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "Expired",
+			req: &pb.ApprovePendingOperationRequest{
+				OperationId: operationID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewPendingOperationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewPendingOperationTxResult{}, db.ErrOperationExpired)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApprovePendingOperationResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.ApprovePendingOperationRequest{
+				OperationId: operationID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReviewPendingOperationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReviewPendingOperationTxResult{}, db.ErrorRecordNotFound)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApprovePendingOperationResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "NotBanker",
+			req: &pb.ApprovePendingOperationRequest{
+				OperationId: operationID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReviewPendingOperationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ApprovePendingOperationResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.maker)
+			res, err := server.ApprovePendingOperation(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
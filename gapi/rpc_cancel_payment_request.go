@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CancelPaymentRequest(ctx context.Context, req *pb.CancelPaymentRequestRequest) (*pb.CancelPaymentRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCancelPaymentRequestRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	request, err := server.getPaymentRequest(ctx, req.GetPaymentRequestId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Requester != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "payment request [%d] was not sent by the authenticated user", request.ID)
+	}
+
+	result, err := server.store.UpdatePaymentRequestTx(ctx, db.UpdatePaymentRequestTxParams{
+		RequestID:   request.ID,
+		Status:      util.PaymentRequestCancelled,
+		AfterUpdate: server.notifyPaymentRequest(ctx),
+	})
+
+	if err != nil {
+		return nil, paymentRequestError(request.ID, err)
+	}
+
+	rsp := &pb.CancelPaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(result.PaymentRequest),
+	}
+
+	return rsp, nil
+}
+
+func validateCancelPaymentRequestRequest(req *pb.CancelPaymentRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePaymentRequestID(req.GetPaymentRequestId()); err != nil {
+		violations = append(violations, filedViolation("payment_request_id", err))
+	}
+
+	return violations
+}
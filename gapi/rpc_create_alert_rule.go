@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateAlertRule(ctx context.Context, req *pb.CreateAlertRuleRequest) (*pb.CreateAlertRuleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateAlertRuleRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validAccount(ctx, req.GetAccountId(), req.GetThreshold().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", account.ID)
+	}
+
+	rule, err := server.store.CreateAlertRule(ctx, db.CreateAlertRuleParams{
+		Owner:     authPayload.Username,
+		AccountID: account.ID,
+		Kind:      req.GetKind(),
+		Threshold: req.GetThreshold().GetUnits(),
+	})
+
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "account [%d] already has a %s alert", account.ID, req.GetKind())
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to create alert rule: %v", err)
+	}
+
+	rsp := &pb.CreateAlertRuleResponse{
+		AlertRule: convertAlertRule(rule, account.Currency),
+	}
+
+	return rsp, nil
+}
+
+func validateCreateAlertRuleRequest(req *pb.CreateAlertRuleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, filedViolation("account_id", err))
+	}
+
+	if err := validator.ValidateAlertKind(req.GetKind()); err != nil {
+		violations = append(violations, filedViolation("kind", err))
+	}
+
+	violations = append(violations, validateMoney("threshold", req.GetThreshold())...)
+
+	return violations
+}
@@ -0,0 +1,160 @@
+package gapi
+
+import (
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateAlertRuleAPI(t *testing.T) {
+	user, _ := randomUser()
+	otherUser, _ := randomUser()
+	account := randomAccount(user.Username, util.USD)
+
+	threshold := int64(10000)
+
+	testCases := []struct {
+		name          string
+		username      string
+		req           *pb.CreateAlertRuleRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateAlertRuleResponse, err error)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			req: &pb.CreateAlertRuleRequest{
+				AccountId: account.ID,
+				Kind:      util.AlertLowBalance,
+				Threshold: &pb.Money{Currency: util.USD, Units: threshold},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					CreateAlertRule(gomock.Any(), gomock.Eq(db.CreateAlertRuleParams{
+						Owner:     user.Username,
+						AccountID: account.ID,
+						Kind:      util.AlertLowBalance,
+						Threshold: threshold,
+					})).
+					Times(1).
+					Return(db.AlertRule{
+						ID:        1,
+						Owner:     user.Username,
+						AccountID: account.ID,
+						Kind:      util.AlertLowBalance,
+						Threshold: threshold,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAlertRuleResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(1), res.GetAlertRule().GetId())
+				require.Equal(t, threshold, res.GetAlertRule().GetThreshold().GetUnits())
+				require.Equal(t, util.USD, res.GetAlertRule().GetThreshold().GetCurrency())
+			},
+		},
+		{
+			name:     "AlreadyExists",
+			username: user.Username,
+			req: &pb.CreateAlertRuleRequest{
+				AccountId: account.ID,
+				Kind:      util.AlertLowBalance,
+				Threshold: &pb.Money{Currency: util.USD, Units: threshold},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreateAlertRule(gomock.Any(), gomock.Any()).Times(1).Return(db.AlertRule{}, db.ErrorUniqueViolation)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAlertRuleResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.AlreadyExists, st.Code())
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: otherUser.Username,
+			req: &pb.CreateAlertRuleRequest{
+				AccountId: account.ID,
+				Kind:      util.AlertLargeIncomingTransfer,
+				Threshold: &pb.Money{Currency: util.USD, Units: threshold},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreateAlertRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAlertRuleResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name:     "CurrencyMismatch",
+			username: user.Username,
+			req: &pb.CreateAlertRuleRequest{
+				AccountId: account.ID,
+				Kind:      util.AlertLowBalance,
+				Threshold: &pb.Money{Currency: util.EUR, Units: threshold},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreateAlertRule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAlertRuleResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name:     "InvalidKind",
+			username: user.Username,
+			req: &pb.CreateAlertRuleRequest{
+				AccountId: account.ID,
+				Kind:      "high_balance",
+				Threshold: &pb.Money{Currency: util.USD, Units: threshold},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAlertRuleResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := newContextWithBearerToken(t, server.maker, tc.username, util.DepositorRole, time.Minute)
+			res, err := server.CreateAlertRule(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateP2PTransfer(ctx context.Context, req *pb.CreateP2PTransferRequest) (*pb.CreateP2PTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateP2PTransferRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetAmount().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", fromAccount.ID)
+	}
+
+	recipient, toAccount, err := server.resolveRecipient(ctx, req.GetRecipient(), req.GetAmount().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if toAccount.ID == fromAccount.ID {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot send money to the same account")
+	}
+
+	result, err := server.executeTransfer(ctx, authPayload, fromAccount, toAccount, db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        req.GetAmount().GetUnits(),
+		Memo:          req.GetMemo(),
+		Reference:     req.GetReference(),
+		Category:      req.GetCategory(),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.CreateP2PTransferResponse{
+		Transfer:            result.GetTransfer(),
+		FromAccount:         result.GetFromAccount(),
+		FromEntry:           result.GetFromEntry(),
+		Fee:                 result.GetFee(),
+		FeeEntry:            result.GetFeeEntry(),
+		RecipientMaskedName: util.MaskName(recipient.FullName),
+		Review:              result.GetReview(),
+		PendingOperation:    result.GetPendingOperation(),
+	}
+
+	return rsp, nil
+}
+
+func validateCreateP2PTransferRequest(req *pb.CreateP2PTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAccountID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, filedViolation("from_account_id", err))
+	}
+
+	if err := validator.ValidateRecipient(req.GetRecipient()); err != nil {
+		violations = append(violations, filedViolation("recipient", err))
+	}
+
+	violations = append(violations, validateMoney("amount", req.GetAmount())...)
+	violations = append(violations, validateTransferDetails(req.GetMemo(), req.GetReference(), req.GetCategory())...)
+
+	return violations
+}
@@ -0,0 +1,193 @@
+package gapi
+
+import (
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateP2PTransferAPI(t *testing.T) {
+	user, _ := randomUser()
+	recipient, _ := randomUser()
+	recipient.FullName = "Shouta Yamada"
+	recipient.IsEmailVerified = true
+
+	unverified := recipient
+	unverified.IsEmailVerified = false
+
+	systemUser := recipient
+	systemUser.Username = util.FeeRevenueOwner
+	systemUser.Role = util.SystemRole
+
+	fromAccount := randomAccount(user.Username, util.USD)
+	toAccount := randomAccount(recipient.Username, util.USD)
+	toAccount.ID = fromAccount.ID + 1
+
+	// 通貨の違う口座は選ばれない
+	eurAccount := randomAccount(recipient.Username, util.EUR)
+	eurAccount.ID = fromAccount.ID + 2
+
+	amount := int64(10)
+
+	testCases := []struct {
+		name          string
+		recipient     string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateP2PTransferResponse, err error)
+	}{
+		{
+			name:      "OKByEmail",
+			recipient: recipient.Email,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(recipient.Email)).Times(1).Return(recipient, nil)
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Eq(db.ListAccountsParams{
+						Owner: recipient.Username,
+						Limit: recipientAccountsPageSize,
+					})).
+					Times(1).
+					Return([]db.Account{eurAccount, toAccount}, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, db.ErrorRecordNotFound)
+
+				arg := db.TransferTxParams{
+					FromAccountID: fromAccount.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        amount,
+				}
+
+				store.EXPECT().
+					TransferTx(gomock.Any(), EqTransferTxParams(arg)).
+					Times(1).
+					Return(db.TransferTxResult{
+						Transfer: db.Transfer{
+							FromAccountID: fromAccount.ID,
+							ToAccountID:   toAccount.ID,
+							Amount:        amount,
+						},
+						FromAccount: fromAccount,
+						ToAccount:   toAccount,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateP2PTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "S*** Y***", res.GetRecipientMaskedName())
+				require.Equal(t, amount, res.GetTransfer().GetAmount().GetUnits())
+				require.Equal(t, toAccount.ID, res.GetTransfer().GetToAccountId())
+			},
+		},
+		{
+			name:      "OKByUsername",
+			recipient: recipient.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(recipient.Username)).Times(1).Return(recipient, nil)
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{toAccount}, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, db.ErrorRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateP2PTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "S*** Y***", res.GetRecipientMaskedName())
+			},
+		},
+		{
+			name:      "UnverifiedRecipient",
+			recipient: recipient.Email,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(1).Return(unverified, nil)
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateP2PTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name:      "NoAccountInCurrency",
+			recipient: recipient.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(recipient, nil)
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(1).Return([]db.Account{eurAccount}, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateP2PTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name:      "SystemRecipient",
+			recipient: util.FeeRevenueOwner,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(util.FeeRevenueOwner)).Times(1).Return(systemUser, nil)
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateP2PTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name:      "RecipientNotFound",
+			recipient: recipient.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, db.ErrorRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateP2PTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := newContextWithBearerToken(t, server.maker, user.Username, user.Role, time.Minute)
+			res, err := server.CreateP2PTransfer(ctx, &pb.CreateP2PTransferRequest{
+				FromAccountId: fromAccount.ID,
+				Recipient:     tc.recipient,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreatePaymentRequest(ctx context.Context, req *pb.CreatePaymentRequestRequest) (*pb.CreatePaymentRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreatePaymentRequestRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if req.GetPayer() == authPayload.Username {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot request money from yourself")
+	}
+
+	payer, err := server.store.GetUser(ctx, req.GetPayer())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "payer not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get payer: %v", err)
+	}
+
+	// 支払われたお金は請求した人のその通貨の普通口座に入る
+	_, toAccount, err := server.resolveRecipient(ctx, authPayload.Username, req.GetAmount().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.CreatePaymentRequestTxParams{
+		CreatePaymentRequestParams: db.CreatePaymentRequestParams{
+			Requester:   authPayload.Username,
+			Payer:       payer.Username,
+			ToAccountID: toAccount.ID,
+			Amount:      req.GetAmount().GetUnits(),
+			Currency:    toAccount.Currency,
+			Memo:        req.GetMemo(),
+			ExpiresAt:   time.Now().Add(server.paymentRequestTTL()),
+		},
+		AfterCreate: server.notifyPaymentRequest(ctx),
+	}
+
+	result, err := server.store.CreatePaymentRequestTx(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create payment request: %v", err)
+	}
+
+	rsp := &pb.CreatePaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(result.PaymentRequest),
+	}
+
+	return rsp, nil
+}
+
+func validateCreatePaymentRequestRequest(req *pb.CreatePaymentRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUsername(req.GetPayer()); err != nil {
+		violations = append(violations, filedViolation("payer", err))
+	}
+
+	violations = append(violations, validateMoney("amount", req.GetAmount())...)
+
+	if err := validator.ValidateMemo(req.GetMemo()); err != nil {
+		violations = append(violations, filedViolation("memo", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,275 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/fraud"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetAmount().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if authPayload.Role != util.BankerRole && fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", fromAccount.ID)
+	}
+
+	toAccount, err := server.validAccount(ctx, req.GetToAccountId(), req.GetAmount().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return server.executeTransfer(ctx, authPayload, fromAccount, toAccount, db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        req.GetAmount().GetUnits(),
+		Memo:          req.GetMemo(),
+		Reference:     req.GetReference(),
+		Category:      req.GetCategory(),
+	})
+}
+
+// executeTransfer は確認済みの口座の間で送金する
+// 手数料を含めた残高の確認、承認待ち、不正検知のルールによる保留もここで扱う
+func (server *Server) executeTransfer(ctx context.Context, authPayload *token.Payload, fromAccount db.Account, toAccount db.Account, arg db.TransferTxParams) (*pb.CreateTransferResponse, error) {
+	// システム口座のお金は入金、出金、手数料、利息でだけ動かす
+	if util.IsSystemAccountOwner(fromAccount.Owner) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot transfer from system account [%d]", fromAccount.ID)
+	}
+
+	if util.IsSystemAccountOwner(toAccount.Owner) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot transfer to system account [%d]", toAccount.ID)
+	}
+
+	amount := arg.Amount
+
+	fee, err := server.quoteTransferFee(ctx, fromAccount.ID, amount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// 読んだ残高で先に断る。同時に送金した場合はトランザクションの中で確かめる
+	if fromAccount.Balance < amount+fee {
+		return nil, status.Errorf(codes.FailedPrecondition, "account [%d] doesn't have enough balance", fromAccount.ID)
+	}
+
+	// 銀行員が顧客の代わりに行う金額が大きい送金は、別の銀行員の承認を待つ
+	if authPayload.Role == util.BankerRole && fromAccount.Owner != authPayload.Username &&
+		server.requiresApproval(amount, fromAccount.Currency) {
+		operation, err := server.requestOperation(ctx, operationRequest{
+			kind:          util.OperationTransfer,
+			fromAccountID: fromAccount.ID,
+			toAccountID:   arg.ToAccountID,
+			amount:        amount,
+			currency:      fromAccount.Currency,
+			requestedBy:   authPayload.Username,
+			memo:          arg.Memo,
+			reference:     arg.Reference,
+			category:      arg.Category,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.CreateTransferResponse{PendingOperation: operation}, nil
+	}
+
+	review := server.setTransferHooks(ctx, authPayload.Username, fromAccount.Currency, &arg, 0)
+
+	result, err := server.store.TransferTx(ctx, arg)
+
+	if err != nil {
+		var limitErr *db.TransferLimitError
+		if errors.As(err, &limitErr) {
+			return nil, transferLimitError(limitErr)
+		}
+
+		if errors.Is(err, db.ErrInsufficientBalance) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account [%d] doesn't have enough balance", fromAccount.ID)
+		}
+
+		if errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account is closed")
+		}
+
+		if errors.Is(err, fraud.ErrBlocked) {
+			return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to transfer: %v", err)
+	}
+
+	if result.Held {
+		return &pb.CreateTransferResponse{
+			Review: convertFraudReview(*review),
+		}, nil
+	}
+
+	currency := fromAccount.Currency
+
+	rsp := &pb.CreateTransferResponse{
+		Transfer:    convertTransfer(result.Transfer, currency),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertTransferEntry(result.FromEntry, result.Transfer, currency),
+		ToEntry:     convertTransferEntry(result.ToEntry, result.Transfer, currency),
+		Fee:         convertMoney(result.Fee, currency),
+	}
+
+	if result.Fee > 0 {
+		rsp.FeeEntry = convertTransferEntry(result.FeeEntry, result.Transfer, currency)
+	}
+
+	return rsp, nil
+}
+
+// setTransferHooks は送金に不正検知のルールによる評価と、送金を記録した後の通知を設定する
+// 請求の支払いの場合は paymentRequestID を渡し、保留したときの審査に記録する
+// 保留された場合の審査は返した FraudReview に入る
+func (server *Server) setTransferHooks(ctx context.Context, username string, currency string, arg *db.TransferTxParams, paymentRequestID int64) *db.FraudReview {
+	review := &db.FraudReview{}
+
+	if server.fraud != nil {
+		arg.Screen = server.screenTransfer(ctx, username, currency, *arg, paymentRequestID, review)
+	}
+
+	arg.AfterCreate = server.notifyTransfer(ctx)
+
+	return review
+}
+
+// screenTransfer は不正検知のルールで送金を評価する
+// 保留する場合は送金と同じトランザクションで審査を作成し、review に入れる
+func (server *Server) screenTransfer(ctx context.Context, username string, currency string, arg db.TransferTxParams, paymentRequestID int64, review *db.FraudReview) func(q db.Querier) (bool, error) {
+	return func(q db.Querier) (bool, error) {
+		decision, err := server.fraud.Evaluate(ctx, q, fraud.Transfer{
+			Username:      username,
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+		})
+
+		if err != nil {
+			return false, err
+		}
+
+		switch decision.Action {
+		case fraud.ActionBlock:
+			return false, fmt.Errorf("%w: %s", fraud.ErrBlocked, strings.Join(decision.Rules, ","))
+		case fraud.ActionHold:
+			*review, err = q.CreateFraudReview(ctx, db.CreateFraudReviewParams{
+				FromAccountID: arg.FromAccountID,
+				ToAccountID:   arg.ToAccountID,
+				Amount:        arg.Amount,
+				Currency:      currency,
+				RequestedBy:   username,
+				Rules:         strings.Join(decision.Rules, ","),
+				Memo:          arg.Memo,
+				Reference:     arg.Reference,
+				Category:      arg.Category,
+				PaymentRequestID: pgtype.Int8{
+					Int64: paymentRequestID,
+					Valid: paymentRequestID != 0,
+				},
+			})
+
+			return false, err
+		}
+
+		return true, nil
+	}
+}
+
+func (server *Server) validAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+
+		return account, status.Errorf(codes.Internal, "cannot get account: %v", err)
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", accountID, account.Currency, currency)
+	}
+
+	// 送金するまでの間に閉じられた場合はトランザクションの中で確かめる
+	if account.ClosedAt.Valid {
+		return account, status.Errorf(codes.FailedPrecondition, "account [%d] is closed", accountID)
+	}
+
+	return account, nil
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAccountID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, filedViolation("from_account_id", err))
+	}
+
+	if err := validator.ValidateAccountID(req.GetToAccountId()); err != nil {
+		violations = append(violations, filedViolation("to_account_id", err))
+	}
+
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "to_account_id",
+			Description: "from account id and to account id cannot be the same",
+		})
+	}
+
+	violations = append(violations, validateMoney("amount", req.GetAmount())...)
+	violations = append(violations, validateTransferDetails(req.GetMemo(), req.GetReference(), req.GetCategory())...)
+
+	return violations
+}
+
+// validateTransferDetails は送金に付けるメモ、参照番号、分類を確認する
+func validateTransferDetails(memo string, reference string, category string) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateMemo(memo); err != nil {
+		violations = append(violations, filedViolation("memo", err))
+	}
+
+	if err := validator.ValidateReference(reference); err != nil {
+		violations = append(violations, filedViolation("reference", err))
+	}
+
+	if err := validator.ValidateCategory(category); err != nil {
+		violations = append(violations, filedViolation("category", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,673 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/fraud"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eqTransferTxParamsMatcher struct {
+	arg db.TransferTxParams
+}
+
+func (expected eqTransferTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.TransferTxParams)
+	if !ok {
+		return false
+	}
+
+	// 送金の後に通知のタスクを登録する
+	if actualArg.AfterCreate == nil {
+		return false
+	}
+
+	// 関数は reflect.DeepEqual で比較できないので除いて比較する
+	actualArg.Screen = nil
+	actualArg.AfterCreate = nil
+
+	return reflect.DeepEqual(expected.arg, actualArg)
+}
+
+func (e eqTransferTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v", e.arg)
+}
+
+func EqTransferTxParams(arg db.TransferTxParams) gomock.Matcher {
+	return eqTransferTxParamsMatcher{arg}
+}
+
+func TestCreateTransferAPI(t *testing.T) {
+	user1, _ := randomUser()
+	user2, _ := randomUser()
+
+	account1 := randomAccount(user1.Username, util.USD)
+	account2 := randomAccount(user2.Username, util.USD)
+	account3 := randomAccount(user2.Username, util.EUR)
+
+	// ランダムなIDが重複しないようにする
+	account2.ID = account1.ID + 1
+	account3.ID = account1.ID + 2
+
+	// 持ち主を削除して閉じた口座
+	closedAccount := account2
+	closedAccount.ClosedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	amount := int64(10)
+	schedule := db.FeeSchedule{
+		Role:          util.DepositorRole,
+		AccountType:   util.CheckingAccount,
+		Currency:      util.USD,
+		FlatFee:       1,
+		PercentFeeBps: 1000,
+	}
+	fee := int64(2)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(schedule, nil)
+
+				arg := db.TransferTxParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+				}
+
+				store.EXPECT().
+					TransferTx(gomock.Any(), EqTransferTxParams(arg)).
+					Times(1).
+					Return(db.TransferTxResult{
+						Transfer: db.Transfer{
+							FromAccountID: account1.ID,
+							ToAccountID:   account2.ID,
+							Amount:        amount,
+							Fee:           fee,
+						},
+						FromAccount: account1,
+						ToAccount:   account2,
+						Fee:         fee,
+						FeeEntry: db.Entry{
+							AccountID: account1.ID,
+							Amount:    -fee,
+						},
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, amount, res.GetTransfer().GetAmount().GetUnits())
+				require.Equal(t, fee, res.GetTransfer().GetFee().GetUnits())
+				require.Equal(t, fee, res.GetFee().GetUnits())
+				require.Equal(t, -fee, res.GetFeeEntry().GetAmount().GetUnits())
+			},
+		},
+		{
+			name: "TransferLimitExceeded",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(schedule, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, &db.TransferLimitError{
+						Limit:     db.LimitDailyAmount,
+						Max:       100,
+						Remaining: 5,
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+
+				require.Len(t, st.Details(), 1)
+				quotaFailure, ok := st.Details()[0].(*errdetails.QuotaFailure)
+				require.True(t, ok)
+				require.Equal(t, db.LimitDailyAmount, quotaFailure.GetViolations()[0].GetSubject())
+			},
+		},
+		{
+			name: "InsufficientBalanceInTx",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(schedule, nil)
+				// 読んだ後に同時に送金して、ロックした後の残高では足りない場合
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, db.ErrInsufficientBalance)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "ToAccountClosed",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   closedAccount.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(closedAccount.ID)).Times(1).Return(closedAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "AccountClosedInTx",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(schedule, nil)
+				// 読んだ後に送金先の持ち主が削除された場合
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, db.ErrAccountClosed)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "InsufficientBalance",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    account1.Balance,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(schedule, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "NoFeeSchedule",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    account1.Balance,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, db.ErrorRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Zero(t, res.GetFee().GetUnits())
+				require.Nil(t, res.GetFeeEntry())
+			},
+		},
+		{
+			name: "PermissionDenied",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, user2.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "SystemAccount",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				systemAccount := account2
+				systemAccount.Owner = util.FeeRevenueOwner
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(systemAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "FromSystemAccount",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				systemAccount := account1
+				systemAccount.Owner = util.CashVaultOwner
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(systemAccount, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				// 銀行員はほかの人の口座から送金できるが、システム口座からは送れない
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account3.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account3.ID)).Times(1).Return(account3, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "FromAccountNotFound",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(db.Account{}, db.ErrorRecordNotFound)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidAmount",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    -amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, user1.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.maker)
+			res, err := server.CreateTransfer(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestCreateTransferFraudScreening(t *testing.T) {
+	user1, _ := randomUser()
+	user2, _ := randomUser()
+
+	account1 := randomAccount(user1.Username, util.USD)
+	account2 := randomAccount(user2.Username, util.USD)
+	account2.ID = account1.ID + 1
+
+	amount := int64(10)
+
+	req := &pb.CreateTransferRequest{
+		FromAccountId: account1.ID,
+		ToAccountId:   account2.ID,
+		Amount: &pb.Money{
+			Currency: util.USD,
+			Units:    amount,
+		},
+		Memo:      "invoice 42",
+		Reference: "INV-42",
+		Category:  "rent",
+	}
+
+	testCases := []struct {
+		name          string
+		action        fraud.Action
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name:   "Held",
+			action: fraud.ActionHold,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateFraudReview(gomock.Any(), gomock.Eq(db.CreateFraudReviewParams{
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+						Currency:      util.USD,
+						RequestedBy:   user1.Username,
+						Rules:         "new_ip",
+						Memo:          "invoice 42",
+						Reference:     "INV-42",
+						Category:      "rent",
+					})).
+					Times(1).
+					Return(db.FraudReview{
+						ID:            1,
+						FromAccountID: account1.ID,
+						ToAccountID:   account2.ID,
+						Amount:        amount,
+						Currency:      util.USD,
+						RequestedBy:   user1.Username,
+						Rules:         "new_ip",
+						Status:        util.ReviewPending,
+						Memo:          "invoice 42",
+						Reference:     "INV-42",
+						Category:      "rent",
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Nil(t, res.GetTransfer())
+				require.Equal(t, int64(1), res.GetReview().GetId())
+				require.Equal(t, util.ReviewPending, res.GetReview().GetStatus())
+				require.Equal(t, []string{"new_ip"}, res.GetReview().GetRules())
+				require.Equal(t, "invoice 42", res.GetReview().GetMemo())
+			},
+		},
+		{
+			name:   "Blocked",
+			action: fraud.ActionBlock,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFraudReview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+			store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, db.ErrorRecordNotFound)
+			store.EXPECT().GetRecentLoginFromNewIP(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{Username: user1.Username}, nil)
+
+			// 送金上限の確認の後に Screen が呼ばれる
+			store.EXPECT().
+				TransferTx(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+					proceed, err := arg.Screen(store)
+					require.False(t, proceed)
+
+					return db.TransferTxResult{Held: true}, err
+				})
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			engine, err := fraud.NewEngine([]fraud.Rule{
+				{Name: "new_ip", Type: fraud.RuleLoginFromNewIP, Window: time.Minute, Action: tc.action},
+			})
+			require.NoError(t, err)
+			server.fraud = engine
+
+			ctx := newContextWithBearerToken(t, server.maker, user1.Username, user1.Role, time.Minute)
+			res, err := server.CreateTransfer(ctx, req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func randomAccount(owner string, currency string) db.Account {
+	return db.Account{
+		ID:          int64(util.RandomInt(1, 1000)),
+		Owner:       owner,
+		Balance:     int64(util.RandomInt(100, 1000)),
+		Currency:    currency,
+		AccountType: util.CheckingAccount,
+	}
+}
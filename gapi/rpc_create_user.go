@@ -22,7 +22,7 @@ func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 	// getterが使用できる。
-	hashedPassword, err := util.HashPassword(req.GetPassword())
+	hashedPassword, err := util.HashPasswordWithParams(req.GetPassword(), server.config.PasswordParams())
 
 	if err != nil {
 
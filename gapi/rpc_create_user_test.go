@@ -216,7 +216,7 @@ func TestCreateUserTxAPI(t *testing.T) {
 }
 
 func randomUser() (user db.User, password string) {
-	password = util.RandomString(6)
+	password = util.RandomString(12)
 
 	hashedPassword, err := util.HashPassword(password)
 	if err != nil {
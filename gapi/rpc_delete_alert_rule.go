@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) DeleteAlertRule(ctx context.Context, req *pb.DeleteAlertRuleRequest) (*pb.DeleteAlertRuleResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeleteAlertRuleRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	rule, err := server.store.GetAlertRule(ctx, req.GetAlertRuleId())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "alert rule [%d] not found", req.GetAlertRuleId())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get alert rule: %v", err)
+	}
+
+	if rule.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "alert rule [%d] doesn't belong to the authenticated user", rule.ID)
+	}
+
+	err = server.store.DeleteAlertRule(ctx, rule.ID)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete alert rule: %v", err)
+	}
+
+	return &pb.DeleteAlertRuleResponse{}, nil
+}
+
+func validateDeleteAlertRuleRequest(req *pb.DeleteAlertRuleRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAlertRuleID(req.GetAlertRuleId()); err != nil {
+		violations = append(violations, filedViolation("alert_rule_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,86 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) Deposit(ctx context.Context, req *pb.DepositRequest) (*pb.DepositResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDepositRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validAccount(ctx, req.GetAccountId(), req.GetAmount().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if util.IsSystemAccountOwner(account.Owner) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot deposit into system account [%d]", account.ID)
+	}
+
+	if server.requiresApproval(req.GetAmount().GetUnits(), account.Currency) {
+		operation, err := server.requestOperation(ctx, operationRequest{
+			kind:        util.OperationDeposit,
+			toAccountID: account.ID,
+			amount:      req.GetAmount().GetUnits(),
+			currency:    account.Currency,
+			requestedBy: authPayload.Username,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.DepositResponse{PendingOperation: operation}, nil
+	}
+
+	result, err := server.store.DepositTx(ctx, db.DepositTxParams{
+		AccountID:   account.ID,
+		Amount:      req.GetAmount().GetUnits(),
+		AfterCreate: server.notifyTransfer(ctx),
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account [%d] is closed", account.ID)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to deposit: %v", err)
+	}
+
+	rsp := &pb.DepositResponse{
+		Account:  convertAccount(result.Account),
+		Entry:    convertEntry(result.Entry, account.Currency),
+		Transfer: convertTransfer(result.Transfer, account.Currency),
+	}
+
+	return rsp, nil
+}
+
+func validateDepositRequest(req *pb.DepositRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, filedViolation("account_id", err))
+	}
+
+	violations = append(violations, validateMoney("amount", req.GetAmount())...)
+
+	return violations
+}
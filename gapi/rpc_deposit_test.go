@@ -0,0 +1,176 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDepositAPI(t *testing.T) {
+	user, _ := randomUser()
+	banker, _ := randomUser()
+	banker.Role = util.BankerRole
+
+	account := randomAccount(user.Username, util.USD)
+	amount := int64(10)
+
+	testCases := []struct {
+		name          string
+		req           *pb.DepositRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.DepositResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				updatedAccount := account
+				updatedAccount.Balance += amount
+
+				store.EXPECT().
+					DepositTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.DepositTxParams) (db.DepositTxResult, error) {
+						require.Equal(t, account.ID, arg.AccountID)
+						require.Equal(t, amount, arg.Amount)
+						// 送金の通知とアラートの評価のタスクを登録する
+						require.NotNil(t, arg.AfterCreate)
+
+						return db.DepositTxResult{
+							Account: updatedAccount,
+							Entry: db.Entry{
+								AccountID: account.ID,
+								Amount:    amount,
+							},
+						}, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DepositResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, account.Balance+amount, res.GetAccount().GetBalance().GetUnits())
+				require.Equal(t, amount, res.GetEntry().GetAmount().GetUnits())
+			},
+		},
+		{
+			name: "SystemAccount",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				systemAccount := account
+				systemAccount.Owner = util.FeeRevenueOwner
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(systemAccount, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount: &pb.Money{
+					Currency: util.EUR,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.DepositRequest{
+				AccountId: account.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.DepositResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.maker)
+			res, err := server.Deposit(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.ExportMyDataResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get user: %v", err)
+	}
+
+	if user.DeletedAt.Valid {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	// ZIP を作るのに時間がかかるので、ワーカーで作ってからダウンロードのリンクをメールで送る
+	txResult, err := server.store.CreateDataExportTx(ctx, db.CreateDataExportTxParams{
+		Username: user.Username,
+		AfterCreate: func(dataExport db.DataExport) error {
+			return server.exportUserData(ctx, dataExport)
+		},
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create data export: %v", err)
+	}
+
+	rsp := &pb.ExportMyDataResponse{
+		ExportId: txResult.DataExport.ID,
+		Status:   txResult.DataExport.Status,
+	}
+
+	return rsp, nil
+}
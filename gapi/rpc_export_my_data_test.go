@@ -0,0 +1,258 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/link"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/worker"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eqCreateDataExportTxParamsMatcher struct {
+	username   string
+	dataExport db.DataExport
+}
+
+func (expected eqCreateDataExportTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.CreateDataExportTxParams)
+
+	if !ok || actualArg.Username != expected.username {
+		return false
+	}
+
+	// CreateDataExportTx は mock なので、タスクの登録をここで呼び出す
+	return actualArg.AfterCreate(expected.dataExport) == nil
+}
+
+func (e eqCreateDataExportTxParamsMatcher) String() string {
+	return "matches username " + e.username
+}
+
+func TestExportMyDataAPI(t *testing.T) {
+	user, _ := randomUser()
+
+	deleted := user
+	deleted.DeletedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	dataExport := db.DataExport{
+		ID:       int64(util.RandomInt(1, 1000)),
+		Username: user.Username,
+		Status:   util.DataExportPending,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		buildContext  func(t *testing.T, server *Server) context.Context
+		checkResponse func(t *testing.T, res *pb.ExportMyDataResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					CreateDataExportTx(gomock.Any(), eqCreateDataExportTxParamsMatcher{username: user.Username, dataExport: dataExport}).
+					Times(1).
+					Return(db.CreateDataExportTxResult{DataExport: dataExport}, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskExportUserData(gomock.Any(), gomock.Eq(&worker.PayloadExportUserData{ExportID: dataExport.ID}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return newContextWithBearerToken(t, server.maker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExportMyDataResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, dataExport.ID, res.GetExportId())
+				require.Equal(t, util.DataExportPending, res.GetStatus())
+			},
+		},
+		{
+			name: "DeletedUser",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(deleted, nil)
+				store.EXPECT().CreateDataExportTx(gomock.Any(), gomock.Any()).Times(0)
+				taskDistributor.EXPECT().DistributeTaskExportUserData(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return newContextWithBearerToken(t, server.maker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ExportMyDataResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateDataExportTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, server *Server) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.ExportMyDataResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, taskDistributor)
+
+			res, err := server.ExportMyData(tc.buildContext(t, server), &pb.ExportMyDataRequest{})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestDownloadDataExport(t *testing.T) {
+	archive := []byte("PK\x05\x06")
+
+	ready := db.DataExport{
+		ID:        int64(util.RandomInt(1, 1000)),
+		Username:  util.RandomOwner(),
+		Status:    util.DataExportReady,
+		Archive:   archive,
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+	}
+
+	pending := ready
+	pending.Status = util.DataExportPending
+	pending.Archive = nil
+
+	expired := ready
+	expired.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Second), Valid: true}
+
+	testCases := []struct {
+		name          string
+		buildURL      func(server *Server) string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildURL: func(server *Server) string {
+				return server.links.SignedURL(link.DataExportPath, link.DataExportParams(ready.ID))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDataExport(gomock.Any(), gomock.Eq(ready.ID)).Times(1).Return(ready, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/zip", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+				require.Equal(t, archive, recorder.Body.Bytes())
+			},
+		},
+		{
+			name: "TamperedExportID",
+			buildURL: func(server *Server) string {
+				u, err := url.Parse(server.links.SignedURL(link.DataExportPath, link.DataExportParams(ready.ID)))
+				require.NoError(t, err)
+
+				// ほかのユーザーの書き出しの ID に書き換える
+				query := u.Query()
+				query.Set("export_id", "0")
+				u.RawQuery = query.Encode()
+
+				return u.String()
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDataExport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotReady",
+			buildURL: func(server *Server) string {
+				return server.links.SignedURL(link.DataExportPath, link.DataExportParams(pending.ID))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDataExport(gomock.Any(), gomock.Eq(pending.ID)).Times(1).Return(pending, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Expired",
+			buildURL: func(server *Server) string {
+				return server.links.SignedURL(link.DataExportPath, link.DataExportParams(expired.ID))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDataExport(gomock.Any(), gomock.Eq(expired.ID)).Times(1).Return(expired, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusGone, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildURL: func(server *Server) string {
+				return server.links.SignedURL(link.DataExportPath, link.DataExportParams(ready.ID))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDataExport(gomock.Any(), gomock.Eq(ready.ID)).Times(1).Return(db.DataExport{}, db.ErrorRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectActiveUser(store)
+
+			server := newTestServer(t, store, mockwk.NewMockTaskDistributor(ctrl))
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, tc.buildURL(server), nil)
+			server.DownloadDataExport(recorder, request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
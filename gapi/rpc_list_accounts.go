@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListAccountsRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scope, err := pageScope(authPayload.Username, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// 次のページがあるかどうかを知るために1件多く取得する
+	arg := db.ListAccountsParams{
+		Owner: authPayload.Username,
+		Limit: pageSize + 1,
+	}
+
+	arg.CursorCreatedAt, arg.CursorID, err = server.pageCursor(scope, req.GetPageToken())
+
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []db.Account
+
+	if req.GetSortDirection() == pb.SortDirection_SORT_DIRECTION_DESCENDING {
+		accounts, err = server.store.ListAccountsDesc(ctx, db.ListAccountsDescParams(arg))
+	} else {
+		accounts, err = server.store.ListAccounts(ctx, arg)
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list accounts: %v", err)
+	}
+
+	rsp := &pb.ListAccountsResponse{
+		Accounts: make([]*pb.Account, 0, len(accounts)),
+	}
+
+	if len(accounts) > int(pageSize) {
+		accounts = accounts[:pageSize]
+		last := accounts[len(accounts)-1]
+
+		rsp.NextPageToken, err = server.nextPageToken(scope, last.CreatedAt, last.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, account := range accounts {
+		rsp.Accounts = append(rsp.Accounts, convertAccount(account))
+	}
+
+	return rsp, nil
+}
+
+func validateListAccountsRequest(req *pb.ListAccountsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateListPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, filedViolation("page_size", err))
+	}
+
+	return violations
+}
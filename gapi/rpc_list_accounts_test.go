@@ -0,0 +1,190 @@
+package gapi
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListAccountsAPI(t *testing.T) {
+	user, _ := randomUser()
+	otherUser, _ := randomUser()
+
+	pageSize := int32(3)
+	// 次のページがあることを示すために1件多く返す
+	accounts := make([]db.Account, pageSize+1)
+
+	for i := range accounts {
+		accounts[i] = randomAccount(user.Username, util.USD)
+		accounts[i].ID = int64(i + 1)
+		accounts[i].CreatedAt = time.Now().Add(time.Duration(i) * time.Minute).UTC()
+	}
+
+	pageTokens, err := pagination.NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	scope, err := pageScope(user.Username, &pb.ListAccountsRequest{})
+	require.NoError(t, err)
+
+	last := accounts[pageSize-1]
+	nextPageToken, err := pageTokens.Encode(scope, pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		username      string
+		req           *pb.ListAccountsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ListAccountsResponse, err error)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			req: &pb.ListAccountsRequest{
+				PageSize: pageSize,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Eq(db.ListAccountsParams{
+						Owner: user.Username,
+						Limit: pageSize + 1,
+					})).
+					Times(1).
+					Return(accounts, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetAccounts(), int(pageSize))
+				require.Equal(t, nextPageToken, res.GetNextPageToken())
+			},
+		},
+		{
+			name:     "LastPage",
+			username: user.Username,
+			req: &pb.ListAccountsRequest{
+				PageSize:  pageSize,
+				PageToken: nextPageToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Eq(db.ListAccountsParams{
+						Owner:           user.Username,
+						CursorCreatedAt: pgtype.Timestamptz{Time: last.CreatedAt, Valid: true},
+						CursorID:        pgtype.Int8{Int64: last.ID, Valid: true},
+						Limit:           pageSize + 1,
+					})).
+					Times(1).
+					Return(accounts[pageSize:], nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetAccounts(), 1)
+				require.Empty(t, res.GetNextPageToken())
+			},
+		},
+		{
+			name:     "Descending",
+			username: user.Username,
+			req: &pb.ListAccountsRequest{
+				SortDirection: pb.SortDirection_SORT_DIRECTION_DESCENDING,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					ListAccountsDesc(gomock.Any(), gomock.Eq(db.ListAccountsDescParams{
+						Owner: user.Username,
+						Limit: pagination.DefaultPageSize + 1,
+					})).
+					Times(1).
+					Return(accounts, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetAccounts(), len(accounts))
+				require.Empty(t, res.GetNextPageToken())
+			},
+		},
+		{
+			name:     "PageTokenOfOtherUser",
+			username: otherUser.Username,
+			req: &pb.ListAccountsRequest{
+				PageSize:  pageSize,
+				PageToken: nextPageToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name:     "InvalidPageSize",
+			username: user.Username,
+			req: &pb.ListAccountsRequest{
+				PageSize: -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name:     "InternalError",
+			username: user.Username,
+			req:      &pb.ListAccountsRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListAccountsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Internal, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+			server.pageTokens = pageTokens
+
+			ctx := newContextWithBearerToken(t, server.maker, tc.username, util.DepositorRole, time.Minute)
+			res, err := server.ListAccounts(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAlertRules(ctx context.Context, req *pb.ListAlertRulesRequest) (*pb.ListAlertRulesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	rules, err := server.store.ListAlertRules(ctx, authPayload.Username)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list alert rules: %v", err)
+	}
+
+	rsp := &pb.ListAlertRulesResponse{
+		AlertRules: make([]*pb.AlertRule, 0, len(rules)),
+	}
+
+	// 閾値の通貨を知るために口座を取得する
+	currencies := map[int64]string{}
+
+	for _, rule := range rules {
+		currency, ok := currencies[rule.AccountID]
+
+		if !ok {
+			account, err := server.store.GetAccount(ctx, rule.AccountID)
+
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "cannot get account: %v", err)
+			}
+
+			currency = account.Currency
+			currencies[rule.AccountID] = currency
+		}
+
+		rsp.AlertRules = append(rsp.AlertRules, convertAlertRule(rule, currency))
+	}
+
+	return rsp, nil
+}
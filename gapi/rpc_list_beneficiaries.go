@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListBeneficiaries(ctx context.Context, req *pb.ListBeneficiariesRequest) (*pb.ListBeneficiariesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListBeneficiariesRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scope, err := pageScope(authPayload.Username, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// 次のページがあるかどうかを知るために1件多く取得する
+	arg := db.ListBeneficiariesParams{
+		Owner: authPayload.Username,
+		Limit: pageSize + 1,
+	}
+
+	arg.CursorCreatedAt, arg.CursorID, err = server.pageCursor(scope, req.GetPageToken())
+
+	if err != nil {
+		return nil, err
+	}
+
+	beneficiaries, err := server.store.ListBeneficiaries(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list beneficiaries: %v", err)
+	}
+
+	rsp := &pb.ListBeneficiariesResponse{
+		Beneficiaries: make([]*pb.Beneficiary, 0, len(beneficiaries)),
+	}
+
+	if len(beneficiaries) > int(pageSize) {
+		beneficiaries = beneficiaries[:pageSize]
+		last := beneficiaries[len(beneficiaries)-1]
+
+		rsp.NextPageToken, err = server.nextPageToken(scope, last.CreatedAt, last.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, beneficiary := range beneficiaries {
+		rsp.Beneficiaries = append(rsp.Beneficiaries, convertBeneficiary(beneficiary))
+	}
+
+	return rsp, nil
+}
+
+func validateListBeneficiariesRequest(req *pb.ListBeneficiariesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateListPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, filedViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,110 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListEntries(ctx context.Context, req *pb.ListEntriesRequest) (*pb.ListEntriesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListEntriesRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.GetAccount(ctx, req.GetAccountId())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "account [%d] not found", req.GetAccountId())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get account: %v", err)
+	}
+
+	if authPayload.Role != util.BankerRole && account.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", account.ID)
+	}
+
+	scope, err := pageScope(authPayload.Username, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// 次のページがあるかどうかを知るために1件多く取得する
+	arg := db.ListEntriesParams{
+		AccountID: account.ID,
+		Limit:     pageSize + 1,
+	}
+
+	arg.CursorCreatedAt, arg.CursorID, err = server.pageCursor(scope, req.GetPageToken())
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []db.Entry
+
+	if req.GetSortDirection() == pb.SortDirection_SORT_DIRECTION_DESCENDING {
+		entries, err = server.store.ListEntriesDesc(ctx, db.ListEntriesDescParams(arg))
+	} else {
+		entries, err = server.store.ListEntries(ctx, arg)
+	}
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list entries: %v", err)
+	}
+
+	rsp := &pb.ListEntriesResponse{
+		Entries: make([]*pb.Entry, 0, len(entries)),
+	}
+
+	if len(entries) > int(pageSize) {
+		entries = entries[:pageSize]
+		last := entries[len(entries)-1]
+
+		rsp.NextPageToken, err = server.nextPageToken(scope, last.CreatedAt, last.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range entries {
+		rsp.Entries = append(rsp.Entries, convertEntry(entry, account.Currency))
+	}
+
+	return rsp, nil
+}
+
+func validateListEntriesRequest(req *pb.ListEntriesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, filedViolation("account_id", err))
+	}
+
+	if err := validator.ValidateListPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, filedViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,96 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListFraudReviews(ctx context.Context, req *pb.ListFraudReviewsRequest) (*pb.ListFraudReviewsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListFraudReviewsRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// 指定がない場合は審査待ちのものを返す
+	reviewStatus := req.GetStatus()
+
+	if reviewStatus == "" {
+		reviewStatus = util.ReviewPending
+	}
+
+	scope, err := pageScope(authPayload.Username, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// 次のページがあるかどうかを知るために1件多く取得する
+	arg := db.ListFraudReviewsParams{
+		Status: reviewStatus,
+		Limit:  pageSize + 1,
+	}
+
+	arg.CursorCreatedAt, arg.CursorID, err = server.pageCursor(scope, req.GetPageToken())
+
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := server.store.ListFraudReviews(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list fraud reviews: %v", err)
+	}
+
+	rsp := &pb.ListFraudReviewsResponse{
+		Reviews: make([]*pb.FraudReview, 0, len(reviews)),
+	}
+
+	if len(reviews) > int(pageSize) {
+		reviews = reviews[:pageSize]
+		last := reviews[len(reviews)-1]
+
+		rsp.NextPageToken, err = server.nextPageToken(scope, last.CreatedAt, last.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, review := range reviews {
+		rsp.Reviews = append(rsp.Reviews, convertFraudReview(review))
+	}
+
+	return rsp, nil
+}
+
+func validateListFraudReviewsRequest(req *pb.ListFraudReviewsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.Status != "" {
+		if err := validator.ValidateReviewStatus(req.GetStatus()); err != nil {
+			violations = append(violations, filedViolation("status", err))
+		}
+	}
+
+	if err := validator.ValidateListPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, filedViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListIncomingPaymentRequests(ctx context.Context, req *pb.ListIncomingPaymentRequestsRequest) (*pb.ListIncomingPaymentRequestsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListIncomingPaymentRequestsRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scope, err := pageScope(authPayload.Username, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// 次のページがあるかどうかを知るために1件多く取得する
+	arg := db.ListIncomingPaymentRequestsParams{
+		Payer: authPayload.Username,
+		Limit: pageSize + 1,
+	}
+
+	arg.CursorCreatedAt, arg.CursorID, err = server.pageCursor(scope, req.GetPageToken())
+
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := server.store.ListIncomingPaymentRequests(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payment requests: %v", err)
+	}
+
+	rsp := &pb.ListIncomingPaymentRequestsResponse{
+		PaymentRequests: make([]*pb.PaymentRequest, 0, len(requests)),
+	}
+
+	if len(requests) > int(pageSize) {
+		requests = requests[:pageSize]
+		last := requests[len(requests)-1]
+
+		rsp.NextPageToken, err = server.nextPageToken(scope, last.CreatedAt, last.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, request := range requests {
+		rsp.PaymentRequests = append(rsp.PaymentRequests, convertPaymentRequest(request))
+	}
+
+	return rsp, nil
+}
+
+func validateListIncomingPaymentRequestsRequest(req *pb.ListIncomingPaymentRequestsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateListPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, filedViolation("page_size", err))
+	}
+
+	return violations
+}
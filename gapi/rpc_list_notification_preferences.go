@@ -0,0 +1,46 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/shouta0715/simple-bank/notify"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListNotificationPreferences(ctx context.Context, req *pb.ListNotificationPreferencesRequest) (*pb.ListNotificationPreferencesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	preferences, err := server.store.ListNotificationPreferences(ctx, authPayload.Username)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list notification preferences: %v", err)
+	}
+
+	rsp := &pb.ListNotificationPreferencesResponse{
+		Preferences: make([]*pb.NotificationPreference, 0, len(util.NotificationEvents)),
+	}
+
+	// 保存していないイベントは既定の設定を返す
+	for _, event := range util.NotificationEvents {
+		preference := notify.DefaultPreference(authPayload.Username, event)
+
+		for _, saved := range preferences {
+			if saved.Event == event {
+				preference = saved
+			}
+		}
+
+		rsp.Preferences = append(rsp.Preferences, convertNotificationPreference(preference))
+	}
+
+	return rsp, nil
+}
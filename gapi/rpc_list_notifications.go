@@ -0,0 +1,93 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateListNotificationsRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scope, err := pageScope(authPayload.Username, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	// 次のページがあるかどうかを知るために1件多く取得する
+	arg := db.ListNotificationsParams{
+		Username:   authPayload.Username,
+		UnreadOnly: req.GetUnreadOnly(),
+		Limit:      pageSize + 1,
+	}
+
+	arg.CursorCreatedAt, arg.CursorID, err = server.pageCursor(scope, req.GetPageToken())
+
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := server.store.ListNotifications(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list notifications: %v", err)
+	}
+
+	unreadCount, err := server.store.CountUnreadNotifications(ctx, authPayload.Username)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count unread notifications: %v", err)
+	}
+
+	rsp := &pb.ListNotificationsResponse{
+		Notifications: make([]*pb.Notification, 0, len(notifications)),
+		UnreadCount:   unreadCount,
+	}
+
+	if len(notifications) > int(pageSize) {
+		notifications = notifications[:pageSize]
+		last := notifications[len(notifications)-1]
+
+		rsp.NextPageToken, err = server.nextPageToken(scope, last.CreatedAt, last.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, notification := range notifications {
+		rsp.Notifications = append(rsp.Notifications, convertNotification(notification))
+	}
+
+	return rsp, nil
+}
+
+func validateListNotificationsRequest(req *pb.ListNotificationsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateListPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, filedViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,160 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListReconciliationReportsAPI(t *testing.T) {
+	user, _ := randomUser()
+	banker, _ := randomUser()
+	banker.Role = util.BankerRole
+
+	reports := []db.ReconciliationReport{
+		{
+			ID:    2,
+			RunID: util.RandomString(36),
+			Kind:  "transfer_imbalance",
+			TransferID: pgtype.Int8{
+				Int64: int64(util.RandomInt(1, 1000)),
+				Valid: true,
+			},
+			Actual: -util.RandomMoney(),
+		},
+		{
+			ID:    1,
+			RunID: util.RandomString(36),
+			Kind:  "account_balance",
+			AccountID: pgtype.Int8{
+				Int64: int64(util.RandomInt(1, 1000)),
+				Valid: true,
+			},
+			Expected: util.RandomMoney(),
+			Actual:   util.RandomMoney(),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListReconciliationReportsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.ListReconciliationReportsRequest{
+				PageSize: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListReconciliationReportsParams{
+					Limit: 6,
+				}
+
+				store.EXPECT().
+					ListReconciliationReports(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(reports, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetReports(), len(reports))
+				require.Empty(t, res.GetNextPageToken())
+
+				require.Equal(t, reports[0].TransferID.Int64, res.GetReports()[0].GetTransferId())
+				require.Nil(t, res.GetReports()[0].AccountId)
+				require.Equal(t, reports[1].AccountID.Int64, res.GetReports()[1].GetAccountId())
+				require.Nil(t, res.GetReports()[1].TransferId)
+			},
+		},
+		{
+			name: "InvalidPageToken",
+			req: &pb.ListReconciliationReportsRequest{
+				PageToken: "invalid",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListReconciliationReports(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "NotBanker",
+			req: &pb.ListReconciliationReportsRequest{
+				PageSize: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListReconciliationReports(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "InvalidPageSize",
+			req: &pb.ListReconciliationReportsRequest{
+				PageSize: -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListReconciliationReports(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListReconciliationReportsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.maker)
+			res, err := server.ListReconciliationReports(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/rs/zerolog/log"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
 	"github.com/shouta0715/simple-bank/pb"
 	"github.com/shouta0715/simple-bank/util"
@@ -33,6 +34,11 @@ func (server *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 		return nil, status.Errorf(codes.Internal, "cannot find user: %v", err)
 	}
 
+	// 削除したユーザーはパスワードのハッシュも消しているので、見つからないユーザーと同じに扱う
+	if user.DeletedAt.Valid {
+		return nil, status.Errorf(codes.NotFound, "cannot find user: %v", db.ErrUserDeleted)
+	}
+
 	err = util.CheckPassword(req.GetPassword(), user.HashedPassword)
 
 	if err != nil {
@@ -40,60 +46,58 @@ func (server *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Logi
 		return nil, status.Errorf(codes.Unauthenticated, "invalid password: %v", err)
 	}
 
-	accessToken, accessPayload, err := server.maker.CreateToken(
-		user.Username,
-		user.Role,
-		server.config.AccessTokenDuration,
-	)
-
-	if err != nil {
-
-		return nil, status.Errorf(codes.Internal, "cannot create access token: %v", err)
+	// bcrypt や古いパラメーターで作ったハッシュは、パスワードが分かるログインのときに作り直す
+	// 作り直せなくてもログインは成功させる
+	if util.NeedsRehash(user.HashedPassword, server.config.PasswordParams()) {
+		if err := server.rehashPassword(ctx, user, req.GetPassword()); err != nil {
+			log.Error().Err(err).Str("username", user.Username).Msg("cannot rehash password")
+		}
 	}
 
-	refreshToken, refreshPayload, err := server.maker.CreateToken(
-		user.Username,
-		user.Role,
-		server.config.RefreshTokenDuration,
-	)
+	tokens, err := server.createSession(ctx, user)
 
 	if err != nil {
-
-		return nil, status.Errorf(codes.Internal, "cannot create refresh token: %v", err)
+		return nil, status.Errorf(codes.Internal, "cannot create session: %v", err)
 	}
 
-	mtdt := server.extractMetadata(ctx)
-	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    mtdt.UserAgent,
-		ClientIp:     mtdt.ClientIP,
-		IsBlocked:    false,
-		ExpiresAt:    refreshPayload.ExpiresAt.Time,
-	})
-
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "cannot create session: %v", err)
+	// 通知を登録できなくてもログインは成功させる
+	if err := server.notifyLogin(ctx, tokens.session); err != nil {
+		log.Error().Err(err).Str("username", user.Username).Msg("cannot enqueue login notification")
 	}
 
 	rsp := &pb.LoginResponse{
 		User:                  convertUser(user),
-		SessionId:             session.ID,
-		AccessToken:           accessToken,
-		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiresAt.Time),
-		RefreshToken:          refreshToken,
-		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiresAt.Time),
+		SessionId:             tokens.session.ID,
+		AccessToken:           tokens.accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(tokens.accessPayload.ExpiresAt.Time),
+		RefreshToken:          tokens.refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(tokens.refreshPayload.ExpiresAt.Time),
 	}
 	return rsp, nil
 }
 
+func (server *Server) rehashPassword(ctx context.Context, user db.User, password string) error {
+	hashedPassword, err := util.HashPasswordWithParams(password, server.config.PasswordParams())
+
+	if err != nil {
+		return err
+	}
+
+	// パスワード自体は変わらないので password_changed_at と version は更新しない
+	// 同時にパスワードが変わった場合は、読んだときのハッシュと違うので上書きしない
+	return server.store.RehashUserPassword(ctx, db.RehashUserPasswordParams{
+		Username:          user.Username,
+		OldHashedPassword: user.HashedPassword,
+		NewHashedPassword: hashedPassword,
+	})
+}
+
 func validateLoginRequest(req *pb.LoginRequest) (violations []*errdetails.BadRequest_FieldViolation) {
 	if err := validator.ValidateUsername(req.GetUsername()); err != nil {
 		violations = append(violations, filedViolation("username", err))
 	}
 
-	if err := validator.ValidatePassword(req.GetPassword()); err != nil {
+	if err := validator.ValidateLoginPassword(req.GetPassword()); err != nil {
 		violations = append(violations, filedViolation("password", err))
 	}
 
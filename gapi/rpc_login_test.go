@@ -0,0 +1,243 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	bycript "golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eqRehashPasswordMatcher struct {
+	username       string
+	password       string
+	hashedPassword string
+}
+
+func (expected eqRehashPasswordMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.RehashUserPasswordParams)
+
+	if !ok || actualArg.Username != expected.username {
+		return false
+	}
+
+	// 読んだときのハッシュのままの場合だけ作り直す
+	if actualArg.OldHashedPassword != expected.hashedPassword {
+		return false
+	}
+
+	if util.NeedsRehash(actualArg.NewHashedPassword, util.DefaultPasswordParams) {
+		return false
+	}
+
+	return util.CheckPassword(expected.password, actualArg.NewHashedPassword) == nil
+}
+
+func (e eqRehashPasswordMatcher) String() string {
+	return "matches rehashed password for " + e.username
+}
+
+func TestLoginAPI(t *testing.T) {
+	user, password := randomUser()
+
+	legacyHash, err := bycript.GenerateFromPassword([]byte(password), bycript.MinCost)
+	require.NoError(t, err)
+
+	legacy := user
+	legacy.HashedPassword = string(legacyHash)
+
+	deleted := user
+	deleted.HashedPassword = ""
+	deleted.DeletedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		req           *pb.LoginRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.LoginResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.LoginRequest{
+				Username: user.Username,
+				Password: password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					RehashUserPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{Username: user.Username}, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyLogin(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, res.GetUser().GetUsername())
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEmpty(t, res.GetRefreshToken())
+			},
+		},
+		{
+			name: "RehashLegacyPassword",
+			req: &pb.LoginRequest{
+				Username: legacy.Username,
+				Password: password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(legacy.Username)).
+					Times(1).
+					Return(legacy, nil)
+
+				store.EXPECT().
+					RehashUserPassword(gomock.Any(), eqRehashPasswordMatcher{
+						username:       legacy.Username,
+						password:       password,
+						hashedPassword: legacy.HashedPassword,
+					}).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{Username: legacy.Username}, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyLogin(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetAccessToken())
+			},
+		},
+		{
+			name: "WrongPassword",
+			req: &pb.LoginRequest{
+				Username: legacy.Username,
+				Password: password + "x",
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(legacy.Username)).
+					Times(1).
+					Return(legacy, nil)
+
+				store.EXPECT().
+					RehashUserPassword(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			// ポリシーより短い既存のパスワードでもログインできる
+			name: "ShortExistingPassword",
+			req: &pb.LoginRequest{
+				Username: user.Username,
+				Password: "abc",
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "DeletedUser",
+			req: &pb.LoginRequest{
+				Username: deleted.Username,
+				Password: password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(deleted.Username)).
+					Times(1).
+					Return(deleted, nil)
+
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "EmptyPassword",
+			req: &pb.LoginRequest{
+				Username: user.Username,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LoginResponse, err error) {
+				requireFieldViolation(t, err, "password")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			taskDistributor := mockwk.NewMockTaskDistributor(ctrl)
+			tc.buildStubs(store, taskDistributor)
+
+			server := newTestServer(t, store, taskDistributor)
+			res, err := server.Login(context.Background(), tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) LookupRecipient(ctx context.Context, req *pb.LookupRecipientRequest) (*pb.LookupRecipientResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateLookupRecipientRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, _, err := server.resolveRecipient(ctx, req.GetRecipient(), req.GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.LookupRecipientResponse{
+		MaskedName: util.MaskName(user.FullName),
+		Currency:   req.GetCurrency(),
+	}
+
+	return rsp, nil
+}
+
+func validateLookupRecipientRequest(req *pb.LookupRecipientRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateRecipient(req.GetRecipient()); err != nil {
+		violations = append(violations, filedViolation("recipient", err))
+	}
+
+	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, filedViolation("currency", err))
+	}
+
+	return violations
+}
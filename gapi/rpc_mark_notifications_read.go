@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) MarkNotificationsRead(ctx context.Context, req *pb.MarkNotificationsReadRequest) (*pb.MarkNotificationsReadResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateMarkNotificationsReadRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// 他のユーザーの通知の ID はクエリで無視される
+	notifications, err := server.store.MarkNotificationsRead(ctx, db.MarkNotificationsReadParams{
+		Username: authPayload.Username,
+		Ids:      req.GetNotificationIds(),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to mark notifications read: %v", err)
+	}
+
+	rsp := &pb.MarkNotificationsReadResponse{
+		Notifications: make([]*pb.Notification, 0, len(notifications)),
+	}
+
+	for _, notification := range notifications {
+		rsp.Notifications = append(rsp.Notifications, convertNotification(notification))
+	}
+
+	return rsp, nil
+}
+
+func validateMarkNotificationsReadRequest(req *pb.MarkNotificationsReadRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateNotificationIDs(req.GetNotificationIds()); err != nil {
+		violations = append(violations, filedViolation("notification_ids", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,114 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) PayPaymentRequest(ctx context.Context, req *pb.PayPaymentRequestRequest) (*pb.PayPaymentRequestResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validatePayPaymentRequestRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	request, err := server.getPaymentRequest(ctx, req.GetPaymentRequestId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Payer != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "payment request [%d] is not addressed to the authenticated user", request.ID)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), request.Currency)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", fromAccount.ID)
+	}
+
+	fee, err := server.quoteTransferFee(ctx, fromAccount.ID, request.Amount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Balance < request.Amount+fee {
+		return nil, status.Errorf(codes.FailedPrecondition, "account [%d] doesn't have enough balance", fromAccount.ID)
+	}
+
+	// 請求の支払いも通常の送金と同じように不正検知のルールで評価し、送金を知らせる
+	transferArg := db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   request.ToAccountID,
+		Amount:        request.Amount,
+		Memo:          request.Memo,
+	}
+	review := server.setTransferHooks(ctx, authPayload.Username, request.Currency, &transferArg, request.ID)
+
+	result, err := server.store.UpdatePaymentRequestTx(ctx, db.UpdatePaymentRequestTxParams{
+		RequestID:   request.ID,
+		Status:      util.PaymentRequestPaid,
+		Transfer:    transferArg,
+		AfterUpdate: server.notifyPaymentRequest(ctx),
+	})
+
+	if err != nil {
+		return nil, paymentRequestError(request.ID, err)
+	}
+
+	if result.Transfer.Held {
+		return &pb.PayPaymentRequestResponse{
+			PaymentRequest: convertPaymentRequest(result.PaymentRequest),
+			Review:         convertFraudReview(*review),
+		}, nil
+	}
+
+	currency := request.Currency
+
+	rsp := &pb.PayPaymentRequestResponse{
+		PaymentRequest: convertPaymentRequest(result.PaymentRequest),
+		Transfer:       convertTransfer(result.Transfer.Transfer, currency),
+		FromAccount:    convertAccount(result.Transfer.FromAccount),
+		FromEntry:      convertTransferEntry(result.Transfer.FromEntry, result.Transfer.Transfer, currency),
+		Fee:            convertMoney(result.Transfer.Fee, currency),
+	}
+
+	if result.Transfer.Fee > 0 {
+		rsp.FeeEntry = convertTransferEntry(result.Transfer.FeeEntry, result.Transfer.Transfer, currency)
+	}
+
+	return rsp, nil
+}
+
+func validatePayPaymentRequestRequest(req *pb.PayPaymentRequestRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidatePaymentRequestID(req.GetPaymentRequestId()); err != nil {
+		violations = append(violations, filedViolation("payment_request_id", err))
+	}
+
+	if err := validator.ValidateAccountID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, filedViolation("from_account_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,305 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/fraud"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/worker"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPayPaymentRequestAPI(t *testing.T) {
+	payer, _ := randomUser()
+	requester, _ := randomUser()
+
+	fromAccount := randomAccount(payer.Username, util.USD)
+	toAccount := randomAccount(requester.Username, util.USD)
+	toAccount.ID = fromAccount.ID + 1
+
+	request := db.PaymentRequest{
+		ID:          int64(util.RandomInt(1, 1000)),
+		Requester:   requester.Username,
+		Payer:       payer.Username,
+		ToAccountID: toAccount.ID,
+		Amount:      fromAccount.Balance,
+		Currency:    util.USD,
+		Memo:        "dinner",
+		Status:      util.PaymentRequestPending,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	paid := request
+	paid.Status = util.PaymentRequestPaid
+	paid.TransferID = pgtype.Int8{Int64: 1, Valid: true}
+
+	tooMuch := request
+	tooMuch.Amount = fromAccount.Balance + 1
+
+	testCases := []struct {
+		name          string
+		request       db.PaymentRequest
+		username      string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.PayPaymentRequestResponse, err error)
+	}{
+		{
+			name:     "OK",
+			request:  request,
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, db.ErrorRecordNotFound)
+
+				store.EXPECT().
+					UpdatePaymentRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdatePaymentRequestTxParams) (db.UpdatePaymentRequestTxResult, error) {
+						require.Equal(t, request.ID, arg.RequestID)
+						require.Equal(t, util.PaymentRequestPaid, arg.Status)
+						require.Equal(t, fromAccount.ID, arg.Transfer.FromAccountID)
+						require.Equal(t, request.ToAccountID, arg.Transfer.ToAccountID)
+						require.Equal(t, request.Memo, arg.Transfer.Memo)
+
+						result := db.UpdatePaymentRequestTxResult{
+							PaymentRequest: paid,
+							Transfer: db.TransferTxResult{
+								Transfer: db.Transfer{
+									ID:            paid.TransferID.Int64,
+									FromAccountID: fromAccount.ID,
+									ToAccountID:   toAccount.ID,
+									Amount:        request.Amount,
+								},
+								FromAccount: fromAccount,
+								ToAccount:   toAccount,
+							},
+						}
+
+						// UpdatePaymentRequestTx は mock なので、ここで通知のタスクを登録する
+						err := arg.Transfer.AfterCreate(result.Transfer)
+						require.NoError(t, err)
+
+						return result, arg.AfterUpdate(paid)
+					})
+
+				taskDistributor.EXPECT().
+					DistributeTaskNotifyTransfer(gomock.Any(), gomock.Eq(&worker.PayloadNotifyTransfer{TransferID: paid.TransferID.Int64}), gomock.Any()).
+					Times(1).
+					Return(nil)
+				taskDistributor.EXPECT().
+					DistributeTaskEvaluateAlerts(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				taskPayload := &worker.PayloadSendPaymentRequestEmail{
+					PaymentRequestID: request.ID,
+				}
+				taskDistributor.EXPECT().
+					DistributeTaskSendPaymentRequestEmail(gomock.Any(), taskPayload, gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, util.PaymentRequestPaid, res.GetPaymentRequest().GetStatus())
+				require.Equal(t, paid.TransferID.Int64, res.GetPaymentRequest().GetTransferId())
+				require.Equal(t, toAccount.ID, res.GetTransfer().GetToAccountId())
+			},
+		},
+		{
+			name:     "NotPayer",
+			request:  request,
+			username: requester.Username,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().UpdatePaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name:     "InsufficientBalance",
+			request:  tooMuch,
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(tooMuch, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, db.ErrorRecordNotFound)
+				store.EXPECT().UpdatePaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name:     "NotPending",
+			request:  request,
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+				store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, db.ErrorRecordNotFound)
+				store.EXPECT().
+					UpdatePaymentRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdatePaymentRequestTxResult{}, db.ErrPaymentRequestNotPending)
+				taskDistributor.EXPECT().DistributeTaskSendPaymentRequestEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name:     "NotFound",
+			request:  request,
+			username: payer.Username,
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(db.PaymentRequest{}, db.ErrorRecordNotFound)
+				store.EXPECT().UpdatePaymentRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.PayPaymentRequestResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, taskDistributor)
+
+			ctx := newContextWithBearerToken(t, server.maker, tc.username, util.DepositorRole, time.Minute)
+			res, err := server.PayPaymentRequest(ctx, &pb.PayPaymentRequestRequest{
+				PaymentRequestId: tc.request.ID,
+				FromAccountId:    fromAccount.ID,
+			})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestPayPaymentRequestFraudScreening(t *testing.T) {
+	payer, _ := randomUser()
+	requester, _ := randomUser()
+
+	fromAccount := randomAccount(payer.Username, util.USD)
+	toAccount := randomAccount(requester.Username, util.USD)
+	toAccount.ID = fromAccount.ID + 1
+
+	request := db.PaymentRequest{
+		ID:          int64(util.RandomInt(1, 1000)),
+		Requester:   requester.Username,
+		Payer:       payer.Username,
+		ToAccountID: toAccount.ID,
+		Amount:      fromAccount.Balance,
+		Currency:    util.USD,
+		Memo:        "dinner",
+		Status:      util.PaymentRequestPending,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	held := request
+	held.Status = util.PaymentRequestHeld
+
+	storeCtrl := gomock.NewController(t)
+	defer storeCtrl.Finish()
+	store := mockdb.NewMockStore(storeCtrl)
+
+	taskCtrl := gomock.NewController(t)
+	defer taskCtrl.Finish()
+	taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+	expectActiveUser(store)
+	store.EXPECT().GetPaymentRequest(gomock.Any(), gomock.Eq(request.ID)).Times(1).Return(request, nil)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(fromAccount.ID)).Times(1).Return(fromAccount, nil)
+	store.EXPECT().GetFeeScheduleForAccount(gomock.Any(), gomock.Any()).Times(1).Return(db.FeeSchedule{}, db.ErrorRecordNotFound)
+	store.EXPECT().GetRecentLoginFromNewIP(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{Username: payer.Username}, nil)
+	store.EXPECT().
+		CreateFraudReview(gomock.Any(), gomock.Eq(db.CreateFraudReviewParams{
+			FromAccountID: fromAccount.ID,
+			ToAccountID:   toAccount.ID,
+			Amount:        request.Amount,
+			Currency:      util.USD,
+			RequestedBy:   payer.Username,
+			Rules:         "new_ip",
+			Memo:          request.Memo,
+			PaymentRequestID: pgtype.Int8{
+				Int64: request.ID,
+				Valid: true,
+			},
+		})).
+		Times(1).
+		Return(db.FraudReview{ID: 1, Status: util.ReviewPending, Rules: "new_ip", Currency: util.USD}, nil)
+
+	// 送金上限の確認の後に Screen が呼ばれる
+	store.EXPECT().
+		UpdatePaymentRequestTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdatePaymentRequestTxParams) (db.UpdatePaymentRequestTxResult, error) {
+			proceed, err := arg.Transfer.Screen(store)
+			require.False(t, proceed)
+
+			return db.UpdatePaymentRequestTxResult{
+				PaymentRequest: held,
+				Transfer:       db.TransferTxResult{Held: true},
+			}, err
+		})
+
+	// 保留された支払いは審査が終わるまで知らせない
+	taskDistributor.EXPECT().DistributeTaskNotifyTransfer(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	taskDistributor.EXPECT().DistributeTaskSendPaymentRequestEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store, taskDistributor)
+
+	engine, err := fraud.NewEngine([]fraud.Rule{
+		{Name: "new_ip", Type: fraud.RuleLoginFromNewIP, Window: time.Minute, Action: fraud.ActionHold},
+	})
+	require.NoError(t, err)
+	server.fraud = engine
+
+	ctx := newContextWithBearerToken(t, server.maker, payer.Username, util.DepositorRole, time.Minute)
+	res, err := server.PayPaymentRequest(ctx, &pb.PayPaymentRequestRequest{
+		PaymentRequestId: request.ID,
+		FromAccountId:    fromAccount.ID,
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.GetTransfer())
+	require.Equal(t, util.PaymentRequestHeld, res.GetPaymentRequest().GetStatus())
+	require.Equal(t, int64(1), res.GetReview().GetId())
+}
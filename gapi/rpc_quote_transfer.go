@@ -0,0 +1,81 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) QuoteTransfer(ctx context.Context, req *pb.QuoteTransferRequest) (*pb.QuoteTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateQuoteTransferRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetAmount().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if authPayload.Role != util.BankerRole && fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", fromAccount.ID)
+	}
+
+	fee, err := server.quoteTransferFee(ctx, fromAccount.ID, req.GetAmount().GetUnits())
+
+	if err != nil {
+		return nil, err
+	}
+
+	amount := req.GetAmount().GetUnits()
+
+	rsp := &pb.QuoteTransferResponse{
+		Amount: convertMoney(amount, fromAccount.Currency),
+		Fee:    convertMoney(fee, fromAccount.Currency),
+		Total:  convertMoney(amount+fee, fromAccount.Currency),
+	}
+
+	return rsp, nil
+}
+
+// quoteTransferFee は TransferTx と同じ手数料表を使って手数料を計算する
+func (server *Server) quoteTransferFee(ctx context.Context, accountID int64, amount int64) (int64, error) {
+	schedule, err := server.store.GetFeeScheduleForAccount(ctx, accountID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return 0, nil
+		}
+
+		return 0, status.Errorf(codes.Internal, "cannot get fee schedule: %v", err)
+	}
+
+	return util.CalculateFee(amount, schedule.FlatFee, schedule.PercentFeeBps), nil
+}
+
+func validateQuoteTransferRequest(req *pb.QuoteTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAccountID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, filedViolation("from_account_id", err))
+	}
+
+	violations = append(violations, validateMoney("amount", req.GetAmount())...)
+
+	return violations
+}
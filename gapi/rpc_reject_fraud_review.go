@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) RejectFraudReview(ctx context.Context, req *pb.RejectFraudReviewRequest) (*pb.RejectFraudReviewResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRejectFraudReviewRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ReviewFraudTx(ctx, db.ReviewFraudTxParams{
+		ReviewID:   req.GetReviewId(),
+		ReviewedBy: authPayload.Username,
+		Approve:    false,
+	})
+
+	if err != nil {
+		return nil, fraudReviewError(req.GetReviewId(), err)
+	}
+
+	rsp := &pb.RejectFraudReviewResponse{
+		Review: convertFraudReview(result.Review),
+	}
+
+	return rsp, nil
+}
+
+func validateRejectFraudReviewRequest(req *pb.RejectFraudReviewRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateReviewID(req.GetReviewId()); err != nil {
+		violations = append(violations, filedViolation("review_id", err))
+	}
+
+	return violations
+}
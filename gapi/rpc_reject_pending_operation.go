@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func (server *Server) RejectPendingOperation(ctx context.Context, req *pb.RejectPendingOperationRequest) (*pb.RejectPendingOperationResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRejectPendingOperationRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	result, err := server.store.ReviewPendingOperationTx(ctx, db.ReviewPendingOperationTxParams{
+		OperationID: req.GetOperationId(),
+		ReviewedBy:  authPayload.Username,
+		Approve:     false,
+	})
+
+	if err != nil {
+		return nil, pendingOperationError(req.GetOperationId(), err)
+	}
+
+	rsp := &pb.RejectPendingOperationResponse{
+		Operation: convertPendingOperation(result.Operation),
+	}
+
+	return rsp, nil
+}
+
+func validateRejectPendingOperationRequest(req *pb.RejectPendingOperationRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateOperationID(req.GetOperationId()); err != nil {
+		violations = append(violations, filedViolation("operation_id", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RemoveBeneficiary(ctx context.Context, req *pb.RemoveBeneficiaryRequest) (*pb.RemoveBeneficiaryResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRemoveBeneficiaryRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	beneficiary, err := server.store.GetBeneficiary(ctx, req.GetBeneficiaryId())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "beneficiary [%d] not found", req.GetBeneficiaryId())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get beneficiary: %v", err)
+	}
+
+	if beneficiary.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "beneficiary [%d] doesn't belong to the authenticated user", beneficiary.ID)
+	}
+
+	err = server.store.DeleteBeneficiary(ctx, beneficiary.ID)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove beneficiary: %v", err)
+	}
+
+	return &pb.RemoveBeneficiaryResponse{}, nil
+}
+
+func validateRemoveBeneficiaryRequest(req *pb.RemoveBeneficiaryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateBeneficiaryID(req.GetBeneficiaryId()); err != nil {
+		violations = append(violations, filedViolation("beneficiary_id", err))
+	}
+
+	return violations
+}
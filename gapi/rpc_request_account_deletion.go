@@ -0,0 +1,78 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) RequestAccountDeletion(ctx context.Context, req *pb.RequestAccountDeletionRequest) (*pb.RequestAccountDeletionResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateRequestAccountDeletionRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get user: %v", err)
+	}
+
+	if user.DeletedAt.Valid {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	if err := server.verifyStepUp(user, req.CurrentPassword, req.StepUpToken); err != nil {
+		return nil, err
+	}
+
+	txResult, err := server.store.DeleteUserTx(ctx, db.DeleteUserTxParams{
+		Username: user.Username,
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrNonZeroBalance):
+			return nil, status.Errorf(codes.FailedPrecondition, "withdraw or transfer the remaining balance before deleting the account")
+		case errors.Is(err, db.ErrUserDeleted), errors.Is(err, db.ErrorRecordNotFound):
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to delete user: %v", err)
+	}
+
+	rsp := &pb.RequestAccountDeletionResponse{
+		DeletedAt: timestamppb.New(txResult.User.DeletedAt.Time),
+	}
+
+	return rsp, nil
+}
+
+func validateRequestAccountDeletionRequest(req *pb.RequestAccountDeletionRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.CurrentPassword != nil {
+		if err := validator.ValidateLoginPassword(req.GetCurrentPassword()); err != nil {
+			violations = append(violations, filedViolation("current_password", err))
+		}
+	}
+
+	return violations
+}
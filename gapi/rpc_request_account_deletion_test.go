@@ -0,0 +1,189 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestAccountDeletionAPI(t *testing.T) {
+	user, password := randomUser()
+	wrongPassword := util.RandomString(12)
+
+	deletedAt := time.Now().Truncate(time.Second)
+
+	deleted := user
+	deleted.FullName = db.DeletedUserFullName
+	deleted.Email = "deleted@deleted.invalid"
+	deleted.HashedPassword = ""
+	deleted.DeletedAt = pgtype.Timestamptz{Time: deletedAt, Valid: true}
+
+	testCases := []struct {
+		name          string
+		req           func(t *testing.T, tokenMaker token.Maker) *pb.RequestAccountDeletionRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: func(t *testing.T, tokenMaker token.Maker) *pb.RequestAccountDeletionRequest {
+				return &pb.RequestAccountDeletionRequest{CurrentPassword: &password}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					DeleteUserTx(gomock.Any(), gomock.Eq(db.DeleteUserTxParams{Username: user.Username})).
+					Times(1).
+					Return(db.DeleteUserTxResult{User: deleted}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				require.NoError(t, err)
+				require.WithinDuration(t, deletedAt, res.GetDeletedAt().AsTime(), time.Second)
+			},
+		},
+		{
+			name: "StepUpToken",
+			req: func(t *testing.T, tokenMaker token.Maker) *pb.RequestAccountDeletionRequest {
+				stepUpToken, _, err := tokenMaker.CreateToken(user.Username, util.StepUpRole, time.Minute)
+				require.NoError(t, err)
+
+				return &pb.RequestAccountDeletionRequest{StepUpToken: &stepUpToken}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					DeleteUserTx(gomock.Any(), gomock.Eq(db.DeleteUserTxParams{Username: user.Username})).
+					Times(1).
+					Return(db.DeleteUserTxResult{User: deleted}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "NonZeroBalance",
+			req: func(t *testing.T, tokenMaker token.Maker) *pb.RequestAccountDeletionRequest {
+				return &pb.RequestAccountDeletionRequest{CurrentPassword: &password}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					DeleteUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DeleteUserTxResult{}, db.ErrNonZeroBalance)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "WrongPassword",
+			req: func(t *testing.T, tokenMaker token.Maker) *pb.RequestAccountDeletionRequest {
+				return &pb.RequestAccountDeletionRequest{CurrentPassword: &wrongPassword}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().DeleteUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				requireStepUpFactors(t, err, "current_password")
+			},
+		},
+		{
+			name: "StepUpRequired",
+			req: func(t *testing.T, tokenMaker token.Maker) *pb.RequestAccountDeletionRequest {
+				return &pb.RequestAccountDeletionRequest{}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().DeleteUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				requireStepUpFactors(t, err, "current_password", "step_up_token")
+			},
+		},
+		{
+			name: "AlreadyDeleted",
+			req: func(t *testing.T, tokenMaker token.Maker) *pb.RequestAccountDeletionRequest {
+				return &pb.RequestAccountDeletionRequest{CurrentPassword: &password}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(deleted, nil)
+				store.EXPECT().DeleteUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: func(t *testing.T, tokenMaker token.Maker) *pb.RequestAccountDeletionRequest {
+				return &pb.RequestAccountDeletionRequest{CurrentPassword: &password}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.RequestAccountDeletionResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectActiveUser(store)
+
+			server := newTestServer(t, store, mockwk.NewMockTaskDistributor(ctrl))
+			res, err := server.RequestAccountDeletion(tc.buildContext(t, server.maker), tc.req(t, server.maker))
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
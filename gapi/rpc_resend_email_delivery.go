@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hibiken/asynq"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"github.com/shouta0715/simple-bank/worker"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ResendEmailDelivery(ctx context.Context, req *pb.ResendEmailDeliveryRequest) (*pb.ResendEmailDeliveryResponse, error) {
+	_, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateResendEmailDeliveryRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	delivery, err := server.store.GetEmailDelivery(ctx, req.GetId())
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "email delivery [%d] not found", req.GetId())
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get email delivery: %v", err)
+	}
+
+	taskPayload := &worker.PayloadResendEmail{
+		DeliveryID: delivery.ID,
+	}
+
+	err = server.taskDistributor.DistributeTaskResendEmail(ctx, taskPayload, asynq.MaxRetry(10), asynq.Queue(worker.QueueCritical))
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to distribute task to resend email: %v", err)
+	}
+
+	rsp := &pb.ResendEmailDeliveryResponse{
+		Delivery: convertEmailDelivery(delivery),
+	}
+
+	return rsp, nil
+}
+
+func validateResendEmailDeliveryRequest(req *pb.ResendEmailDeliveryRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateEmailDeliveryID(req.GetId()); err != nil {
+		violations = append(violations, filedViolation("id", err))
+	}
+
+	return violations
+}
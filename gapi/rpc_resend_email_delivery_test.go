@@ -0,0 +1,153 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/worker"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResendEmailDeliveryAPI(t *testing.T) {
+	user, _ := randomUser()
+	banker, _ := randomUser()
+	banker.Role = util.BankerRole
+
+	delivery := db.EmailDelivery{
+		ID:          int64(util.RandomInt(1, 1000)),
+		Template:    mail.TemplateVerifyEmail,
+		Recipient:   user.Email,
+		Subject:     "Welcome to Simple Bank",
+		ContentHtml: "<p>Hello</p>",
+		ContentText: "Hello",
+		MessageID:   "<1.abc@example.com>",
+		Status:      util.EmailDeliveryFailed,
+		Error:       "550 mailbox unavailable",
+		CreatedAt:   time.Now(),
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ResendEmailDeliveryRequest
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.ResendEmailDeliveryResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.ResendEmailDeliveryRequest{Id: delivery.ID},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmailDelivery(gomock.Any(), gomock.Eq(delivery.ID)).
+					Times(1).
+					Return(delivery, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskResendEmail(gomock.Any(), gomock.Eq(&worker.PayloadResendEmail{DeliveryID: delivery.ID}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResendEmailDeliveryResponse, err error) {
+				require.NoError(t, err)
+
+				got := res.GetDelivery()
+				require.Equal(t, delivery.ID, got.GetId())
+				require.Equal(t, delivery.Recipient, got.GetRecipient())
+				require.Equal(t, delivery.MessageID, got.GetMessageId())
+				require.Equal(t, delivery.Error, got.GetError())
+			},
+		},
+		{
+			name: "NotBanker",
+			req:  &pb.ResendEmailDeliveryRequest{Id: delivery.ID},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetEmailDelivery(gomock.Any(), gomock.Any()).Times(0)
+				taskDistributor.EXPECT().DistributeTaskResendEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResendEmailDeliveryResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "NotFound",
+			req:  &pb.ResendEmailDeliveryRequest{Id: delivery.ID},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetEmailDelivery(gomock.Any(), gomock.Eq(delivery.ID)).
+					Times(1).
+					Return(db.EmailDelivery{}, db.ErrorRecordNotFound)
+
+				taskDistributor.EXPECT().DistributeTaskResendEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResendEmailDeliveryResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "InvalidID",
+			req:  &pb.ResendEmailDeliveryRequest{Id: 0},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetEmailDelivery(gomock.Any(), gomock.Any()).Times(0)
+				taskDistributor.EXPECT().DistributeTaskResendEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResendEmailDeliveryResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, taskDistributor)
+
+			ctx := tc.buildContext(t, server.maker)
+			res, err := server.ResendEmailDelivery(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
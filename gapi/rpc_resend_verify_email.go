@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) ResendVerifyEmail(ctx context.Context, req *pb.ResendVerifyEmailRequest) (*pb.ResendVerifyEmailResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get user: %v", err)
+	}
+
+	if user.IsEmailVerified {
+		return nil, status.Errorf(codes.FailedPrecondition, "email is already verified")
+	}
+
+	interval := server.config.VerifyEmailResendInterval
+
+	txResult, err := server.store.ResendVerifyEmailTx(ctx, db.ResendVerifyEmailTxParams{
+		RequestVerifyEmailParams: db.RequestVerifyEmailParams{
+			Username:        user.Username,
+			RequestedBefore: time.Now().Add(-interval),
+		},
+		AfterRequest: func(user db.User) error {
+			return server.sendVerifyEmail(ctx, user.Username)
+		},
+	})
+
+	if err != nil {
+		// 前回の要求から間がない場合は記録を更新しない
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			retryDelay := time.Until(user.VerifyEmailRequestedAt.Time.Add(interval))
+
+			return nil, rateLimitError("verification email was sent recently, try again later", max(retryDelay, time.Second))
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to resend verify email: %v", err)
+	}
+
+	rsp := &pb.ResendVerifyEmailResponse{
+		NextResendTime: timestamppb.New(txResult.User.VerifyEmailRequestedAt.Time.Add(interval)),
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,156 @@
+package gapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/worker"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type eqResendVerifyEmailTxParamsMatcher struct {
+	username string
+	user     db.User
+}
+
+func (expected eqResendVerifyEmailTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.ResendVerifyEmailTxParams)
+
+	if !ok || actualArg.Username != expected.username {
+		return false
+	}
+
+	// 前回の要求から1分経っていれば送れる
+	if time.Since(actualArg.RequestedBefore) < time.Minute-time.Second {
+		return false
+	}
+
+	// ResendVerifyEmailTx は mock なので、タスクの登録をここで呼び出す
+	return actualArg.AfterRequest(expected.user) == nil
+}
+
+func (e eqResendVerifyEmailTxParamsMatcher) String() string {
+	return "matches username " + e.username
+}
+
+func TestResendVerifyEmailAPI(t *testing.T) {
+	user, _ := randomUser()
+
+	verified := user
+	verified.IsEmailVerified = true
+
+	requestedAt := time.Now().Add(-20 * time.Second)
+
+	requested := user
+	requested.VerifyEmailRequestedAt = pgtype.Timestamptz{Time: requestedAt, Valid: true}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.ResendVerifyEmailResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				now := user
+				now.VerifyEmailRequestedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+				store.EXPECT().
+					ResendVerifyEmailTx(gomock.Any(), eqResendVerifyEmailTxParamsMatcher{username: user.Username, user: now}).
+					Times(1).
+					Return(db.ResendVerifyEmailTxResult{User: now}, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Eq(&worker.PayloadSendVerifyEmail{Username: user.Username}), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResendVerifyEmailResponse, err error) {
+				require.NoError(t, err)
+				require.WithinDuration(t, time.Now().Add(time.Minute), res.GetNextResendTime().AsTime(), time.Second)
+			},
+		},
+		{
+			name: "AlreadyVerified",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(verified, nil)
+
+				store.EXPECT().ResendVerifyEmailTx(gomock.Any(), gomock.Any()).Times(0)
+				taskDistributor.EXPECT().DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResendVerifyEmailResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "TooManyRequests",
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(requested, nil)
+
+				store.EXPECT().
+					ResendVerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ResendVerifyEmailTxResult{}, db.ErrorRecordNotFound)
+
+				taskDistributor.EXPECT().DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ResendVerifyEmailResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+
+				require.Len(t, st.Details(), 1)
+				retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+				require.True(t, ok)
+				require.InDelta(t, 40*time.Second, retryInfo.GetRetryDelay().AsDuration(), float64(time.Second))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, taskDistributor)
+
+			ctx := newContextWithBearerToken(t, server.maker, user.Username, user.Role, time.Minute)
+			res, err := server.ResendVerifyEmail(ctx, &pb.ResendVerifyEmailRequest{})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
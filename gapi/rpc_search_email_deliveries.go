@@ -0,0 +1,114 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SearchEmailDeliveries(ctx context.Context, req *pb.SearchEmailDeliveriesRequest) (*pb.SearchEmailDeliveriesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSearchEmailDeliveriesRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scope, err := pageScope(authPayload.Username, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	arg := db.SearchEmailDeliveriesParams{
+		Recipient: pgtype.Text{
+			String: req.GetRecipient(),
+			Valid:  req.GetRecipient() != "",
+		},
+		Template: pgtype.Text{
+			String: req.GetTemplate(),
+			Valid:  req.GetTemplate() != "",
+		},
+		Status: pgtype.Text{
+			String: req.GetStatus(),
+			Valid:  req.GetStatus() != "",
+		},
+		StartTime: pgtype.Timestamptz{
+			Time:  req.GetStartTime().AsTime(),
+			Valid: req.StartTime != nil,
+		},
+		EndTime: pgtype.Timestamptz{
+			Time:  req.GetEndTime().AsTime(),
+			Valid: req.EndTime != nil,
+		},
+		// 次のページがあるかどうかを知るために1件多く取得する
+		Limit: pageSize + 1,
+	}
+
+	arg.CursorCreatedAt, arg.CursorID, err = server.pageCursor(scope, req.GetPageToken())
+
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := server.store.SearchEmailDeliveries(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search email deliveries: %v", err)
+	}
+
+	rsp := &pb.SearchEmailDeliveriesResponse{
+		Deliveries: make([]*pb.EmailDelivery, 0, len(deliveries)),
+	}
+
+	if len(deliveries) > int(pageSize) {
+		deliveries = deliveries[:pageSize]
+		last := deliveries[len(deliveries)-1]
+
+		rsp.NextPageToken, err = server.nextPageToken(scope, last.CreatedAt, last.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, delivery := range deliveries {
+		rsp.Deliveries = append(rsp.Deliveries, convertEmailDelivery(delivery))
+	}
+
+	return rsp, nil
+}
+
+func validateSearchEmailDeliveriesRequest(req *pb.SearchEmailDeliveriesRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateEmailDeliveryStatus(req.GetStatus()); err != nil {
+		violations = append(violations, filedViolation("status", err))
+	}
+
+	if req.StartTime != nil && req.EndTime != nil && !req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "end_time",
+			Description: "end time must be after start time",
+		})
+	}
+
+	if err := validator.ValidateListPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, filedViolation("page_size", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,156 @@
+package gapi
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) SearchTransactions(ctx context.Context, req *pb.SearchTransactionsRequest) (*pb.SearchTransactionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSearchTransactionsRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scope, err := pageScope(authPayload.Username, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.PageSize(req.GetPageSize())
+
+	arg := db.SearchTransactionsParams{
+		Owner: authPayload.Username,
+		Query: pgtype.Text{
+			String: req.GetQuery(),
+			Valid:  req.GetQuery() != "",
+		},
+		MinAmount: pgtype.Int8{
+			Int64: req.GetMinAmount(),
+			Valid: req.MinAmount != nil,
+		},
+		MaxAmount: pgtype.Int8{
+			Int64: req.GetMaxAmount(),
+			Valid: req.MaxAmount != nil,
+		},
+		StartTime: pgtype.Timestamptz{
+			Time:  req.GetStartTime().AsTime(),
+			Valid: req.StartTime != nil,
+		},
+		EndTime: pgtype.Timestamptz{
+			Time:  req.GetEndTime().AsTime(),
+			Valid: req.EndTime != nil,
+		},
+		Counterparty: pgtype.Text{
+			String: req.GetCounterparty(),
+			Valid:  req.GetCounterparty() != "",
+		},
+		Currency: pgtype.Text{
+			String: req.GetCurrency(),
+			Valid:  req.GetCurrency() != "",
+		},
+		// 次のページがあるかどうかを知るために1件多く取得する
+		Limit: pageSize + 1,
+	}
+
+	arg.CursorCreatedAt, arg.CursorID, err = server.pageCursor(scope, req.GetPageToken())
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := server.store.SearchTransactions(ctx, arg)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search transactions: %v", err)
+	}
+
+	rsp := &pb.SearchTransactionsResponse{
+		Entries: make([]*pb.Entry, 0, len(transactions)),
+	}
+
+	if len(transactions) > int(pageSize) {
+		transactions = transactions[:pageSize]
+		last := transactions[len(transactions)-1]
+
+		rsp.NextPageToken, err = server.nextPageToken(scope, last.CreatedAt, last.ID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, transaction := range transactions {
+		rsp.Entries = append(rsp.Entries, convertTransaction(transaction))
+	}
+
+	return rsp, nil
+}
+
+func validateSearchTransactionsRequest(req *pb.SearchTransactionsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateMemo(req.GetQuery()); err != nil {
+		violations = append(violations, filedViolation("query", err))
+	}
+
+	if req.MinAmount != nil {
+		if err := validator.ValidateAmount(req.GetMinAmount()); err != nil {
+			violations = append(violations, filedViolation("min_amount", err))
+		}
+	}
+
+	if req.MaxAmount != nil {
+		if err := validator.ValidateAmount(req.GetMaxAmount()); err != nil {
+			violations = append(violations, filedViolation("max_amount", err))
+		}
+	}
+
+	if req.MinAmount != nil && req.MaxAmount != nil && req.GetMinAmount() > req.GetMaxAmount() {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "max_amount",
+			Description: "max amount cannot be less than min amount",
+		})
+	}
+
+	if req.StartTime != nil && req.EndTime != nil && !req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "end_time",
+			Description: "end time must be after start time",
+		})
+	}
+
+	if req.GetCounterparty() != "" {
+		if err := validator.ValidateUsername(req.GetCounterparty()); err != nil {
+			violations = append(violations, filedViolation("counterparty", err))
+		}
+	}
+
+	if req.GetCurrency() != "" {
+		if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+			violations = append(violations, filedViolation("currency", err))
+		}
+	}
+
+	if err := validator.ValidateListPageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, filedViolation("page_size", err))
+	}
+
+	return violations
+}
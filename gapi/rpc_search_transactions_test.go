@@ -0,0 +1,190 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pagination"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSearchTransactionsAPI(t *testing.T) {
+	user, _ := randomUser()
+	account := randomAccount(user.Username, util.USD)
+
+	pageSize := int32(5)
+	// 次のページがあることを示すために1件多く返す
+	transactions := make([]db.SearchTransactionsRow, pageSize+1)
+
+	for i := range transactions {
+		transactions[i] = db.SearchTransactionsRow{
+			ID:                    int64(100 - i),
+			AccountID:             account.ID,
+			Amount:                -10,
+			TransferID:            pgtype.Int8{Int64: int64(200 - i), Valid: true},
+			CreatedAt:             time.Now().Add(-time.Duration(i) * time.Minute).UTC(),
+			Currency:              util.USD,
+			Memo:                  "rent",
+			Category:              util.CategoryRent,
+			CounterpartyAccountID: account.ID + 1,
+		}
+	}
+
+	pageTokens, err := pagination.NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	scope, err := pageScope(user.Username, &pb.SearchTransactionsRequest{Query: "rent", Currency: util.USD})
+	require.NoError(t, err)
+
+	last := transactions[pageSize-1]
+	nextPageToken, err := pageTokens.Encode(scope, pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	require.NoError(t, err)
+
+	minAmount := int64(100)
+	maxAmount := int64(10)
+
+	testCases := []struct {
+		name          string
+		req           *pb.SearchTransactionsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.SearchTransactionsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.SearchTransactionsRequest{
+				Query:    "rent",
+				Currency: util.USD,
+				PageSize: pageSize,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchTransactions(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SearchTransactionsParams) ([]db.SearchTransactionsRow, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, pgtype.Text{String: "rent", Valid: true}, arg.Query)
+						require.Equal(t, pgtype.Text{String: util.USD, Valid: true}, arg.Currency)
+						require.False(t, arg.MinAmount.Valid)
+						require.False(t, arg.Counterparty.Valid)
+						require.False(t, arg.CursorID.Valid)
+						require.Equal(t, pageSize+1, arg.Limit)
+
+						return transactions, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchTransactionsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetEntries(), int(pageSize))
+				require.Equal(t, "rent", res.GetEntries()[0].GetMemo())
+				require.Equal(t, account.ID+1, res.GetEntries()[0].GetCounterpartyAccountId())
+				require.Equal(t, nextPageToken, res.GetNextPageToken())
+			},
+		},
+		{
+			name: "NextPage",
+			req: &pb.SearchTransactionsRequest{
+				Query:     "rent",
+				Currency:  util.USD,
+				PageSize:  pageSize,
+				PageToken: nextPageToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SearchTransactions(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.SearchTransactionsParams) ([]db.SearchTransactionsRow, error) {
+						require.Equal(t, pgtype.Int8{Int64: last.ID, Valid: true}, arg.CursorID)
+						require.True(t, last.CreatedAt.Equal(arg.CursorCreatedAt.Time))
+
+						return transactions[:1], nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchTransactionsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetEntries(), 1)
+				require.Empty(t, res.GetNextPageToken())
+			},
+		},
+		{
+			name: "PageTokenForOtherQuery",
+			req: &pb.SearchTransactionsRequest{
+				Query:     "dinner",
+				Currency:  util.USD,
+				PageSize:  pageSize,
+				PageToken: nextPageToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchTransactions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchTransactionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InvalidPageToken",
+			req: &pb.SearchTransactionsRequest{
+				PageSize:  pageSize,
+				PageToken: "invalid",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchTransactions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchTransactionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "InvalidAmountRange",
+			req: &pb.SearchTransactionsRequest{
+				MinAmount: &minAmount,
+				MaxAmount: &maxAmount,
+				PageSize:  pageSize,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchTransactions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.SearchTransactionsResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+			server.pageTokens = pageTokens
+
+			ctx := newContextWithBearerToken(t, server.maker, user.Username, user.Role, time.Minute)
+			res, err := server.SearchTransactions(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) SetUserTransferLimit(ctx context.Context, req *pb.SetUserTransferLimitRequest) (*pb.SetUserTransferLimitResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateSetUserTransferLimitRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	limit, err := server.store.UpsertUserTransferLimit(ctx, db.UpsertUserTransferLimitParams{
+		Username:       req.GetUsername(),
+		Currency:       req.GetCurrency(),
+		PerTransaction: moneyToInt8(req.GetPerTransaction()),
+		DailyAmount:    moneyToInt8(req.GetDailyAmount()),
+		MonthlyAmount:  moneyToInt8(req.GetMonthlyAmount()),
+		DailyCount: pgtype.Int8{
+			Int64: req.GetDailyCount(),
+			Valid: req.DailyCount != nil,
+		},
+		UpdatedBy: authPayload.Username,
+	})
+
+	if err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			return nil, status.Errorf(codes.NotFound, "user [%s] not found", req.GetUsername())
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to set transfer limit: %v", err)
+	}
+
+	rsp := &pb.SetUserTransferLimitResponse{
+		Limit: convertUserTransferLimit(limit),
+	}
+
+	return rsp, nil
+}
+
+func moneyToInt8(money *pb.Money) pgtype.Int8 {
+	return pgtype.Int8{
+		Int64: money.GetUnits(),
+		Valid: money != nil,
+	}
+}
+
+func int8ToMoney(value pgtype.Int8, currency string) *pb.Money {
+	if !value.Valid {
+		return nil
+	}
+
+	return convertMoney(value.Int64, currency)
+}
+
+func convertUserTransferLimit(limit db.UserTransferLimit) *pb.UserTransferLimit {
+	rsp := &pb.UserTransferLimit{
+		Username:       limit.Username,
+		Currency:       limit.Currency,
+		PerTransaction: int8ToMoney(limit.PerTransaction, limit.Currency),
+		DailyAmount:    int8ToMoney(limit.DailyAmount, limit.Currency),
+		MonthlyAmount:  int8ToMoney(limit.MonthlyAmount, limit.Currency),
+		UpdatedBy:      limit.UpdatedBy,
+		UpdatedAt:      timestamppb.New(limit.UpdatedAt),
+	}
+
+	if limit.DailyCount.Valid {
+		rsp.DailyCount = &limit.DailyCount.Int64
+	}
+
+	return rsp
+}
+
+func validateSetUserTransferLimitRequest(req *pb.SetUserTransferLimitRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, filedViolation("username", err))
+	}
+
+	if err := validator.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, filedViolation("currency", err))
+	}
+
+	limits := []struct {
+		field string
+		money *pb.Money
+	}{
+		{field: "per_transaction", money: req.GetPerTransaction()},
+		{field: "daily_amount", money: req.GetDailyAmount()},
+		{field: "monthly_amount", money: req.GetMonthlyAmount()},
+	}
+
+	for _, limit := range limits {
+		if limit.money == nil {
+			continue
+		}
+
+		violations = append(violations, validateMoney(limit.field, limit.money)...)
+
+		if limit.money.GetCurrency() != req.GetCurrency() {
+			violations = append(violations, filedViolation(limit.field+".currency",
+				fmt.Errorf("currency must be %s", req.GetCurrency())))
+		}
+	}
+
+	if req.DailyCount != nil {
+		if err := validator.ValidateAmount(req.GetDailyCount()); err != nil {
+			violations = append(violations, filedViolation("daily_count", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,165 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetUserTransferLimitAPI(t *testing.T) {
+	user, _ := randomUser()
+	banker, _ := randomUser()
+	banker.Role = util.BankerRole
+
+	dailyCount := int64(3)
+
+	testCases := []struct {
+		name          string
+		req           *pb.SetUserTransferLimitRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.SetUserTransferLimitResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.SetUserTransferLimitRequest{
+				Username: user.Username,
+				Currency: util.USD,
+				DailyAmount: &pb.Money{
+					Currency: util.USD,
+					Units:    1000,
+				},
+				DailyCount: &dailyCount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertUserTransferLimitParams{
+					Username:    user.Username,
+					Currency:    util.USD,
+					DailyAmount: pgtype.Int8{Int64: 1000, Valid: true},
+					DailyCount:  pgtype.Int8{Int64: dailyCount, Valid: true},
+					UpdatedBy:   banker.Username,
+				}
+
+				store.EXPECT().
+					UpsertUserTransferLimit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.UserTransferLimit{
+						Username:    arg.Username,
+						Currency:    arg.Currency,
+						DailyAmount: arg.DailyAmount,
+						DailyCount:  arg.DailyCount,
+						UpdatedBy:   arg.UpdatedBy,
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserTransferLimitResponse, err error) {
+				require.NoError(t, err)
+
+				limit := res.GetLimit()
+				require.Equal(t, user.Username, limit.GetUsername())
+				require.Nil(t, limit.GetPerTransaction())
+				require.Equal(t, int64(1000), limit.GetDailyAmount().GetUnits())
+				require.Equal(t, dailyCount, limit.GetDailyCount())
+				require.Equal(t, banker.Username, limit.GetUpdatedBy())
+			},
+		},
+		{
+			name: "NotBanker",
+			req: &pb.SetUserTransferLimitRequest{
+				Username: user.Username,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserTransferLimitResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req: &pb.SetUserTransferLimitRequest{
+				Username: user.Username,
+				Currency: util.USD,
+				PerTransaction: &pb.Money{
+					Currency: util.JPY,
+					Units:    1000,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserTransferLimitResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
+		{
+			name: "UserNotFound",
+			req: &pb.SetUserTransferLimitRequest{
+				Username: user.Username,
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertUserTransferLimit(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserTransferLimit{}, &pgconn.PgError{Code: db.ForeignKeyViolation})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetUserTransferLimitResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.maker)
+			res, err := server.SetUserTransferLimit(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) StepUp(ctx context.Context, req *pb.StepUpRequest) (*pb.StepUpResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateStepUpRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Errorf(codes.Internal, "cannot get user: %v", err)
+	}
+
+	if err := util.CheckPassword(req.GetPassword(), user.HashedPassword); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid password: %v", err)
+	}
+
+	stepUpToken, payload, err := server.maker.CreateToken(user.Username, util.StepUpRole, server.config.StepUpTokenDuration)
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot create step-up token: %v", err)
+	}
+
+	rsp := &pb.StepUpResponse{
+		StepUpToken:          stepUpToken,
+		StepUpTokenExpiresAt: timestamppb.New(payload.ExpiresAt.Time),
+	}
+
+	return rsp, nil
+}
+
+func validateStepUpRequest(req *pb.StepUpRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateLoginPassword(req.GetPassword()); err != nil {
+		violations = append(violations, filedViolation("password", err))
+	}
+
+	return violations
+}
@@ -0,0 +1,120 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStepUpAPI(t *testing.T) {
+	user, password := randomUser()
+
+	testCases := []struct {
+		name          string
+		req           *pb.StepUpRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, server *Server, res *pb.StepUpResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.StepUpRequest{Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, res *pb.StepUpResponse, err error) {
+				require.NoError(t, err)
+
+				payload, err := server.maker.VerifyToken(res.GetStepUpToken())
+				require.NoError(t, err)
+				require.Equal(t, user.Username, payload.Username)
+				require.Equal(t, util.StepUpRole, payload.Role)
+				require.WithinDuration(t, time.Now().Add(5*time.Minute), res.GetStepUpTokenExpiresAt().AsTime(), time.Second)
+
+				// StepUp のトークンはアクセストークンとしては使えない
+				ctx := newContextWithBearerToken(t, server.maker, user.Username, util.StepUpRole, time.Minute)
+				_, err = server.authorizeUser(ctx, []string{util.BankerRole, util.DepositorRole})
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "WrongPassword",
+			req:  &pb.StepUpRequest{Password: util.RandomString(12)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, res *pb.StepUpResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "EmptyPassword",
+			req:  &pb.StepUpRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, server *Server, res *pb.StepUpResponse, err error) {
+				requireFieldViolation(t, err, "password")
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req:  &pb.StepUpRequest{Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, server *Server, res *pb.StepUpResponse, err error) {
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectActiveUser(store)
+
+			server := newTestServer(t, store, mockwk.NewMockTaskDistributor(ctrl))
+			res, err := server.StepUp(tc.buildContext(t, server.maker), tc.req)
+
+			tc.checkResponse(t, server, res, err)
+		})
+	}
+}
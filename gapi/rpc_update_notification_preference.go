@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateNotificationPreference(ctx context.Context, req *pb.UpdateNotificationPreferenceRequest) (*pb.UpdateNotificationPreferenceResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{
+		util.BankerRole, util.DepositorRole,
+	})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateNotificationPreferenceRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	preference, err := server.store.UpsertNotificationPreference(ctx, db.UpsertNotificationPreferenceParams{
+		Username: authPayload.Username,
+		Event:    req.GetEvent(),
+		Email:    req.GetEmail(),
+		Sms:      req.GetSms(),
+		InApp:    req.GetInApp(),
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update notification preference: %v", err)
+	}
+
+	rsp := &pb.UpdateNotificationPreferenceResponse{
+		Preference: convertNotificationPreference(preference),
+	}
+
+	return rsp, nil
+}
+
+func validateUpdateNotificationPreferenceRequest(req *pb.UpdateNotificationPreferenceRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateNotificationEvent(req.GetEvent()); err != nil {
+		violations = append(violations, filedViolation("event", err))
+	}
+
+	return violations
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
 	"github.com/shouta0715/simple-bank/pb"
 	"github.com/shouta0715/simple-bank/util"
@@ -13,6 +14,7 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
@@ -31,24 +33,58 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, invalidArgumentError(violation)
 	}
 
+	expectedVersion, err := parseETag(requestETag(ctx, req.GetEtag()))
+
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{filedViolation("etag", err)})
+	}
+
 	if authPayload.Role != util.BankerRole && authPayload.Username != req.GetUsername() {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's data")
 	}
 
-	arg := db.UpdateUserParams{
-		Username: authPayload.Username,
-		FullName: pgtype.Text{
-			String: req.GetFullName(),
-			Valid:  req.FullName != nil,
-		},
-		Email: pgtype.Text{
-			String: req.GetEmail(),
-			Valid:  req.Email != nil,
+	fields := updateUserFields(req)
+
+	if fields["password"] || fields["email"] {
+		user, err := server.store.GetUser(ctx, authPayload.Username)
+
+		if err != nil {
+			if errors.Is(err, db.ErrorRecordNotFound) {
+				return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+			}
+
+			return nil, status.Errorf(codes.Internal, "cannot get user: %v", err)
+		}
+
+		if err := server.verifyStepUp(user, req.CurrentPassword, req.StepUpToken); err != nil {
+			return nil, err
+		}
+	}
+
+	locale := req.GetLocale()
+
+	// マスクで値のない locale を指定した場合は既定の言語に戻す
+	if fields["locale"] && locale == "" {
+		locale = util.DefaultLocale
+	}
+
+	arg := db.UpdateUserTxParams{
+		UpdateUserParams: db.UpdateUserParams{
+			Username:    authPayload.Username,
+			FullName:    updateText(req.GetFullName(), fields["full_name"]),
+			Email:       updateText(req.GetEmail(), fields["email"]),
+			PhoneNumber: updateText(req.GetPhoneNumber(), fields["phone_number"]),
+			Locale:      updateText(locale, fields["locale"]),
 		},
+		ExpectedVersion: expectedVersion,
+		// メールアドレスが変わった場合は新しいアドレスを確認し直し、古いアドレスに知らせる
+		AfterEmailChange: server.notifyEmailChanged(ctx),
+		// パスワードが変わった場合はほかのセッションを使えなくし、本人に知らせる
+		AfterPasswordChange: server.notifyPasswordChanged(ctx),
 	}
 
-	if req.Password != nil {
-		hashedPassword, err := util.HashPassword(req.GetPassword())
+	if fields["password"] {
+		hashedPassword, err := util.HashPasswordWithParams(req.GetPassword(), server.config.PasswordParams())
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "error hashing password: %v", err)
 		}
@@ -64,23 +100,62 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 
 	}
 
-	user, err := server.store.UpdateUser(ctx, arg)
+	txResult, err := server.store.UpdateUserTx(ctx, arg)
 
 	if err != nil {
-		if errors.Is(err, db.ErrorRecordNotFound) {
+		if errors.Is(err, db.ErrorRecordNotFound) || errors.Is(err, db.ErrUserDeleted) {
 			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
 		}
 
+		if errors.Is(err, db.ErrVersionMismatch) {
+			return nil, status.Errorf(codes.FailedPrecondition, "user has been modified, read it again to get the latest etag")
+		}
+
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, status.Errorf(codes.AlreadyExists, "email is already in use")
+		}
+
 		return nil, status.Errorf(codes.Internal, "error updating user: %v", err)
 	}
 
 	rsp := &pb.UpdateUserResponse{
-		User: convertUser(user),
+		User: convertUser(txResult.User),
+	}
+
+	// すべてのセッションを使えなくしたので、呼び出したクライアントには新しいセッションを返す
+	// 作れなくてもパスワードは変わっているので、エラーにはせずにログインし直してもらう
+	if fields["password"] {
+		tokens, err := server.createSession(ctx, txResult.User)
+
+		if err != nil {
+			log.Error().Err(err).Str("username", txResult.User.Username).Msg("cannot create session after password change")
+			return rsp, nil
+		}
+
+		rsp.SessionId = tokens.session.ID
+		rsp.AccessToken = tokens.accessToken
+		rsp.AccessTokenExpiresAt = timestamppb.New(tokens.accessPayload.ExpiresAt.Time)
+		rsp.RefreshToken = tokens.refreshToken
+		rsp.RefreshTokenExpiresAt = timestamppb.New(tokens.refreshPayload.ExpiresAt.Time)
 	}
 
 	return rsp, nil
 }
 
+// UpdateUser の update_mask に指定できるフィールド
+var updateUserPaths = []string{"full_name", "email", "password", "phone_number", "locale"}
+
+// updateUserFields は UpdateUser で更新するフィールドを返す
+func updateUserFields(req *pb.UpdateUserRequest) map[string]bool {
+	return updateFields(req.GetUpdateMask(), map[string]bool{
+		"full_name":    req.FullName != nil,
+		"email":        req.Email != nil,
+		"password":     req.Password != nil,
+		"phone_number": req.PhoneNumber != nil,
+		"locale":       req.Locale != nil,
+	})
+}
+
 func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
 
 	if validator.ValidateUsername(req.GetUsername()) != nil {
@@ -90,7 +165,15 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		})
 	}
 
-	if req.Password != nil {
+	if err := validateUpdateMask(req.GetUpdateMask(), updateUserPaths); err != nil {
+		violations = append(violations, filedViolation("update_mask", err))
+		return violations
+	}
+
+	// マスクで指定したフィールドは値がなくても確認するので、消せないフィールドは空にできない
+	fields := updateUserFields(req)
+
+	if fields["password"] {
 		if validator.ValidatePassword(req.GetPassword()) != nil {
 			violations = append(violations, &errdetails.BadRequest_FieldViolation{
 				Field:       "password",
@@ -99,7 +182,13 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		}
 	}
 
-	if req.Email != nil {
+	if req.CurrentPassword != nil {
+		if err := validator.ValidateLoginPassword(req.GetCurrentPassword()); err != nil {
+			violations = append(violations, filedViolation("current_password", err))
+		}
+	}
+
+	if fields["email"] {
 		if validator.ValidateEmail(req.GetEmail()) != nil {
 			violations = append(violations, &errdetails.BadRequest_FieldViolation{
 				Field:       "email",
@@ -108,7 +197,20 @@ func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdeta
 		}
 	}
 
-	if req.FullName != nil {
+	if fields["phone_number"] {
+		if err := validator.ValidatePhoneNumber(req.GetPhoneNumber()); err != nil {
+			violations = append(violations, filedViolation("phone_number", err))
+		}
+	}
+
+	// 空の locale は既定の言語に戻す
+	if fields["locale"] && req.GetLocale() != "" {
+		if err := validator.ValidateLocale(req.GetLocale()); err != nil {
+			violations = append(violations, filedViolation("locale", err))
+		}
+	}
+
+	if fields["full_name"] {
 		if validator.ValidateFullName(req.GetFullName()) != nil {
 			violations = append(violations, &errdetails.BadRequest_FieldViolation{
 				Field:       "full_name",
@@ -3,42 +3,114 @@ package gapi
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgtype"
 	mockdb "github.com/shouta0715/simple-bank/db/mock"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
 	"github.com/shouta0715/simple-bank/pb"
 	"github.com/shouta0715/simple-bank/token"
 	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/worker"
+	mockwk "github.com/shouta0715/simple-bank/worker/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+type eqUpdateUserTxParamsMatcher struct {
+	arg      db.UpdateUserParams
+	user     db.User
+	oldEmail string
+	// 空でない場合は、このパスワードのハッシュに変えることを確かめる
+	password string
+	version  int64
+}
+
+func (expected eqUpdateUserTxParamsMatcher) Matches(x interface{}) bool {
+	actualArg, ok := x.(db.UpdateUserTxParams)
+
+	if !ok || actualArg.ExpectedVersion != expected.version {
+		return false
+	}
+
+	arg := actualArg.UpdateUserParams
+
+	if expected.password != "" {
+		if !arg.HashedPassword.Valid || util.CheckPassword(expected.password, arg.HashedPassword.String) != nil {
+			return false
+		}
+
+		if !arg.PasswordChangedAt.Valid {
+			return false
+		}
+
+		arg.HashedPassword = pgtype.Text{}
+		arg.PasswordChangedAt = pgtype.Timestamptz{}
+	}
+
+	if !reflect.DeepEqual(expected.arg, arg) {
+		return false
+	}
+
+	// UpdateUserTx は mock なので、パスワードやメールアドレスが変わったときの処理をここで呼び出す
+	if expected.password != "" && actualArg.AfterPasswordChange(expected.user) != nil {
+		return false
+	}
+
+	if expected.oldEmail != "" && actualArg.AfterEmailChange(expected.user, expected.oldEmail) != nil {
+		return false
+	}
+
+	return true
+}
+
+func (e eqUpdateUserTxParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v, old email %v and version %d", e.arg, e.oldEmail, e.version)
+}
+
+func EqUpdateUserTxParams(arg db.UpdateUserParams, user db.User, oldEmail string) gomock.Matcher {
+	return eqUpdateUserTxParamsMatcher{arg: arg, user: user, oldEmail: oldEmail}
+}
+
 func TestUpdateUserAPI(t *testing.T) {
-	user, _ := randomUser()
+	user, password := randomUser()
+	wrongPassword := util.RandomString(12)
 	newName := util.RandomOwner()
 	newEmail := util.RandomEmail()
 	invalidEmail := "invalid-email"
+	invalidLocale := "fr"
+	empty := ""
 
 	testCases := []struct {
 		name          string
 		req           *pb.UpdateUserRequest
-		buildStubs    func(store *mockdb.MockStore)
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
 		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
 		checkResponse func(t *testing.T, res *pb.UpdateUserResponse, err error)
 	}{
 		{
 			name: "OK",
 			req: &pb.UpdateUserRequest{
-				Username: user.Username,
-				FullName: &newName,
-				Email:    &newEmail,
+				Username:        user.Username,
+				FullName:        &newName,
+				Email:           &newEmail,
+				CurrentPassword: &password,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
 				arg := db.UpdateUserParams{
 					Username: user.Username,
 					FullName: pgtype.Text{
@@ -62,9 +134,24 @@ func TestUpdateUserAPI(t *testing.T) {
 				}
 
 				store.EXPECT().
-					UpdateUser(gomock.Any(), gomock.Eq(arg)).
+					UpdateUserTx(gomock.Any(), EqUpdateUserTxParams(arg, updatedUser, user.Email)).
 					Times(1).
-					Return(updatedUser, nil)
+					Return(db.UpdateUserTxResult{User: updatedUser, OldEmail: user.Email}, nil)
+
+				// 新しいアドレスを確認し直し、古いアドレスに知らせる
+				taskDistributor.EXPECT().
+					DistributeTaskSendVerifyEmail(gomock.Any(), gomock.Eq(&worker.PayloadSendVerifyEmail{Username: user.Username}), gomock.Any()).
+					Times(1).
+					Return(nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendEmailChangedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, payload *worker.PayloadSendEmailChangedEmail, opts ...asynq.Option) error {
+						require.Equal(t, user.Username, payload.Username)
+						require.Equal(t, user.Email, payload.OldEmail)
+						return nil
+					})
 
 			},
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
@@ -83,12 +170,14 @@ func TestUpdateUserAPI(t *testing.T) {
 		{
 			name: "InternalError",
 			req: &pb.UpdateUserRequest{
-				Username: user.Username,
-				FullName: &newName,
-				Email:    &newEmail,
+				Username:        user.Username,
+				FullName:        &newName,
+				Email:           &newEmail,
+				CurrentPassword: &password,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrConnDone)
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.UpdateUserTxResult{}, sql.ErrConnDone)
 			},
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
 				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
@@ -106,12 +195,14 @@ func TestUpdateUserAPI(t *testing.T) {
 		{
 			name: "UserNotFound",
 			req: &pb.UpdateUserRequest{
-				Username: user.Username,
-				FullName: &newName,
-				Email:    &newEmail,
+				Username:        user.Username,
+				FullName:        &newName,
+				Email:           &newEmail,
+				CurrentPassword: &password,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, db.ErrorRecordNotFound)
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.UpdateUserTxResult{}, db.ErrorRecordNotFound)
 
 			},
 			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
@@ -127,6 +218,275 @@ func TestUpdateUserAPI(t *testing.T) {
 				require.Equal(t, codes.NotFound, st.Code())
 			},
 		},
+		{
+			name: "DeletedUser",
+			req: &pb.UpdateUserRequest{
+				Username:        user.Username,
+				FullName:        &newName,
+				CurrentPassword: &password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				// 削除したユーザーのトークンは期限が切れる前でも使えない
+				store.EXPECT().IsUserDeleted(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(true, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+		{
+			name: "UserDeletedInTx",
+			req: &pb.UpdateUserRequest{
+				Username:        user.Username,
+				FullName:        &newName,
+				CurrentPassword: &password,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				// トークンを確かめた後に削除された場合
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.UpdateUserTxResult{}, db.ErrUserDeleted)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.NotFound, st.Code())
+			},
+		},
+		{
+			name: "StepUpRequired",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				Email:    &newEmail,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Nil(t, res)
+				requireStepUpFactors(t, err, "current_password", "step_up_token")
+			},
+		},
+		{
+			name: "WrongCurrentPassword",
+			req: &pb.UpdateUserRequest{
+				Username:        user.Username,
+				Email:           &newEmail,
+				CurrentPassword: &wrongPassword,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Nil(t, res)
+				requireStepUpFactors(t, err, "current_password")
+			},
+		},
+		{
+			// 名前だけを変える場合はもう一度認証しなくてよい
+			name: "NoStepUpForFullName",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.UpdateUserTxResult{User: user}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.GetRefreshToken())
+			},
+		},
+		{
+			// マスクで指定したフィールドは値がなければ消す
+			name: "UpdateMaskClearsPhoneNumber",
+			req: &pb.UpdateUserRequest{
+				Username:   user.Username,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"phone_number", "locale"}},
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				arg := db.UpdateUserParams{
+					Username:    user.Username,
+					PhoneNumber: pgtype.Text{String: "", Valid: true},
+					Locale:      pgtype.Text{String: util.DefaultLocale, Valid: true},
+				}
+
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), EqUpdateUserTxParams(arg, user, "")).
+					Times(1).
+					Return(db.UpdateUserTxResult{User: user}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			// マスクにないフィールドは値があっても更新しない
+			name: "UpdateMaskIgnoresOtherFields",
+			req: &pb.UpdateUserRequest{
+				Username:   user.Username,
+				FullName:   &newName,
+				Email:      &newEmail,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"full_name"}},
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				arg := db.UpdateUserParams{
+					Username: user.Username,
+					FullName: pgtype.Text{String: newName, Valid: true},
+				}
+
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), EqUpdateUserTxParams(arg, user, "")).
+					Times(1).
+					Return(db.UpdateUserTxResult{User: user}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "UpdateMaskUnknownField",
+			req: &pb.UpdateUserRequest{
+				Username:   user.Username,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"role"}},
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireFieldViolation(t, err, "update_mask")
+			},
+		},
+		{
+			// メールアドレスは消せない
+			name: "UpdateMaskEmptyEmail",
+			req: &pb.UpdateUserRequest{
+				Username:        user.Username,
+				Email:           &empty,
+				CurrentPassword: &password,
+				UpdateMask:      &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireFieldViolation(t, err, "email")
+			},
+		},
+		{
+			name: "ETagMismatch",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+				Etag:     `"3"`,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				arg := db.UpdateUserParams{
+					Username: user.Username,
+					FullName: pgtype.Text{String: newName, Valid: true},
+				}
+
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), eqUpdateUserTxParamsMatcher{arg: arg, user: user, version: 3}).
+					Times(1).
+					Return(db.UpdateUserTxResult{}, db.ErrVersionMismatch)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Nil(t, res)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "IfMatchHeader",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				arg := db.UpdateUserParams{
+					Username: user.Username,
+					FullName: pgtype.Text{String: newName, Valid: true},
+				}
+
+				updated := user
+				updated.Version = 6
+
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), eqUpdateUserTxParamsMatcher{arg: arg, user: user, version: 5}).
+					Times(1).
+					Return(db.UpdateUserTxResult{User: updated}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				ctx := newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+				md, _ := metadata.FromIncomingContext(ctx)
+				md = metadata.Join(md, metadata.Pairs(grpcGatewayIfMatchHeader, `"5"`))
+				return metadata.NewIncomingContext(ctx, md)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, `"6"`, res.GetUser().GetEtag())
+			},
+		},
+		{
+			name: "InvalidETag",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				FullName: &newName,
+				Etag:     `W/"3"`,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireFieldViolation(t, err, "etag")
+			},
+		},
 		{
 			name: "InvalidUsername",
 			req: &pb.UpdateUserRequest{
@@ -134,8 +494,8 @@ func TestUpdateUserAPI(t *testing.T) {
 				FullName: &newName,
 				Email:    &newEmail,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
 
 			},
 
@@ -159,8 +519,8 @@ func TestUpdateUserAPI(t *testing.T) {
 				FullName: &newName,
 				Email:    &invalidEmail,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
 
 			},
 
@@ -177,6 +537,27 @@ func TestUpdateUserAPI(t *testing.T) {
 				require.Equal(t, codes.InvalidArgument, st.Code())
 			},
 		},
+		{
+			name: "InvalidLocale",
+			req: &pb.UpdateUserRequest{
+				Username: user.Username,
+				Locale:   &invalidLocale,
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+			},
+		},
 		{
 			name: "ExpiredToken",
 			req: &pb.UpdateUserRequest{
@@ -184,8 +565,8 @@ func TestUpdateUserAPI(t *testing.T) {
 				FullName: &newName,
 				Email:    &newEmail,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
 
 			},
 
@@ -209,8 +590,8 @@ func TestUpdateUserAPI(t *testing.T) {
 				FullName: &newName,
 				Email:    &newEmail,
 			},
-			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Times(0)
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
 
 			},
 
@@ -237,8 +618,14 @@ func TestUpdateUserAPI(t *testing.T) {
 
 			store := mockdb.NewMockStore(storeCtrl)
 
-			tc.buildStubs(store)
-			server := newTestServer(t, store, nil)
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, taskDistributor)
 
 			ctx := tc.buildContext(t, server.maker)
 			res, err := server.UpdateUser(ctx, tc.req)
@@ -248,3 +635,158 @@ func TestUpdateUserAPI(t *testing.T) {
 		})
 	}
 }
+
+// StepUp のトークンがあれば今のパスワードなしでパスワードを変えられる
+func TestUpdateUserChangePassword(t *testing.T) {
+	user, _ := randomUser()
+	newPassword := util.RandomString(12)
+
+	other, _ := randomUser()
+
+	testCases := []struct {
+		name          string
+		buildStepUp   func(t *testing.T, tokenMaker token.Maker) string
+		buildStubs    func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor)
+		checkResponse func(t *testing.T, res *pb.UpdateUserResponse, err error)
+	}{
+		{
+			name: "OK",
+			buildStepUp: func(t *testing.T, tokenMaker token.Maker) string {
+				stepUpToken, _, err := tokenMaker.CreateToken(user.Username, util.StepUpRole, time.Minute)
+				require.NoError(t, err)
+				return stepUpToken
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+
+				arg := db.UpdateUserParams{Username: user.Username}
+				matcher := eqUpdateUserTxParamsMatcher{arg: arg, user: user, password: newPassword}
+
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), matcher).
+					Times(1).
+					Return(db.UpdateUserTxResult{User: user}, nil)
+
+				taskDistributor.EXPECT().
+					DistributeTaskSendPasswordChangedEmail(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, payload *worker.PayloadSendPasswordChangedEmail, opts ...asynq.Option) error {
+						require.Equal(t, user.Username, payload.Username)
+						return nil
+					})
+
+				// ほかのセッションは使えなくなるので、新しいセッションを作る
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+						require.Equal(t, user.Username, arg.Username)
+						return db.Session{ID: arg.ID, Username: arg.Username}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, res.GetSessionId())
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEmpty(t, res.GetRefreshToken())
+			},
+		},
+		{
+			name: "OtherUsersStepUpToken",
+			buildStepUp: func(t *testing.T, tokenMaker token.Maker) string {
+				stepUpToken, _, err := tokenMaker.CreateToken(other.Username, util.StepUpRole, time.Minute)
+				require.NoError(t, err)
+				return stepUpToken
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireStepUpFactors(t, err, "step_up_token")
+			},
+		},
+		{
+			// アクセストークンは StepUp のトークンの代わりにならない
+			name: "AccessTokenAsStepUpToken",
+			buildStepUp: func(t *testing.T, tokenMaker token.Maker) string {
+				accessToken, _, err := tokenMaker.CreateToken(user.Username, user.Role, time.Minute)
+				require.NoError(t, err)
+				return accessToken
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireStepUpFactors(t, err, "step_up_token")
+			},
+		},
+		{
+			name: "ExpiredStepUpToken",
+			buildStepUp: func(t *testing.T, tokenMaker token.Maker) string {
+				stepUpToken, _, err := tokenMaker.CreateToken(user.Username, util.StepUpRole, -time.Minute)
+				require.NoError(t, err)
+				return stepUpToken
+			},
+			buildStubs: func(store *mockdb.MockStore, taskDistributor *mockwk.MockTaskDistributor) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateUserResponse, err error) {
+				requireStepUpFactors(t, err, "step_up_token")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			// 別の controller にしないと、matcher の中でタスクを登録するときに止まる
+			taskCtrl := gomock.NewController(t)
+			defer taskCtrl.Finish()
+			taskDistributor := mockwk.NewMockTaskDistributor(taskCtrl)
+
+			tc.buildStubs(store, taskDistributor)
+
+			expectActiveUser(store)
+
+			server := newTestServer(t, store, taskDistributor)
+			ctx := newContextWithBearerToken(t, server.maker, user.Username, user.Role, time.Minute)
+
+			stepUpToken := tc.buildStepUp(t, server.maker)
+			res, err := server.UpdateUser(ctx, &pb.UpdateUserRequest{
+				Username:    user.Username,
+				Password:    &newPassword,
+				StepUpToken: &stepUpToken,
+			})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func requireStepUpFactors(t *testing.T, err error, factors ...string) {
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.Unauthenticated, st.Code())
+
+	var subjects []string
+
+	for _, detail := range st.Details() {
+		if failure, ok := detail.(*errdetails.PreconditionFailure); ok {
+			for _, violation := range failure.GetViolations() {
+				subjects = append(subjects, violation.GetSubject())
+			}
+		}
+	}
+
+	require.Equal(t, factors, subjects)
+}
@@ -2,8 +2,11 @@ package gapi
 
 import (
 	"context"
+	"errors"
+	"strconv"
 
 	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/link"
 	"github.com/shouta0715/simple-bank/pb"
 	"github.com/shouta0715/simple-bank/validator"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -18,12 +21,28 @@ func (server *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailReques
 		return nil, invalidArgumentError(violations)
 	}
 
+	// 書き換えられたリンクや期限が切れたリンクはデータベースを見る前に弾く
+	if violations := server.validateVerifyEmailLink(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
 	txResult, err := server.store.VerifyEmailTx(ctx, db.VerifyEmailTxParams{
 		EmailId:    req.GetEmailId(),
 		SecretCode: req.GetSecretCode(),
 	})
 
 	if err != nil {
+		// 確認メールがない場合も、コードが間違っている場合と同じように返す
+		if errors.Is(err, db.ErrorRecordNotFound) || errors.Is(err, db.ErrInvalidSecretCode) {
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+				filedViolation("secret_code", db.ErrInvalidSecretCode),
+			})
+		}
+
+		if errors.Is(err, db.ErrTooManyAttempts) {
+			return nil, status.Errorf(codes.ResourceExhausted, "too many wrong secret codes, request a new verification email")
+		}
+
 		return nil, status.Errorf(codes.Internal, "cannot verify email")
 	}
 
@@ -46,3 +65,19 @@ func validateVerifyEmailRequest(req *pb.VerifyEmailRequest) (violations []*errde
 
 	return violations
 }
+
+func (server *Server) validateVerifyEmailLink(req *pb.VerifyEmailRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	params := link.VerifyEmailParams(req.GetEmailId(), req.GetSecretCode())
+	params.Set(link.ExpiresParam, strconv.FormatInt(req.GetExpires(), 10))
+	params.Set(link.SignatureParam, req.GetSignature())
+
+	err := server.links.Verify(link.VerifyEmailPath, params)
+
+	if errors.Is(err, link.ErrExpiredLink) {
+		violations = append(violations, filedViolation("expires", err))
+	} else if err != nil {
+		violations = append(violations, filedViolation("signature", err))
+	}
+
+	return violations
+}
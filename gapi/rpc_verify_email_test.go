@@ -0,0 +1,203 @@
+package gapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/link"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// signedVerifyEmailQuery はメールに載せるリンクと同じクエリパラメーターを返す
+func signedVerifyEmailQuery(t *testing.T, server *Server, emailID int64, secretCode string) url.Values {
+	u, err := url.Parse(server.links.SignedURL(link.VerifyEmailPath, link.VerifyEmailParams(emailID, secretCode)))
+	require.NoError(t, err)
+
+	return u.Query()
+}
+
+func TestVerifyEmailAPI(t *testing.T) {
+	user, _ := randomUser()
+	emailID := int64(util.RandomInt(1, 1000))
+	secretCode := util.RandomString(32)
+
+	testCases := []struct {
+		name          string
+		modify        func(query url.Values)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.VerifyEmailResponse, err error)
+	}{
+		{
+			name:   "OK",
+			modify: func(query url.Values) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				verified := user
+				verified.IsEmailVerified = true
+
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Eq(db.VerifyEmailTxParams{EmailId: emailID, SecretCode: secretCode})).
+					Times(1).
+					Return(db.VerifyEmailTxResult{User: verified}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyEmailResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, res.GetIsVerified())
+			},
+		},
+		{
+			name:   "WrongSecretCode",
+			modify: func(query url.Values) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.VerifyEmailTxResult{}, db.ErrInvalidSecretCode)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyEmailResponse, err error) {
+				requireFieldViolation(t, err, "secret_code")
+			},
+		},
+		{
+			name:   "TooManyAttempts",
+			modify: func(query url.Values) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.VerifyEmailTxResult{}, db.ErrTooManyAttempts)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyEmailResponse, err error) {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.ResourceExhausted, st.Code())
+			},
+		},
+		{
+			name:   "TamperedEmailID",
+			modify: func(query url.Values) { query.Set("email_id", strconv.FormatInt(emailID+1, 10)) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyEmailResponse, err error) {
+				requireFieldViolation(t, err, "signature")
+			},
+		},
+		{
+			name:   "Expired",
+			modify: func(query url.Values) { query.Set(link.ExpiresParam, "1") },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyEmailResponse, err error) {
+				// 期限を書き換えると署名も合わなくなる
+				requireFieldViolation(t, err, "signature")
+			},
+		},
+		{
+			name:   "NoSignature",
+			modify: func(query url.Values) { query.Del(link.SignatureParam) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.VerifyEmailResponse, err error) {
+				requireFieldViolation(t, err, "signature")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+			server := newTestServer(t, store, nil)
+
+			query := signedVerifyEmailQuery(t, server, emailID, secretCode)
+			tc.modify(query)
+
+			id, _ := strconv.ParseInt(query.Get("email_id"), 10, 64)
+			expires, _ := strconv.ParseInt(query.Get(link.ExpiresParam), 10, 64)
+
+			res, err := server.VerifyEmail(context.Background(), &pb.VerifyEmailRequest{
+				EmailId:    id,
+				SecretCode: query.Get("secret_code"),
+				Expires:    expires,
+				Signature:  query.Get(link.SignatureParam),
+			})
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestVerifyEmailRedirect(t *testing.T) {
+	successURL := "https://app.example.com/email-verified"
+	failureURL := "https://app.example.com/email-verification-failed"
+
+	storeCtrl := gomock.NewController(t)
+	defer storeCtrl.Finish()
+	store := mockdb.NewMockStore(storeCtrl)
+
+	user, _ := randomUser()
+	user.IsEmailVerified = true
+
+	store.EXPECT().
+		VerifyEmailTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.VerifyEmailTxResult{User: user}, nil)
+
+	server := newTestServer(t, store, nil)
+
+	mux := runtime.NewServeMux(VerifyEmailRedirect(successURL, failureURL)...)
+	require.NoError(t, pb.RegisterSimpleBankHandlerServer(context.Background(), mux, server))
+
+	query := signedVerifyEmailQuery(t, server, 1, util.RandomString(32))
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, link.VerifyEmailPath+"?"+query.Encode(), nil))
+	require.Equal(t, http.StatusFound, recorder.Code)
+	require.Equal(t, successURL, recorder.Header().Get("Location"))
+
+	query.Set("email_id", "2")
+
+	recorder = httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, link.VerifyEmailPath+"?"+query.Encode(), nil))
+	require.Equal(t, http.StatusFound, recorder.Code)
+	require.Equal(t, failureURL, recorder.Header().Get("Location"))
+}
+
+func requireFieldViolation(t *testing.T, err error, field string) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				if violation.GetField() == field {
+					return
+				}
+			}
+		}
+	}
+
+	t.Fatalf("no field violation for %s in %v", field, st.Details())
+}
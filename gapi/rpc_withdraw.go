@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) Withdraw(ctx context.Context, req *pb.WithdrawRequest) (*pb.WithdrawResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, []string{util.BankerRole})
+
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateWithdrawRequest(req)
+
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validAccount(ctx, req.GetAccountId(), req.GetAmount().GetCurrency())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if util.IsSystemAccountOwner(account.Owner) {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot withdraw from system account [%d]", account.ID)
+	}
+
+	if server.requiresApproval(req.GetAmount().GetUnits(), account.Currency) {
+		operation, err := server.requestOperation(ctx, operationRequest{
+			kind:          util.OperationWithdraw,
+			fromAccountID: account.ID,
+			amount:        req.GetAmount().GetUnits(),
+			currency:      account.Currency,
+			requestedBy:   authPayload.Username,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.WithdrawResponse{PendingOperation: operation}, nil
+	}
+
+	result, err := server.store.WithdrawTx(ctx, db.WithdrawTxParams{
+		AccountID:   account.ID,
+		Amount:      req.GetAmount().GetUnits(),
+		AfterCreate: server.notifyTransfer(ctx),
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrInsufficientBalance) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account [%d] doesn't have enough balance", account.ID)
+		}
+
+		if errors.Is(err, db.ErrAccountClosed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "account [%d] is closed", account.ID)
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to withdraw: %v", err)
+	}
+
+	rsp := &pb.WithdrawResponse{
+		Account:  convertAccount(result.Account),
+		Entry:    convertEntry(result.Entry, account.Currency),
+		Transfer: convertTransfer(result.Transfer, account.Currency),
+	}
+
+	return rsp, nil
+}
+
+func validateWithdrawRequest(req *pb.WithdrawRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validator.ValidateAccountID(req.GetAccountId()); err != nil {
+		violations = append(violations, filedViolation("account_id", err))
+	}
+
+	violations = append(violations, validateMoney("amount", req.GetAmount())...)
+
+	return violations
+}
@@ -0,0 +1,235 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithdrawAPI(t *testing.T) {
+	user, _ := randomUser()
+	banker, _ := randomUser()
+	banker.Role = util.BankerRole
+
+	account := randomAccount(user.Username, util.USD)
+	amount := int64(10)
+
+	testCases := []struct {
+		name          string
+		req           *pb.WithdrawRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.WithdrawResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.WithdrawRequest{
+				AccountId: account.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				updatedAccount := account
+				updatedAccount.Balance -= amount
+
+				store.EXPECT().
+					WithdrawTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.WithdrawTxParams) (db.WithdrawTxResult, error) {
+						require.Equal(t, account.ID, arg.AccountID)
+						require.Equal(t, amount, arg.Amount)
+						// 送金の通知とアラートの評価のタスクを登録する
+						require.NotNil(t, arg.AfterCreate)
+
+						return db.WithdrawTxResult{
+							Account: updatedAccount,
+							Entry: db.Entry{
+								AccountID: account.ID,
+								Amount:    -amount,
+							},
+						}, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.WithdrawResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Equal(t, account.Balance-amount, res.GetAccount().GetBalance().GetUnits())
+				require.Equal(t, util.FormatMoney(account.Balance-amount, util.USD), res.GetAccount().GetBalance().GetFormatted())
+				require.Equal(t, -amount, res.GetEntry().GetAmount().GetUnits())
+			},
+		},
+		{
+			name: "InsufficientBalance",
+			req: &pb.WithdrawRequest{
+				AccountId: account.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    account.Balance + 1,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(1).Return(db.WithdrawTxResult{}, db.ErrInsufficientBalance)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.WithdrawResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+			},
+		},
+		{
+			name: "SystemAccount",
+			req: &pb.WithdrawRequest{
+				AccountId: account.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				systemAccount := account
+				systemAccount.Owner = util.CashVaultOwner
+
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(systemAccount, nil)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker.Username, banker.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.WithdrawResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.PermissionDenied, st.Code())
+			},
+		},
+		{
+			name: "DepositorNotAllowed",
+			req: &pb.WithdrawRequest{
+				AccountId: account.ID,
+				Amount: &pb.Money{
+					Currency: util.USD,
+					Units:    amount,
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, user.Role, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.WithdrawResponse, err error) {
+				require.Error(t, err)
+				require.Nil(t, res)
+
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				require.Equal(t, codes.Unauthenticated, st.Code())
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			storeCtrl := gomock.NewController(t)
+			defer storeCtrl.Finish()
+
+			store := mockdb.NewMockStore(storeCtrl)
+
+			tc.buildStubs(store)
+
+			expectActiveUser(store)
+			server := newTestServer(t, store, nil)
+
+			ctx := tc.buildContext(t, server.maker)
+			res, err := server.Withdraw(ctx, tc.req)
+
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestWithdrawRequiresApproval(t *testing.T) {
+	user, _ := randomUser()
+	banker, _ := randomUser()
+	banker.Role = util.BankerRole
+
+	account := randomAccount(user.Username, util.USD)
+	currency, _ := util.GetCurrency(util.USD)
+	amount := currency.ApprovalThreshold + 1
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	expectActiveUser(store)
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().
+		RequestOperationTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreatePendingOperationParams) (db.PendingOperation, error) {
+			require.Equal(t, util.OperationWithdraw, arg.Kind)
+			require.Equal(t, account.ID, arg.FromAccountID.Int64)
+			require.False(t, arg.ToAccountID.Valid)
+			require.Equal(t, amount, arg.Amount)
+			require.Equal(t, banker.Username, arg.RequestedBy)
+			require.WithinDuration(t, time.Now().Add(time.Hour), arg.ExpiresAt, time.Second)
+
+			return db.PendingOperation{
+				ID:            1,
+				Kind:          arg.Kind,
+				FromAccountID: arg.FromAccountID,
+				Amount:        arg.Amount,
+				Currency:      arg.Currency,
+				RequestedBy:   arg.RequestedBy,
+				Status:        util.ReviewPending,
+				ExpiresAt:     arg.ExpiresAt,
+			}, nil
+		})
+
+	server := newTestServer(t, store, nil)
+	server.config.ApprovalTTL = time.Hour
+
+	ctx := newContextWithBearerToken(t, server.maker, banker.Username, banker.Role, time.Minute)
+	res, err := server.Withdraw(ctx, &pb.WithdrawRequest{
+		AccountId: account.ID,
+		Amount: &pb.Money{
+			Currency: util.USD,
+			Units:    amount,
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.GetAccount())
+	require.Equal(t, util.ReviewPending, res.GetPendingOperation().GetStatus())
+	require.Equal(t, account.ID, res.GetPendingOperation().GetFromAccountId())
+	require.Nil(t, res.GetPendingOperation().ToAccountId)
+}
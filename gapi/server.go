@@ -4,6 +4,9 @@ import (
 	"fmt"
 
 	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/fraud"
+	"github.com/shouta0715/simple-bank/link"
+	"github.com/shouta0715/simple-bank/pagination"
 	"github.com/shouta0715/simple-bank/pb"
 	"github.com/shouta0715/simple-bank/token"
 	"github.com/shouta0715/simple-bank/util"
@@ -16,6 +19,12 @@ type Server struct {
 	maker           token.Maker
 	config          util.Config
 	taskDistributor worker.TaskDistributor
+	// 不正検知のルールが設定されていない場合は nil
+	fraud *fraud.Engine
+	// 一覧の次のページのトークンに署名する
+	pageTokens *pagination.Signer
+	// メールのリンクの署名を確認する
+	links *link.Builder
 }
 
 // setup gRPC server
@@ -26,11 +35,39 @@ func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDi
 		return nil, fmt.Errorf("cannnot create token maker: %w", err)
 	}
 
+	pageTokens, err := pagination.NewSigner(config.PageTokenKey)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot create page token signer: %w", err)
+	}
+
+	links, err := link.NewBuilder(config.PublicBaseURL, config.LinkSigningKey, config.SignedLinkDuration)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot create link builder: %w", err)
+	}
+
 	server := &Server{
 		store:           store,
 		maker:           tokenMaker,
 		config:          config,
 		taskDistributor: taskDistributor,
+		pageTokens:      pageTokens,
+		links:           links,
+	}
+
+	if config.FraudRulesPath != "" {
+		rules, err := fraud.LoadRules(config.FraudRulesPath)
+
+		if err != nil {
+			return nil, err
+		}
+
+		server.fraud, err = fraud.NewEngine(rules)
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot create fraud engine: %w", err)
+		}
 	}
 
 	return server, nil
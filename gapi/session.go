@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/token"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+type sessionTokens struct {
+	session        db.Session
+	accessToken    string
+	accessPayload  *token.Payload
+	refreshToken   string
+	refreshPayload *token.Payload
+}
+
+// createSession はユーザーのアクセストークンとリフレッシュトークンを作り、セッションを登録する
+func (server *Server) createSession(ctx context.Context, user db.User) (*sessionTokens, error) {
+	accessToken, accessPayload, err := server.maker.CreateToken(
+		user.Username,
+		user.Role,
+		server.config.AccessTokenDuration,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshPayload, err := server.maker.CreateToken(
+		user.Username,
+		user.Role,
+		server.config.RefreshTokenDuration,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
+		ID:               refreshPayload.ID,
+		Username:         user.Username,
+		RefreshTokenHash: util.HashSecret(refreshToken),
+		UserAgent:        mtdt.UserAgent,
+		ClientIp:         mtdt.ClientIP,
+		IsBlocked:        false,
+		ExpiresAt:        refreshPayload.ExpiresAt.Time,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionTokens{
+		session:        session,
+		accessToken:    accessToken,
+		accessPayload:  accessPayload,
+		refreshToken:   refreshToken,
+		refreshPayload: refreshPayload,
+	}, nil
+}
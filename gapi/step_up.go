@@ -0,0 +1,39 @@
+package gapi
+
+import (
+	"fmt"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+// verifyStepUp はパスワードやメールアドレスの変更、アカウントの削除の前に、本人がもう一度認証したかを確かめる
+// アクセストークンを盗まれただけではアカウントを乗っ取れないように、今のパスワードか StepUp のトークンを求める
+func (server *Server) verifyStepUp(user db.User, currentPassword *string, stepUpToken *string) error {
+	if stepUpToken != nil {
+		payload, err := server.maker.VerifyToken(*stepUpToken)
+
+		if err != nil || payload.Role != util.StepUpRole || payload.Username != user.Username {
+			return stepUpRequiredError("invalid step-up token",
+				stepUpViolation("step_up_token", "step-up token is invalid or expired, call StepUp again"),
+			)
+		}
+
+		return nil
+	}
+
+	if currentPassword != nil {
+		if err := util.CheckPassword(*currentPassword, user.HashedPassword); err != nil {
+			return stepUpRequiredError(fmt.Sprintf("invalid current password: %v", err),
+				stepUpViolation("current_password", "current password is incorrect"),
+			)
+		}
+
+		return nil
+	}
+
+	return stepUpRequiredError("current password or step-up token is required",
+		stepUpViolation("current_password", "current password is required for this change"),
+		stepUpViolation("step_up_token", "a step-up token from StepUp can be used instead of the current password"),
+	)
+}
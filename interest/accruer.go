@@ -0,0 +1,147 @@
+package interest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+)
+
+const DefaultBatchSize = 500
+
+// Accruer は利率プランがある口座に1日分の利息を計上し、月末に1か月分をまとめて支払う
+// 計上も支払いも日付ごとに冪等なので、途中で失敗しても同じ日付で再実行すればよい
+type Accruer struct {
+	store     db.Store
+	batchSize int32
+	// afterPost は利息を支払う送金を記録した後に同じトランザクションの中で呼ばれる
+	afterPost func(result db.TransferTxResult) error
+}
+
+type Result struct {
+	AccrualDate     time.Time
+	AccountsAccrued int
+	// すでに計上済みで何もしなかった口座の数
+	AccountsSkipped int
+	AccountsPosted  int
+	PostedAmount    int64
+}
+
+func NewAccruer(store db.Store, batchSize int32, afterPost func(result db.TransferTxResult) error) *Accruer {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Accruer{
+		store:     store,
+		batchSize: batchSize,
+		afterPost: afterPost,
+	}
+}
+
+func (accruer *Accruer) Run(ctx context.Context, accrualDate time.Time) (*Result, error) {
+	accrualDate = truncateToDate(accrualDate)
+
+	result := &Result{AccrualDate: accrualDate}
+
+	if err := accruer.accrue(ctx, result); err != nil {
+		return result, err
+	}
+
+	if isLastDayOfMonth(accrualDate) {
+		if err := accruer.post(ctx, result); err != nil {
+			return result, err
+		}
+	}
+
+	log.Info().
+		Str("accrual_date", accrualDate.Format(time.DateOnly)).
+		Int("accounts_accrued", result.AccountsAccrued).
+		Int("accounts_skipped", result.AccountsSkipped).
+		Int("accounts_posted", result.AccountsPosted).
+		Int64("posted_amount", result.PostedAmount).
+		Msg("interest accrual completed")
+
+	return result, nil
+}
+
+func (accruer *Accruer) accrue(ctx context.Context, result *Result) error {
+	var afterID int64
+
+	for {
+		accounts, err := accruer.store.ListAccountsForAccrual(ctx, db.ListAccountsForAccrualParams{
+			AfterID: afterID,
+			Limit:   accruer.batchSize,
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to list accounts for accrual: %w", err)
+		}
+
+		for _, account := range accounts {
+			accrual, err := accruer.store.AccrueInterestTx(ctx, db.AccrueInterestTxParams{
+				AccountID:     account.ID,
+				AccrualDate:   result.AccrualDate,
+				Balance:       account.Balance,
+				AnnualRateBps: account.AnnualRateBps,
+			})
+
+			if err != nil {
+				return fmt.Errorf("failed to accrue interest for account %d: %w", account.ID, err)
+			}
+
+			if accrual.Created {
+				result.AccountsAccrued++
+			} else {
+				result.AccountsSkipped++
+			}
+		}
+
+		if len(accounts) < int(accruer.batchSize) {
+			return nil
+		}
+
+		afterID = accounts[len(accounts)-1].ID
+	}
+}
+
+func (accruer *Accruer) post(ctx context.Context, result *Result) error {
+	var afterID int64
+
+	for {
+		accountIDs, err := accruer.store.ListAccountsWithUnpostedInterest(ctx, db.ListAccountsWithUnpostedInterestParams{
+			AccrualDate: pgDate(result.AccrualDate),
+			AfterID:     afterID,
+			Limit:       accruer.batchSize,
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to list accounts with unposted interest: %w", err)
+		}
+
+		for _, accountID := range accountIDs {
+			posting, err := accruer.store.PostInterestTx(ctx, db.PostInterestTxParams{
+				AccountID:   accountID,
+				PeriodEnd:   result.AccrualDate,
+				AfterCreate: accruer.afterPost,
+			})
+
+			if err != nil {
+				return fmt.Errorf("failed to post interest for account %d: %w", accountID, err)
+			}
+
+			if posting.Amount > 0 {
+				result.AccountsPosted++
+				result.PostedAmount += posting.Amount
+			}
+		}
+
+		if len(accountIDs) < int(accruer.batchSize) {
+			return nil
+		}
+
+		afterID = accountIDs[len(accountIDs)-1]
+	}
+}
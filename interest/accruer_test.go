@@ -0,0 +1,124 @@
+package interest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccruerRun(t *testing.T) {
+	accounts := []db.ListAccountsForAccrualRow{
+		{ID: 1, Balance: 1000, AnnualRateBps: 100},
+		{ID: 2, Balance: 2000, AnnualRateBps: 200},
+	}
+
+	testCases := []struct {
+		name        string
+		accrualDate time.Time
+		buildStubs  func(store *mockdb.MockStore, accrualDate time.Time)
+		checkResult func(t *testing.T, result *Result)
+	}{
+		{
+			name:        "MidMonth",
+			accrualDate: time.Date(2023, time.March, 15, 13, 0, 0, 0, time.UTC),
+			buildStubs: func(store *mockdb.MockStore, accrualDate time.Time) {
+				store.EXPECT().ListAccountsForAccrual(gomock.Any(), gomock.Any()).Times(1).Return(accounts, nil)
+
+				store.EXPECT().
+					AccrueInterestTx(gomock.Any(), gomock.Eq(db.AccrueInterestTxParams{
+						AccountID:     1,
+						AccrualDate:   accrualDate,
+						Balance:       1000,
+						AnnualRateBps: 100,
+					})).
+					Times(1).
+					Return(db.AccrueInterestTxResult{Created: true}, nil)
+
+				// 計上済みの口座
+				store.EXPECT().
+					AccrueInterestTx(gomock.Any(), gomock.Eq(db.AccrueInterestTxParams{
+						AccountID:     2,
+						AccrualDate:   accrualDate,
+						Balance:       2000,
+						AnnualRateBps: 200,
+					})).
+					Times(1).
+					Return(db.AccrueInterestTxResult{Created: false}, nil)
+
+				store.EXPECT().ListAccountsWithUnpostedInterest(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().PostInterestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResult: func(t *testing.T, result *Result) {
+				require.Equal(t, 1, result.AccountsAccrued)
+				require.Equal(t, 1, result.AccountsSkipped)
+				require.Zero(t, result.AccountsPosted)
+			},
+		},
+		{
+			name:        "EndOfMonth",
+			accrualDate: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			buildStubs: func(store *mockdb.MockStore, accrualDate time.Time) {
+				store.EXPECT().ListAccountsForAccrual(gomock.Any(), gomock.Any()).Times(1).Return(accounts, nil)
+				store.EXPECT().
+					AccrueInterestTx(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.AccrueInterestTxResult{Created: true}, nil)
+
+				store.EXPECT().
+					ListAccountsWithUnpostedInterest(gomock.Any(), gomock.Eq(db.ListAccountsWithUnpostedInterestParams{
+						AccrualDate: pgDate(accrualDate),
+						AfterID:     0,
+						Limit:       DefaultBatchSize,
+					})).
+					Times(1).
+					Return([]int64{1, 2}, nil)
+
+				store.EXPECT().
+					PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 1, PeriodEnd: accrualDate})).
+					Times(1).
+					Return(db.PostInterestTxResult{Amount: 3}, nil)
+
+				// 別の実行で支払い済み
+				store.EXPECT().
+					PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 2, PeriodEnd: accrualDate})).
+					Times(1).
+					Return(db.PostInterestTxResult{}, nil)
+			},
+			checkResult: func(t *testing.T, result *Result) {
+				require.Equal(t, 2, result.AccountsAccrued)
+				require.Equal(t, 1, result.AccountsPosted)
+				require.Equal(t, int64(3), result.PostedAmount)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store, truncateToDate(tc.accrualDate))
+
+			accruer := NewAccruer(store, 0, nil)
+
+			result, err := accruer.Run(context.Background(), tc.accrualDate)
+			require.NoError(t, err)
+			require.Equal(t, truncateToDate(tc.accrualDate), result.AccrualDate)
+
+			tc.checkResult(t, result)
+		})
+	}
+}
+
+func TestPreviousDate(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 0, 5, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), PreviousDate(now))
+}
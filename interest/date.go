@@ -0,0 +1,26 @@
+package interest
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func truncateToDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func isLastDayOfMonth(date time.Time) bool {
+	return date.AddDate(0, 0, 1).Day() == 1
+}
+
+func pgDate(date time.Time) pgtype.Date {
+	return pgtype.Date{Time: date, Valid: true}
+}
+
+// PreviousDate は t の前日の日付を返す
+// 日次のジョブは実行した日の前日分の利息を計上する
+func PreviousDate(t time.Time) time.Time {
+	return truncateToDate(t.UTC()).AddDate(0, 0, -1)
+}
@@ -0,0 +1,149 @@
+package link
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const minKeySize = 32
+
+// 署名付きのリンクに付けるクエリパラメーター
+const (
+	ExpiresParam   = "expires"
+	SignatureParam = "signature"
+)
+
+// メールのリンクの宛先
+const (
+	VerifyEmailPath = "/v1/verify_email"
+	DataExportPath  = "/v1/data_exports/download"
+)
+
+var (
+	ErrInvalidLink = errors.New("invalid link")
+	ErrExpiredLink = errors.New("link has expired")
+)
+
+// Builder はメールに載せるリンクを PUBLIC_BASE_URL から作る
+// 署名付きのリンクは有効期限とパス、クエリパラメーターを HMAC-SHA256 で署名するので、ID などを書き換えると使えなくなる
+type Builder struct {
+	baseURL string
+	key     []byte
+	// 署名付きのリンクを使える期間
+	duration time.Duration
+}
+
+func NewBuilder(baseURL string, key string, duration time.Duration) (*Builder, error) {
+	u, err := url.Parse(baseURL)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid public base url: %q", baseURL)
+	}
+
+	if len(key) < minKeySize {
+		return nil, fmt.Errorf("invalid key size: must be at least %d characters", minKeySize)
+	}
+
+	return &Builder{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		key:      []byte(key),
+		duration: duration,
+	}, nil
+}
+
+// Duration は署名付きのリンクを使える期間を返す
+func (builder *Builder) Duration() time.Duration {
+	return builder.duration
+}
+
+// URL は署名のないリンクを返す
+func (builder *Builder) URL(path string, params url.Values) string {
+	if len(params) == 0 {
+		return builder.baseURL + path
+	}
+
+	return builder.baseURL + path + "?" + params.Encode()
+}
+
+// SignedURL は決めた期間だけ使える署名付きのリンクを返す
+func (builder *Builder) SignedURL(path string, params url.Values) string {
+	signed := url.Values{}
+
+	for key, values := range params {
+		signed[key] = append([]string(nil), values...)
+	}
+
+	signed.Set(ExpiresParam, strconv.FormatInt(time.Now().Add(builder.duration).Unix(), 10))
+	signed.Set(SignatureParam, builder.sign(path, signed))
+
+	return builder.URL(path, signed)
+}
+
+// Verify はリンクのクエリパラメーターの署名と有効期限を確認する
+// データベースを見る前に、書き換えられたリンクを弾くために使う
+func (builder *Builder) Verify(path string, params url.Values) error {
+	mac, err := base64.RawURLEncoding.DecodeString(params.Get(SignatureParam))
+
+	if err != nil {
+		return ErrInvalidLink
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(builder.sign(path, params))
+
+	if err != nil || !hmac.Equal(mac, expected) {
+		return ErrInvalidLink
+	}
+
+	expires, err := strconv.ParseInt(params.Get(ExpiresParam), 10, 64)
+
+	if err != nil {
+		return ErrInvalidLink
+	}
+
+	if time.Now().After(time.Unix(expires, 0)) {
+		return ErrExpiredLink
+	}
+
+	return nil
+}
+
+// sign は signature を除いたクエリパラメーターに署名する
+// url.Values.Encode はキーの順に並べるので、パラメーターの順番が変わっても同じ署名になる
+func (builder *Builder) sign(path string, params url.Values) string {
+	unsigned := url.Values{}
+
+	for key, values := range params {
+		if key != SignatureParam {
+			unsigned[key] = values
+		}
+	}
+
+	mac := hmac.New(sha256.New, builder.key)
+	mac.Write([]byte(path))
+	mac.Write([]byte{0})
+	mac.Write([]byte(unsigned.Encode()))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyEmailParams はメールアドレスを確認するリンクのクエリパラメーターを返す
+func VerifyEmailParams(emailID int64, secretCode string) url.Values {
+	return url.Values{
+		"email_id":    {strconv.FormatInt(emailID, 10)},
+		"secret_code": {secretCode},
+	}
+}
+
+// DataExportParams はエクスポートしたデータをダウンロードするリンクのクエリパラメーターを返す
+func DataExportParams(exportID int64) url.Values {
+	return url.Values{
+		"export_id": {strconv.FormatInt(exportID, 10)},
+	}
+}
@@ -0,0 +1,108 @@
+package link
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedURL(t *testing.T) {
+	builder, err := NewBuilder("https://bank.example.com/", util.RandomString(32), time.Minute)
+	require.NoError(t, err)
+
+	params := VerifyEmailParams(42, util.RandomString(32))
+	signed := builder.SignedURL(VerifyEmailPath, params)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	require.Equal(t, "bank.example.com", u.Host)
+	require.Equal(t, VerifyEmailPath, u.Path)
+	require.Equal(t, params.Get("secret_code"), u.Query().Get("secret_code"))
+
+	// 元のパラメーターは変えない
+	require.Empty(t, params.Get(SignatureParam))
+
+	require.NoError(t, builder.Verify(VerifyEmailPath, u.Query()))
+
+	testCases := []struct {
+		name   string
+		path   string
+		modify func(query url.Values)
+		err    error
+	}{
+		{
+			name:   "TamperedID",
+			path:   VerifyEmailPath,
+			modify: func(query url.Values) { query.Set("email_id", "43") },
+			err:    ErrInvalidLink,
+		},
+		{
+			name:   "TamperedExpires",
+			path:   VerifyEmailPath,
+			modify: func(query url.Values) { query.Set(ExpiresParam, "9999999999") },
+			err:    ErrInvalidLink,
+		},
+		{
+			name:   "NoSignature",
+			path:   VerifyEmailPath,
+			modify: func(query url.Values) { query.Del(SignatureParam) },
+			err:    ErrInvalidLink,
+		},
+		{
+			name:   "OtherPath",
+			path:   "/v1/other",
+			modify: func(query url.Values) {},
+			err:    ErrInvalidLink,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			query := u.Query()
+			tc.modify(query)
+
+			require.ErrorIs(t, builder.Verify(tc.path, query), tc.err)
+		})
+	}
+}
+
+func TestExpiredURL(t *testing.T) {
+	builder, err := NewBuilder("http://localhost:8080", util.RandomString(32), -time.Minute)
+	require.NoError(t, err)
+
+	u, err := url.Parse(builder.SignedURL(VerifyEmailPath, VerifyEmailParams(1, "code")))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, builder.Verify(VerifyEmailPath, u.Query()), ErrExpiredLink)
+}
+
+func TestNewBuilder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		baseURL string
+		key     string
+		ok      bool
+	}{
+		{name: "OK", baseURL: "https://bank.example.com", key: util.RandomString(32), ok: true},
+		{name: "NoScheme", baseURL: "bank.example.com", key: util.RandomString(32)},
+		{name: "UnsupportedScheme", baseURL: "ftp://bank.example.com", key: util.RandomString(32)},
+		{name: "ShortKey", baseURL: "https://bank.example.com", key: util.RandomString(16)},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewBuilder(tc.baseURL, tc.key, time.Minute)
+
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package mail
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 署名するヘッダー。メールにないヘッダーは署名しない
+var dkimSignedHeaders = []string{
+	"From", "Reply-To", "Subject", "Date", "To", "Cc", "Message-Id", "Mime-Version", "Content-Type",
+}
+
+// DKIMSigner は送るメールに DKIM-Signature ヘッダーを付ける
+// ヘッダーと本文はどちらも relaxed で正規化し、鍵が RSA なら rsa-sha256、Ed25519 なら ed25519-sha256 で署名する
+type DKIMSigner struct {
+	domain    string
+	selector  string
+	signer    crypto.Signer
+	algorithm string
+}
+
+// NewDKIMSigner は PEM 形式の秘密鍵から署名する人を作る
+// 公開鍵は <selector>._domainkey.<domain> の TXT レコードに登録しておく
+func NewDKIMSigner(domain string, selector string, privateKeyPEM []byte) (*DKIMSigner, error) {
+	if domain == "" || selector == "" {
+		return nil, errors.New("dkim domain and selector are required")
+	}
+
+	block, _ := pem.Decode(privateKeyPEM)
+
+	if block == nil {
+		return nil, errors.New("dkim private key is not in PEM format")
+	}
+
+	var key any
+	var err error
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported dkim private key type: %s", block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse dkim private key: %w", err)
+	}
+
+	signer := &DKIMSigner{domain: domain, selector: selector}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		signer.signer = key
+		signer.algorithm = "rsa-sha256"
+	case ed25519.PrivateKey:
+		signer.signer = key
+		signer.algorithm = "ed25519-sha256"
+	default:
+		return nil, fmt.Errorf("unsupported dkim private key: %T", key)
+	}
+
+	return signer, nil
+}
+
+// Sign は DKIM-Signature ヘッダーを先頭に付けたメールを返す
+// 改行は CRLF にそろえてから署名する
+func (s *DKIMSigner) Sign(msg []byte) ([]byte, error) {
+	msg = toCRLF(msg)
+
+	header, body, ok := bytes.Cut(msg, []byte("\r\n\r\n"))
+
+	if !ok {
+		return nil, errors.New("email has no body")
+	}
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+	headers := parseHeaders(string(header))
+
+	var names []string
+	var canonical strings.Builder
+
+	for _, name := range dkimSignedHeaders {
+		if value, ok := headers[strings.ToLower(name)]; ok {
+			names = append(names, name)
+			canonical.WriteString(relaxedHeader(name, value))
+		}
+	}
+
+	value := fmt.Sprintf("v=1; a=%s; c=relaxed/relaxed; d=%s; s=%s; t=%d; h=%s; bh=%s; b=",
+		s.algorithm, s.domain, s.selector, time.Now().Unix(), strings.Join(names, ":"),
+		base64.StdEncoding.EncodeToString(bodyHash[:]))
+
+	// DKIM-Signature 自体は b= を空にして、最後の CRLF を付けずに署名に含める
+	canonical.WriteString(strings.TrimSuffix(relaxedHeader("DKIM-Signature", value), "\r\n"))
+
+	signature, err := s.sign([]byte(canonical.String()))
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign email: %w", err)
+	}
+
+	signed := "DKIM-Signature: " + value + base64.StdEncoding.EncodeToString(signature) + "\r\n"
+
+	return append([]byte(signed), msg...), nil
+}
+
+// sign はデータの SHA-256 のハッシュに署名する
+// ed25519-sha256 は RFC 8463 に従い、ハッシュをそのまま Ed25519 で署名する
+func (s *DKIMSigner) sign(data []byte) ([]byte, error) {
+	hashed := sha256.Sum256(data)
+	opts := crypto.Hash(0)
+
+	if s.algorithm == "rsa-sha256" {
+		opts = crypto.SHA256
+	}
+
+	return s.signer.Sign(rand.Reader, hashed[:], opts)
+}
+
+// parseHeaders はヘッダーの名前を小文字にして値を返す
+// 同じ名前のヘッダーが複数ある場合は、RFC 6376 に従い最後のものを署名する
+func parseHeaders(header string) map[string]string {
+	headers := map[string]string{}
+	var name, value string
+
+	flush := func() {
+		if name != "" {
+			headers[strings.ToLower(name)] = value
+		}
+	}
+
+	for _, line := range strings.Split(header, "\r\n") {
+		// 空白で始まる行は前のヘッダーの続き
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			value += "\r\n" + line
+			continue
+		}
+
+		flush()
+
+		var ok bool
+		name, value, ok = strings.Cut(line, ":")
+
+		if !ok {
+			name = ""
+		}
+	}
+
+	flush()
+
+	return headers
+}
+
+// relaxedHeader は RFC 6376 3.4.2 の relaxed でヘッダーを正規化する
+func relaxedHeader(name string, value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = strings.Join(strings.FieldsFunc(value, isWSP), " ")
+
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + value + "\r\n"
+}
+
+// relaxedBody は RFC 6376 3.4.4 の relaxed で本文を正規化する
+func relaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+
+	for i, line := range lines {
+		fields := strings.FieldsFunc(line, isWSP)
+		lines[i] = strings.Join(fields, " ")
+
+		// 行頭の空白は1つの空白として残す
+		if len(fields) > 0 && len(line) > 0 && isWSP(rune(line[0])) {
+			lines[i] = " " + lines[i]
+		}
+	}
+
+	// 最後の空行は取り除く
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func isWSP(r rune) bool {
+	return r == ' ' || r == '\t'
+}
+
+// toCRLF は LF だけの改行を CRLF にする
+func toCRLF(msg []byte) []byte {
+	msg = bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(msg, []byte("\n"), []byte("\r\n"))
+}
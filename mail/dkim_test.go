@@ -0,0 +1,132 @@
+package mail
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDKIMSigner(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ed25519Public, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		pem       []byte
+		algorithm string
+		verify    func(t *testing.T, hashed []byte, signature []byte)
+	}{
+		{
+			name:      "RSA",
+			pem:       pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			algorithm: "rsa-sha256",
+			verify: func(t *testing.T, hashed []byte, signature []byte) {
+				require.NoError(t, rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, hashed, signature))
+			},
+		},
+		{
+			name:      "Ed25519",
+			pem:       pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+			algorithm: "ed25519-sha256",
+			verify: func(t *testing.T, hashed []byte, signature []byte) {
+				require.True(t, ed25519.Verify(ed25519Public, hashed, signature))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := NewDKIMSigner("example.com", "bank", tc.pem)
+			require.NoError(t, err)
+
+			e, _, err := newEmail("Simple Bank", "bank@example.com", "A test email",
+				Content{HTML: "<p>Hello</p>", Text: "Hello"}, []string{"alice@example.com"}, nil, nil, nil)
+			require.NoError(t, err)
+
+			msg, err := e.Bytes()
+			require.NoError(t, err)
+
+			signed, err := signer.Sign(msg)
+			require.NoError(t, err)
+
+			// 受け取った側と同じ手順で署名を確かめる
+			line, rest, ok := strings.Cut(string(signed), "\r\n")
+			require.True(t, ok)
+
+			value, ok := strings.CutPrefix(line, "DKIM-Signature: ")
+			require.True(t, ok)
+
+			tags := map[string]string{}
+
+			for _, tag := range strings.Split(value, ";") {
+				k, v, _ := strings.Cut(strings.TrimSpace(tag), "=")
+				tags[k] = v
+			}
+
+			require.Equal(t, tc.algorithm, tags["a"])
+			require.Equal(t, "example.com", tags["d"])
+			require.Equal(t, "bank", tags["s"])
+			require.Contains(t, tags["h"], "From")
+			require.Contains(t, tags["h"], "Message-Id")
+
+			header, body, ok := strings.Cut(rest, "\r\n\r\n")
+			require.True(t, ok)
+
+			bodyHash := sha256.Sum256(relaxedBody([]byte(body)))
+			require.Equal(t, base64.StdEncoding.EncodeToString(bodyHash[:]), tags["bh"])
+
+			headers := parseHeaders(header)
+
+			var canonical strings.Builder
+
+			for _, name := range strings.Split(tags["h"], ":") {
+				canonical.WriteString(relaxedHeader(name, headers[strings.ToLower(name)]))
+			}
+
+			unsigned := strings.TrimSuffix(value, tags["b"])
+			canonical.WriteString(strings.TrimSuffix(relaxedHeader("DKIM-Signature", unsigned), "\r\n"))
+
+			signature, err := base64.StdEncoding.DecodeString(tags["b"])
+			require.NoError(t, err)
+
+			hashed := sha256.Sum256([]byte(canonical.String()))
+			tc.verify(t, hashed[:], signature)
+		})
+	}
+}
+
+func TestNewDKIMSignerInvalid(t *testing.T) {
+	_, err := NewDKIMSigner("", "bank", nil)
+	require.Error(t, err)
+
+	_, err = NewDKIMSigner("example.com", "bank", []byte("not a key"))
+	require.Error(t, err)
+
+	_, err = NewDKIMSigner("example.com", "bank", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("key")}))
+	require.Error(t, err)
+}
+
+func TestDKIMCanonicalization(t *testing.T) {
+	// RFC 6376 3.4.5 の例
+	require.Equal(t, "a:X\r\n", relaxedHeader("A", " X"))
+	require.Equal(t, "b:Y Z\r\n", relaxedHeader("B ", " Y\t\r\n\tZ  "))
+
+	require.Equal(t, " C\r\nD E\r\n", string(relaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))))
+	require.Empty(t, relaxedBody([]byte("\r\n\r\n")))
+}
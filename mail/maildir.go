@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+// MaildirSender はメールを送らずに Maildir 形式のディレクトリに保存する
+// 開発環境で本物のメールを送らずに、メールソフトで中身を確認するために使う
+type MaildirSender struct {
+	name             string
+	fromEmailAddress string
+	dir              string
+	count            atomic.Int64
+}
+
+func NewMaildirSender(name, fromEmailAddress, dir string) (EmailSender, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("maildir path is required")
+	}
+
+	// 書き込み中のメールは tmp に置き、書き終わってから new に移す
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("cannot create maildir: %w", err)
+		}
+	}
+
+	return &MaildirSender{
+		name:             name,
+		fromEmailAddress: fromEmailAddress,
+		dir:              dir,
+	}, nil
+}
+
+func (s *MaildirSender) SendEmail(
+	ctx context.Context,
+	subject string,
+	content Content,
+	to []string,
+	cc []string,
+	bcc []string,
+	attachFiles []string,
+) (string, error) {
+	e, messageID, err := newEmail(s.name, s.fromEmailAddress, subject, content, to, cc, bcc, attachFiles)
+
+	if err != nil {
+		return "", err
+	}
+
+	msg, err := e.Bytes()
+
+	if err != nil {
+		return "", fmt.Errorf("cannot build email: %w", err)
+	}
+
+	filename := fmt.Sprintf("%d.%d_%d.simple-bank.eml", time.Now().Unix(), os.Getpid(), s.count.Add(1))
+	tmp := filepath.Join(s.dir, "tmp", filename)
+
+	if err := os.WriteFile(tmp, msg, 0o644); err != nil {
+		return "", fmt.Errorf("cannot write email: %w", err)
+	}
+
+	return messageID, os.Rename(tmp, filepath.Join(s.dir, "new", filename))
+}
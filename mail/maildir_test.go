@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaildirSender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "maildir")
+
+	sender, err := NewMaildirSender("Simple Bank", "bank@example.com", dir)
+	require.NoError(t, err)
+
+	content := Content{HTML: "<p>Hello</p>", Text: "Hello"}
+
+	for i := 0; i < 2; i++ {
+		_, err = sender.SendEmail(context.Background(), "A test email", content, []string{"alice@example.com"}, nil, nil, nil)
+		require.NoError(t, err)
+	}
+
+	// 書き終わったメールだけが new に置かれる
+	tmp, err := os.ReadDir(filepath.Join(dir, "tmp"))
+	require.NoError(t, err)
+	require.Empty(t, tmp)
+
+	files, err := os.ReadDir(filepath.Join(dir, "new"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	message, err := os.ReadFile(filepath.Join(dir, "new", files[0].Name()))
+	require.NoError(t, err)
+	require.Contains(t, string(message), "From: \"Simple Bank\" <bank@example.com>")
+	require.Contains(t, string(message), "Subject: A test email")
+	require.Contains(t, string(message), "multipart/alternative")
+
+	_, err = NewMaildirSender("Simple Bank", "bank@example.com", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SentEmail は MemorySender が受け取ったメール
+type SentEmail struct {
+	MessageID   string
+	Subject     string
+	Content     Content
+	To          []string
+	Cc          []string
+	Bcc         []string
+	AttachFiles []string
+}
+
+// MemorySender はメールを送らずにメモリに残す。テストで送ったメールを確認するために使う
+type MemorySender struct {
+	mu   sync.Mutex
+	sent []SentEmail
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) SendEmail(
+	ctx context.Context,
+	subject string,
+	content Content,
+	to []string,
+	cc []string,
+	bcc []string,
+	attachFiles []string,
+) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messageID := fmt.Sprintf("<%d@memory>", len(s.sent)+1)
+
+	s.sent = append(s.sent, SentEmail{
+		MessageID:   messageID,
+		Subject:     subject,
+		Content:     content,
+		To:          to,
+		Cc:          cc,
+		Bcc:         bcc,
+		AttachFiles: attachFiles,
+	})
+
+	return messageID, nil
+}
+
+// Sent はこれまでに受け取ったメールを送った順に返す
+func (s *MemorySender) Sent() []SentEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SentEmail(nil), s.sent...)
+}
+
+// Reset は受け取ったメールを捨てる
+func (s *MemorySender) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = nil
+}
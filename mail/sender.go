@@ -1,55 +1,111 @@
 package mail
 
 import (
+	"context"
 	"fmt"
-	"net/smtp"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/jordan-wright/email"
+	"github.com/shouta0715/simple-bank/util"
 )
 
+// メールの送り方
 const (
-	smtpAuthAddress   = "smtp.gmail.com"
-	smtpServerAddress = "smtp.gmail.com:587"
+	// SMTP サーバーに送る
+	TransportSMTP = "smtp"
+	// 送らずに Maildir 形式でファイルに保存する。開発環境で使う
+	TransportMaildir = "maildir"
 )
 
+// Content はメールの本文
+// HTML を表示できないメールソフトのために、同じ内容のプレーンテキストと一緒に multipart/alternative で送る
+// Template は本文を作ったテンプレートの名前で、テンプレートを使わない場合は空にする
+// TemplateData は送り直すときにテンプレートから本文を作り直すための秘密でない値
+// Secrets は確認コードや署名付きの URL など、本文に含まれていても記録してはいけない値
+type Content struct {
+	Template     string
+	HTML         string
+	Text         string
+	TemplateData map[string]string
+	Secrets      []string
+}
+
+// EmailSender はメールを送り、送ったメールの Message-ID を返す
 type EmailSender interface {
 	SendEmail(
+		ctx context.Context,
 		subject string,
-		content string,
+		content Content,
 		to []string,
 		cc []string,
 		bcc []string,
 		attachFiles []string,
-	) error
+	) (messageID string, err error)
 }
 
-type GmailSender struct {
-	name              string
-	fromEmailAddress  string
-	fromEmailPassword string
-}
+// NewEmailSender は設定の EMAIL_TRANSPORT に従ってメールの送り方を選ぶ
+func NewEmailSender(config util.Config) (EmailSender, error) {
+	switch config.EmailTransport {
+	case TransportSMTP:
+		username := config.SMTPUsername
+
+		if username == "" {
+			username = config.EmailSenderAddress
+		}
+
+		var dkim *DKIMSigner
+
+		if config.DKIMPrivateKeyPath != "" {
+			key, err := os.ReadFile(config.DKIMPrivateKeyPath)
+
+			if err != nil {
+				return nil, fmt.Errorf("cannot read dkim private key: %w", err)
+			}
+
+			dkim, err = NewDKIMSigner(config.DKIMDomain, config.DKIMSelector, key)
 
-func NewGmailSender(name, fromEmailAddress, fromEmailPassword string) EmailSender {
-	return &GmailSender{
-		name:              name,
-		fromEmailAddress:  fromEmailAddress,
-		fromEmailPassword: fromEmailPassword,
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return NewSMTPSender(config.EmailSenderName, config.EmailSenderAddress, SMTPConfig{
+			Host:     config.SMTPHost,
+			Port:     config.SMTPPort,
+			Security: config.SMTPSecurity,
+			Auth:     config.SMTPAuth,
+			Username: username,
+			Password: config.EmailSenderPassword,
+			PoolSize: config.SMTPPoolSize,
+			Timeout:  config.SMTPTimeout,
+			DKIM:     dkim,
+		})
+	case TransportMaildir:
+		return NewMaildirSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailMaildirPath)
 	}
+
+	return nil, fmt.Errorf("unsupported email transport: %q", config.EmailTransport)
 }
 
-func (s *GmailSender) SendEmail(
+// newEmail は送信者と本文を設定したメールを作る
+// 送った結果を記録できるように、Message-ID はここで決める
+func newEmail(
+	name string,
+	fromEmailAddress string,
 	subject string,
-	content string,
+	content Content,
 	to []string,
 	cc []string,
 	bcc []string,
 	attachFiles []string,
-) error {
-
+) (*email.Email, string, error) {
 	e := email.NewEmail()
-	e.From = fmt.Sprintf("%s <%s>", s.name, s.fromEmailAddress)
+	e.From = fmt.Sprintf("%s <%s>", name, fromEmailAddress)
 	e.Subject = subject
-	e.HTML = []byte(content)
+	e.HTML = []byte(content.HTML)
+	e.Text = []byte(content.Text)
 	e.To = to
 	e.Cc = cc
 	e.Bcc = bcc
@@ -58,11 +114,23 @@ func (s *GmailSender) SendEmail(
 		_, err := e.AttachFile(f)
 
 		if err != nil {
-			return fmt.Errorf("cannot attach file %s: %w", f, err)
+			return nil, "", fmt.Errorf("cannot attach file %s: %w", f, err)
 		}
 	}
 
-	smtpAuth := smtp.PlainAuth("", s.fromEmailAddress, s.fromEmailPassword, smtpAuthAddress)
+	messageID := newMessageID(fromEmailAddress)
+	e.Headers.Set("Message-Id", messageID)
+
+	return e, messageID, nil
+}
+
+// newMessageID は送信者のドメインで Message-ID を作る
+func newMessageID(fromEmailAddress string) string {
+	domain := "localhost"
+
+	if i := strings.LastIndex(fromEmailAddress, "@"); i >= 0 && i < len(fromEmailAddress)-1 {
+		domain = fromEmailAddress[i+1:]
+	}
 
-	return e.Send(smtpServerAddress, smtpAuth)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), util.RandomString(16), domain)
 }
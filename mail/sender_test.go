@@ -1,13 +1,14 @@
 package mail
 
 import (
+	"context"
 	"testing"
 
 	"github.com/shouta0715/simple-bank/util"
 	"github.com/stretchr/testify/require"
 )
 
-func TestSendEmailWithGmail(t *testing.T) {
+func TestSendEmailWithSMTP(t *testing.T) {
 
 	if testing.Short() {
 		t.Skip("email test skipped")
@@ -16,7 +17,11 @@ func TestSendEmailWithGmail(t *testing.T) {
 	config, err := util.LoadConfig("..")
 	require.NoError(t, err)
 
-	sender := NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
+	// 開発環境の設定では Maildir に保存するので、SMTP の設定で送る
+	config.EmailTransport = TransportSMTP
+
+	sender, err := NewEmailSender(config)
+	require.NoError(t, err)
 
 	subject := "A test Email"
 
@@ -24,11 +29,12 @@ func TestSendEmailWithGmail(t *testing.T) {
 	<h1>Verify Emails</h1>
 	<p>Please click the link below to verify your email address.</p>
 	`
+	text := "Please click the link below to verify your email address."
 
 	to := []string{"kshouta0715@gmail.com"}
 	attachFiles := []string{"../README.md"}
 
-	err = sender.SendEmail(subject, content, to, nil, nil, attachFiles)
+	_, err = sender.SendEmail(context.Background(), subject, Content{HTML: content, Text: text}, to, nil, nil, attachFiles)
 
 	// テストは通過済みです。練習用なのでエラーが発生します。
 	require.NoError(t, err)
@@ -0,0 +1,350 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTP サーバーとの接続の暗号化
+const (
+	// 平文で接続してから STARTTLS で暗号化する。サーバーが STARTTLS に対応していない場合は送らない
+	SecuritySTARTTLS = "starttls"
+	// 最初から TLS で接続する。一般的に 465 番ポートで使う
+	SecurityTLS = "tls"
+	// 暗号化しない。ローカルのメールサーバーにだけ使う
+	SecurityNone = "none"
+)
+
+// SMTP の認証の方式
+const (
+	AuthPlain   = "plain"
+	AuthLogin   = "login"
+	AuthCRAMMD5 = "cram-md5"
+	AuthNone    = "none"
+)
+
+const (
+	DefaultSMTPPoolSize = 4
+	DefaultSMTPTimeout  = 10 * time.Second
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Security string
+	Auth     string
+	Username string
+	Password string
+	// 送信した後も開いたままにしておく接続の数
+	PoolSize int
+	// 接続と1通の送信にかける時間の上限
+	Timeout time.Duration
+	// 設定した場合は DKIM で署名して送る
+	DKIM *DKIMSigner
+}
+
+// SMTPSender は SMTP サーバーにメールを送る
+// 送信した接続は閉じずに残しておき、次の送信で使い回す
+type SMTPSender struct {
+	name             string
+	fromEmailAddress string
+	config           SMTPConfig
+	auth             smtp.Auth
+	idle             chan *smtpConn
+}
+
+type smtpConn struct {
+	conn   net.Conn
+	client *smtp.Client
+}
+
+func NewSMTPSender(name, fromEmailAddress string, config SMTPConfig) (EmailSender, error) {
+	if config.Host == "" || config.Port <= 0 {
+		return nil, fmt.Errorf("smtp host and port are required")
+	}
+
+	switch config.Security {
+	case SecuritySTARTTLS, SecurityTLS, SecurityNone:
+	default:
+		return nil, fmt.Errorf("unsupported smtp security: %q", config.Security)
+	}
+
+	var auth smtp.Auth
+
+	switch config.Auth {
+	case AuthPlain:
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	case AuthLogin:
+		auth = &loginAuth{username: config.Username, password: config.Password, host: config.Host}
+	case AuthCRAMMD5:
+		auth = smtp.CRAMMD5Auth(config.Username, config.Password)
+	case AuthNone:
+	default:
+		return nil, fmt.Errorf("unsupported smtp auth: %q", config.Auth)
+	}
+
+	if config.PoolSize <= 0 {
+		config.PoolSize = DefaultSMTPPoolSize
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultSMTPTimeout
+	}
+
+	return &SMTPSender{
+		name:             name,
+		fromEmailAddress: fromEmailAddress,
+		config:           config,
+		auth:             auth,
+		idle:             make(chan *smtpConn, config.PoolSize),
+	}, nil
+}
+
+func (s *SMTPSender) SendEmail(
+	ctx context.Context,
+	subject string,
+	content Content,
+	to []string,
+	cc []string,
+	bcc []string,
+	attachFiles []string,
+) (string, error) {
+	e, messageID, err := newEmail(s.name, s.fromEmailAddress, subject, content, to, cc, bcc, attachFiles)
+
+	if err != nil {
+		return "", err
+	}
+
+	msg, err := e.Bytes()
+
+	if err != nil {
+		return "", fmt.Errorf("cannot build email: %w", err)
+	}
+
+	if s.config.DKIM != nil {
+		msg, err = s.config.DKIM.Sign(msg)
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var recipients []string
+
+	for _, list := range [][]string{to, cc, bcc} {
+		for _, address := range list {
+			addr, err := netmail.ParseAddress(address)
+
+			if err != nil {
+				return "", fmt.Errorf("invalid recipient %s: %w", address, err)
+			}
+
+			recipients = append(recipients, addr.Address)
+		}
+	}
+
+	c, err := s.get()
+
+	if err != nil {
+		return messageID, fmt.Errorf("cannot connect to smtp server: %w", err)
+	}
+
+	err = s.send(ctx, c, recipients, msg)
+
+	if err != nil {
+		// 途中で失敗した接続はどの状態にあるか分からないので使い回さない
+		c.client.Close()
+		return messageID, err
+	}
+
+	s.put(c)
+
+	return messageID, nil
+}
+
+func (s *SMTPSender) send(ctx context.Context, c *smtpConn, recipients []string, msg []byte) error {
+	deadline := time.Now().Add(s.config.Timeout)
+
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	if err := c.client.Mail(s.fromEmailAddress); err != nil {
+		return err
+	}
+
+	for _, recipient := range recipients {
+		if err := c.client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.client.Data()
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// get は空いている接続を返す。サーバーに切られた接続は捨て、空いている接続がなければ新しく接続する
+func (s *SMTPSender) get() (*smtpConn, error) {
+	for {
+		select {
+		case c := <-s.idle:
+			if err := c.conn.SetDeadline(time.Now().Add(s.config.Timeout)); err == nil && c.client.Noop() == nil {
+				return c, nil
+			}
+
+			c.client.Close()
+		default:
+			return s.dial()
+		}
+	}
+}
+
+// put は送信が終わった接続を空いている接続に戻す。空きが一杯の場合は閉じる
+func (s *SMTPSender) put(c *smtpConn) {
+	if err := c.client.Reset(); err != nil {
+		c.client.Close()
+		return
+	}
+
+	select {
+	case s.idle <- c:
+	default:
+		c.client.Quit()
+	}
+}
+
+func (s *SMTPSender) dial() (*smtpConn, error) {
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	dialer := &net.Dialer{Timeout: s.config.Timeout}
+	tlsConfig := &tls.Config{ServerName: s.config.Host}
+
+	var conn net.Conn
+	var err error
+
+	if s.config.Security == SecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(s.config.Timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if s.config.Security == SecuritySTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, errors.New("smtp server does not support STARTTLS")
+		}
+
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return &smtpConn{conn: conn, client: client}, nil
+}
+
+// IsPermanentError は SMTP サーバーが 5xx で断った送信かどうかを返す
+// 宛先がない場合などは再試行しても届かない。複数のエラーをまとめた場合は、すべてが恒久的な失敗のときだけ true を返す
+func IsPermanentError(err error) bool {
+	switch err := err.(type) {
+	case *textproto.Error:
+		return err.Code >= 500 && err.Code < 600
+	case interface{ Unwrap() []error }:
+		errs := err.Unwrap()
+
+		for _, err := range errs {
+			if !IsPermanentError(err) {
+				return false
+			}
+		}
+
+		return len(errs) > 0
+	case interface{ Unwrap() error }:
+		return IsPermanentError(err.Unwrap())
+	}
+
+	return false
+}
+
+// loginAuth は net/smtp にない LOGIN 認証。PLAIN に対応していないサーバーで使う
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// smtp.PlainAuth と同じく、暗号化していない接続ではローカルのサーバーにだけパスワードを送る
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(string(fromServer))) {
+	case "username:":
+		return []byte(a.username), nil
+	case "password:":
+		return []byte(a.password), nil
+	}
+
+	return nil, fmt.Errorf("unexpected server challenge: %s", fromServer)
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
@@ -0,0 +1,217 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer は受け取ったメールと接続の数を記録するだけの SMTP サーバー
+type fakeSMTPServer struct {
+	listener net.Listener
+
+	mu          sync.Mutex
+	connections int
+	auths       []string
+	messages    []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeSMTPServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				return
+			}
+
+			server.mu.Lock()
+			server.connections++
+			server.mu.Unlock()
+
+			go server.serve(conn)
+		}
+	}()
+
+	return server
+}
+
+func (server *fakeSMTPServer) port() int {
+	return server.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (server *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost ESMTP")
+
+	for {
+		line, err := r.ReadString('\n')
+
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case strings.HasPrefix(command, "AUTH"):
+			server.mu.Lock()
+			server.auths = append(server.auths, strings.TrimSpace(line))
+			server.mu.Unlock()
+			reply("235 authenticated")
+		case command == "DATA":
+			reply("354 go ahead")
+
+			var message strings.Builder
+
+			for {
+				line, err := r.ReadString('\n')
+
+				if err != nil {
+					return
+				}
+
+				if line == ".\r\n" {
+					break
+				}
+
+				message.WriteString(line)
+			}
+
+			server.mu.Lock()
+			server.messages = append(server.messages, message.String())
+			server.mu.Unlock()
+			reply("250 queued")
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	server := newFakeSMTPServer(t)
+
+	sender, err := NewSMTPSender("Simple Bank", "bank@example.com", SMTPConfig{
+		Host:     "127.0.0.1",
+		Port:     server.port(),
+		Security: SecurityNone,
+		Auth:     AuthPlain,
+		Username: "bank@example.com",
+		Password: "secret",
+		PoolSize: 1,
+	})
+	require.NoError(t, err)
+
+	content := Content{HTML: "<p>Hello</p>", Text: "Hello"}
+
+	for i := 0; i < 2; i++ {
+		_, err = sender.SendEmail(context.Background(), "subject "+strconv.Itoa(i), content, []string{"alice@example.com"}, nil, nil, nil)
+		require.NoError(t, err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	// 2通目は1通目の接続を使い回す
+	require.Equal(t, 1, server.connections)
+	require.Len(t, server.auths, 1)
+	require.Len(t, server.messages, 2)
+
+	message := server.messages[0]
+	require.Contains(t, message, "Subject: subject 0")
+	require.Contains(t, message, "multipart/alternative")
+	require.Contains(t, message, "text/plain")
+	require.Contains(t, message, "text/html")
+}
+
+func TestNewSMTPSender(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config SMTPConfig
+		ok     bool
+	}{
+		{
+			name:   "OK",
+			config: SMTPConfig{Host: "smtp.example.com", Port: 587, Security: SecuritySTARTTLS, Auth: AuthLogin},
+			ok:     true,
+		},
+		{
+			name:   "NoHost",
+			config: SMTPConfig{Port: 587, Security: SecuritySTARTTLS, Auth: AuthPlain},
+		},
+		{
+			name:   "UnsupportedSecurity",
+			config: SMTPConfig{Host: "smtp.example.com", Port: 587, Security: "ssl", Auth: AuthPlain},
+		},
+		{
+			name:   "UnsupportedAuth",
+			config: SMTPConfig{Host: "smtp.example.com", Port: 587, Security: SecurityTLS, Auth: "oauth"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewSMTPSender("Simple Bank", "bank@example.com", tc.config)
+
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestIsPermanentError(t *testing.T) {
+	permanent := &textproto.Error{Code: 550, Msg: "mailbox unavailable"}
+	temporary := &textproto.Error{Code: 451, Msg: "try again later"}
+
+	testCases := []struct {
+		name string
+		err  error
+		ok   bool
+	}{
+		{name: "Permanent", err: permanent, ok: true},
+		{name: "Temporary", err: temporary},
+		{name: "Wrapped", err: fmt.Errorf("cannot send: %w", permanent), ok: true},
+		{name: "AllJoinedPermanent", err: errors.Join(permanent, permanent), ok: true},
+		{name: "SomeJoinedTemporary", err: errors.Join(permanent, temporary)},
+		{name: "Network", err: errors.New("connection refused")},
+		{name: "Nil"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.ok, IsPermanentError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+// メールのテンプレートの名前。ファイル名は templates/<言語>/<名前>.html と .txt にする
+const (
+	TemplateVerifyEmail           = "verify_email"
+	TemplateBeneficiaryAdded      = "beneficiary_added"
+	TemplatePaymentRequest        = "payment_request"
+	TemplateLowBalance            = util.AlertLowBalance
+	TemplateLargeIncomingTransfer = util.AlertLargeIncomingTransfer
+	TemplateNotification          = "notification"
+	TemplateEmailChanged          = "email_changed"
+	TemplatePasswordChanged       = "password_changed"
+	TemplateDataExport            = "data_export"
+)
+
+// Content.TemplateData のキー
+const (
+	TemplateDataUsername     = "username"
+	TemplateDataDataExportID = "data_export_id"
+)
+
+var templateNames = []string{
+	TemplateVerifyEmail,
+	TemplateBeneficiaryAdded,
+	TemplatePaymentRequest,
+	TemplateLowBalance,
+	TemplateLargeIncomingTransfer,
+	TemplateNotification,
+	TemplateEmailChanged,
+	TemplatePasswordChanged,
+	TemplateDataExport,
+}
+
+var locales = []string{util.LocaleEnglish, util.LocaleJapanese}
+
+// 言語ごとの日時の書式
+var dateTimeFormats = map[string]string{
+	util.LocaleEnglish:  "2006-01-02 15:04 MST",
+	util.LocaleJapanese: "2006年1月2日 15:04 MST",
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+// templateSet は1つの言語の1つのメールのテンプレート
+// どちらも共通のレイアウトと言語ごとの挨拶と署名を含む
+type templateSet struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// 言語ごと、テンプレートの名前ごとのテンプレート
+var templates = map[string]map[string]templateSet{}
+
+func init() {
+	for _, locale := range locales {
+		templates[locale] = map[string]templateSet{}
+
+		for _, name := range templateNames {
+			templates[locale][name] = parseTemplateSet(locale, name)
+		}
+	}
+}
+
+func parseTemplateSet(locale string, name string) templateSet {
+	funcs := map[string]any{
+		"locale": func() string { return locale },
+		"money":  util.FormatMoney,
+		"datetime": func(t time.Time) string {
+			return t.Format(dateTimeFormats[locale])
+		},
+		"lines": func(s string) []string {
+			return strings.Split(s, "\n")
+		},
+	}
+
+	dir := "templates/" + locale + "/"
+
+	return templateSet{
+		html: htmltemplate.Must(htmltemplate.New(name).
+			Funcs(funcs).
+			ParseFS(templateFS, "templates/layout.html", dir+"common.html", dir+name+".html")),
+		text: texttemplate.Must(texttemplate.New(name).
+			Funcs(funcs).
+			ParseFS(templateFS, "templates/layout.txt", dir+"common.txt", dir+name+".txt")),
+	}
+}
+
+// VerifyEmailData はメールアドレスの確認のメールに渡す値
+type VerifyEmailData struct {
+	User db.User
+	URL  string
+}
+
+// BeneficiaryAddedData は送金先が登録されたことを知らせるメールに渡す値
+type BeneficiaryAddedData struct {
+	User        db.User
+	Beneficiary db.Beneficiary
+}
+
+// PaymentRequestData は支払いの請求の状態を知らせるメールに渡す値
+// Other はメールを受け取る人から見た請求の相手のユーザー名
+type PaymentRequestData struct {
+	User    db.User
+	Request db.PaymentRequest
+	Other   string
+}
+
+// AlertData は口座の通知のルールが発火したことを知らせるメールに渡す値
+type AlertData struct {
+	User     db.User
+	Rule     db.AlertRule
+	Event    db.AlertEvent
+	Account  db.Account
+	Transfer db.Transfer
+}
+
+// NotificationData は notify のメールのチャネルで送る通知に渡す値
+// Body は改行を含むプレーンテキスト
+type NotificationData struct {
+	User    db.User
+	Subject string
+	Body    string
+}
+
+// EmailChangedData はメールアドレスが変わったことを変わる前のアドレスに知らせるメールに渡す値
+// User は変わった後のユーザーで、新しいアドレスはメールに載せない
+type EmailChangedData struct {
+	User      db.User
+	OldEmail  string
+	ChangedAt time.Time
+}
+
+// PasswordChangedData はパスワードが変わったことを知らせるメールに渡す値
+type PasswordChangedData struct {
+	User      db.User
+	ChangedAt time.Time
+}
+
+// DataExportData はエクスポートしたデータのダウンロードのリンクを知らせるメールに渡す値
+type DataExportData struct {
+	User      db.User
+	URL       string
+	ExpiresAt time.Time
+}
+
+// Render はユーザーの言語のテンプレートでメールの件名と本文を作る
+// 対応していない言語の場合は英語のテンプレートを使う
+func Render(name string, locale string, data any) (subject string, content Content, err error) {
+	set, ok := templates[resolveLocale(locale)][name]
+
+	if !ok {
+		return "", content, fmt.Errorf("unsupported email template: %s", name)
+	}
+
+	var buf bytes.Buffer
+
+	if err := set.text.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", content, fmt.Errorf("failed to render subject: %w", err)
+	}
+
+	subject = strings.TrimSpace(buf.String())
+	buf.Reset()
+
+	if err := set.text.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return "", content, fmt.Errorf("failed to render text content: %w", err)
+	}
+
+	content.Text = buf.String()
+	buf.Reset()
+
+	if err := set.html.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return "", content, fmt.Errorf("failed to render html content: %w", err)
+	}
+
+	content.HTML = buf.String()
+	content.Template = name
+
+	return subject, content, nil
+}
+
+func resolveLocale(locale string) string {
+	if util.IsSupportedLocale(locale) {
+		return locale
+	}
+
+	return util.DefaultLocale
+}
@@ -0,0 +1,159 @@
+package mail
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+// テンプレートを変更したときは go test ./mail -run TestRenderGolden -update で golden ファイルを作り直す
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRenderGolden(t *testing.T) {
+	// 名前はエスケープされることを確認する
+	user := db.User{Username: "alice", FullName: "Alice <Smith>"}
+	account := db.Account{ID: 1, Owner: user.Username, Currency: util.USD}
+	createdAt := time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		template string
+		data     any
+	}{
+		{
+			name:     "verify_email",
+			template: TemplateVerifyEmail,
+			data: VerifyEmailData{
+				User: user,
+				URL:  "http://localhost:8080/v1/verify_email?email_id=1&secret_code=abc",
+			},
+		},
+		{
+			name:     "beneficiary_added",
+			template: TemplateBeneficiaryAdded,
+			data: BeneficiaryAddedData{
+				User:        user,
+				Beneficiary: db.Beneficiary{Nickname: "Bob", AccountID: 2, CreatedAt: createdAt},
+			},
+		},
+		{
+			name:     "payment_request_pending",
+			template: TemplatePaymentRequest,
+			data: PaymentRequestData{
+				User:    user,
+				Request: db.PaymentRequest{Amount: 1500, Currency: util.USD, Status: util.PaymentRequestPending, Memo: "dinner"},
+				Other:   "bob",
+			},
+		},
+		{
+			name:     "payment_request_expired",
+			template: TemplatePaymentRequest,
+			data: PaymentRequestData{
+				User:    user,
+				Request: db.PaymentRequest{Amount: 1500, Currency: util.USD, Status: util.PaymentRequestExpired},
+				Other:   "bob",
+			},
+		},
+		{
+			name:     "low_balance",
+			template: TemplateLowBalance,
+			data: AlertData{
+				User:    user,
+				Rule:    db.AlertRule{Kind: util.AlertLowBalance, Threshold: 10000},
+				Event:   db.AlertEvent{Amount: 950},
+				Account: account,
+			},
+		},
+		{
+			name:     "large_incoming_transfer",
+			template: TemplateLargeIncomingTransfer,
+			data: AlertData{
+				User:     user,
+				Rule:     db.AlertRule{Kind: util.AlertLargeIncomingTransfer, Threshold: 100000},
+				Event:    db.AlertEvent{Amount: 250000},
+				Account:  account,
+				Transfer: db.Transfer{FromAccountID: 2, Memo: "salary", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:     "notification",
+			template: TemplateNotification,
+			data: NotificationData{
+				User:    user,
+				Subject: "You received a transfer",
+				Body:    "Account 1 received 10.00 USD from account 2.\nMemo: <b>rent</b>",
+			},
+		},
+		{
+			name:     "email_changed",
+			template: TemplateEmailChanged,
+			data: EmailChangedData{
+				User:      user,
+				OldEmail:  "alice@example.com",
+				ChangedAt: createdAt,
+			},
+		},
+		{
+			name:     "password_changed",
+			template: TemplatePasswordChanged,
+			data: PasswordChangedData{
+				User:      user,
+				ChangedAt: createdAt,
+			},
+		},
+		{
+			name:     "data_export",
+			template: TemplateDataExport,
+			data: DataExportData{
+				User:      user,
+				URL:       "http://localhost:8080/v1/data_exports/download?export_id=1&expires=1700000000&signature=abc",
+				ExpiresAt: createdAt,
+			},
+		},
+	}
+
+	for _, locale := range locales {
+		for i := range testCases {
+			tc := testCases[i]
+			t.Run(locale+"/"+tc.name, func(t *testing.T) {
+				subject, content, err := Render(tc.template, locale, tc.data)
+				require.NoError(t, err)
+
+				got := fmt.Sprintf("Subject: %s\n\n--- text/plain ---\n%s\n--- text/html ---\n%s", subject, content.Text, content.HTML)
+				golden := filepath.Join("testdata", locale, tc.name+".golden")
+
+				if *update {
+					require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+					require.NoError(t, os.WriteFile(golden, []byte(got), 0o644))
+				}
+
+				want, err := os.ReadFile(golden)
+				require.NoError(t, err)
+				require.Equal(t, string(want), got)
+			})
+		}
+	}
+}
+
+func TestRenderFallback(t *testing.T) {
+	data := VerifyEmailData{User: db.User{FullName: "Alice"}, URL: "http://localhost"}
+
+	// 対応していない言語は英語で送る
+	subject, content, err := Render(TemplateVerifyEmail, "fr", data)
+	require.NoError(t, err)
+
+	wantSubject, wantContent, err := Render(TemplateVerifyEmail, util.LocaleEnglish, data)
+	require.NoError(t, err)
+	require.Equal(t, wantSubject, subject)
+	require.Equal(t, wantContent, content)
+
+	_, _, err = Render("unknown", util.LocaleEnglish, data)
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	htmltemplate "html/template"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+// 記録した本文で秘密の値の代わりに入れる文字列
+const redacted = "[REDACTED]"
+
+// TrackingSender は送ったメールと送れなかったメールを email_deliveries に記録する
+// 記録に失敗してもメールは送れているので、ログに残すだけにして送信の結果を返す
+// 添付ファイルは記録しないので、送り直すときは添付ファイルなしで送る
+// 本文に含まれる Content.Secrets は伏せてから記録する
+type TrackingSender struct {
+	sender EmailSender
+	store  db.Store
+}
+
+func NewTrackingSender(sender EmailSender, store db.Store) EmailSender {
+	return &TrackingSender{sender: sender, store: store}
+}
+
+func (s *TrackingSender) SendEmail(
+	ctx context.Context,
+	subject string,
+	content Content,
+	to []string,
+	cc []string,
+	bcc []string,
+	attachFiles []string,
+) (string, error) {
+	messageID, sendErr := s.sender.SendEmail(ctx, subject, content, to, cc, bcc, attachFiles)
+
+	templateData := []byte("{}")
+
+	if len(content.TemplateData) > 0 {
+		// map[string]string は必ず JSON にできる
+		templateData, _ = json.Marshal(content.TemplateData)
+	}
+
+	arg := db.CreateEmailDeliveryParams{
+		Template:     content.Template,
+		Recipient:    strings.Join(to, ", "),
+		Cc:           strings.Join(cc, ", "),
+		Bcc:          strings.Join(bcc, ", "),
+		Subject:      subject,
+		ContentHtml:  redactSecrets(content.HTML, content.Secrets),
+		ContentText:  redactSecrets(content.Text, content.Secrets),
+		MessageID:    messageID,
+		Status:       util.EmailDeliverySent,
+		TemplateData: templateData,
+	}
+
+	if sendErr != nil {
+		arg.Status = util.EmailDeliveryFailed
+		arg.Error = sendErr.Error()
+	}
+
+	_, err := s.store.CreateEmailDelivery(ctx, arg)
+
+	if err != nil {
+		log.Error().Err(err).
+			Str("message_id", messageID).
+			Str("recipient", arg.Recipient).
+			Msg("cannot record email delivery")
+	}
+
+	return messageID, sendErr
+}
+
+// redactSecrets は本文から秘密の値を取り除く
+// HTML の本文では & などがエスケープされているので、エスケープした値も取り除く
+func redactSecrets(body string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+
+		body = strings.ReplaceAll(body, secret, redacted)
+		body = strings.ReplaceAll(body, htmltemplate.HTMLEscapeString(secret), redacted)
+	}
+
+	return body
+}
+
+// SplitRecipients は記録したカンマ区切りの宛先を送り直すために分ける
+func SplitRecipients(recipients string) []string {
+	var addresses []string
+
+	for _, address := range strings.Split(recipients, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
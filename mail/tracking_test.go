@@ -0,0 +1,88 @@
+package mail
+
+import (
+	"context"
+	"testing"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTrackingSender(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	memory := NewMemorySender()
+	sender := NewTrackingSender(memory, store)
+
+	content := Content{Template: TemplateVerifyEmail, HTML: "<p>Hello</p>", Text: "Hello"}
+	to := []string{"alice@example.com", "bob@example.com"}
+
+	store.EXPECT().
+		CreateEmailDelivery(gomock.Any(), gomock.Eq(db.CreateEmailDeliveryParams{
+			Template:     TemplateVerifyEmail,
+			Recipient:    "alice@example.com, bob@example.com",
+			Subject:      "A test email",
+			ContentHtml:  content.HTML,
+			ContentText:  content.Text,
+			MessageID:    "<1@memory>",
+			Status:       util.EmailDeliverySent,
+			TemplateData: []byte("{}"),
+		})).
+		Times(1).
+		Return(db.EmailDelivery{}, nil)
+
+	messageID, err := sender.SendEmail(context.Background(), "A test email", content, to, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "<1@memory>", messageID)
+	require.Len(t, memory.Sent(), 1)
+
+	// 記録した宛先は送り直すときに元の宛先に戻せる
+	require.Equal(t, to, SplitRecipients("alice@example.com, bob@example.com"))
+	require.Empty(t, SplitRecipients(""))
+}
+
+func TestTrackingSenderRedactsSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	memory := NewMemorySender()
+	sender := NewTrackingSender(memory, store)
+
+	user := db.User{Username: "alice", FullName: "Alice", Email: "alice@example.com"}
+	secretURL := "http://localhost:8080/v1/verify_email?email_id=1&expires=1700000000&secret_code=0123456789abcdef&signature=fedcba9876543210"
+
+	subject, content, err := Render(TemplateVerifyEmail, util.LocaleEnglish, VerifyEmailData{User: user, URL: secretURL})
+	require.NoError(t, err)
+
+	content.TemplateData = map[string]string{TemplateDataUsername: user.Username}
+	content.Secrets = []string{secretURL}
+
+	store.EXPECT().
+		CreateEmailDelivery(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateEmailDeliveryParams) (db.EmailDelivery, error) {
+			// 送ったメールにはリンクが載っていても、記録には残さない
+			for _, body := range []string{arg.ContentHtml, arg.ContentText} {
+				require.NotContains(t, body, "0123456789abcdef")
+				require.NotContains(t, body, "fedcba9876543210")
+				require.Contains(t, body, redacted)
+			}
+
+			require.JSONEq(t, `{"username": "alice"}`, string(arg.TemplateData))
+
+			return db.EmailDelivery{}, nil
+		})
+
+	_, err = sender.SendEmail(context.Background(), subject, content, []string{user.Email}, nil, nil, nil)
+	require.NoError(t, err)
+
+	sent := memory.Sent()
+	require.Len(t, sent, 1)
+	require.Contains(t, sent[0].Content.Text, secretURL)
+}
@@ -5,14 +5,19 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/shouta0715/simple-bank/gapi"
+	"github.com/shouta0715/simple-bank/link"
 	"github.com/shouta0715/simple-bank/mail"
+	"github.com/shouta0715/simple-bank/notify"
 	"github.com/shouta0715/simple-bank/pb"
+	"github.com/shouta0715/simple-bank/reconcile"
 	"github.com/shouta0715/simple-bank/util"
+	"github.com/shouta0715/simple-bank/validator"
 	"github.com/shouta0715/simple-bank/worker"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -31,6 +36,8 @@ import (
 	_ "github.com/shouta0715/simple-bank/doc/statik"
 )
 
+const currencyRefreshInterval = 5 * time.Minute
+
 func main() {
 
 	config, err := util.LoadConfig(".")
@@ -42,6 +49,13 @@ func main() {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
 
+	validator.SetPasswordPolicy(validator.PasswordPolicy{
+		MinLength:             config.PasswordMinLength,
+		MaxLength:             config.PasswordMaxLength,
+		RequireLetterAndDigit: config.PasswordLetterAndDigit,
+		RejectCommon:          config.PasswordRejectCommon,
+	})
+
 	connPool, err := pgxpool.New(context.Background(), config.DBSource)
 
 	if err != nil {
@@ -52,26 +66,59 @@ func main() {
 
 	store := db.NewStore(connPool)
 
+	err = db.LoadCurrencies(context.Background(), store)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot load currencies")
+	}
+
+	// simple-bank reconcile で台帳の照合を1回だけ実行して終了する
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconciliation(config, store)
+		return
+	}
+
 	redisOpt := asynq.RedisClientOpt{
 		Addr: config.RedisAddress,
 	}
 
 	taskDistributor := worker.NewRedisTaskDistributor(redisOpt)
 
-	go runTaskProcessor(config, redisOpt, store)
+	go runCurrencyRefresher(store)
+	go runTaskProcessor(config, redisOpt, store, taskDistributor)
+	go runTaskScheduler(config, redisOpt)
 	go runGrpcServer(config, store, taskDistributor)
 	runGatewayServer(config, store, taskDistributor)
 
 }
 
-func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store) {
-	mailer := mail.NewGmailSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword)
+func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store db.Store, taskDistributor worker.TaskDistributor) {
+	mailer, err := mail.NewEmailSender(config)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create email sender")
+	}
 
-	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, mailer)
+	// 送ったメールを email_deliveries に記録する
+	mailer = mail.NewTrackingSender(mailer, store)
+
+	notifier := notify.NewDispatcher(
+		store,
+		notify.NewEmailNotifier(mailer),
+		notify.NewSMSNotifier(notify.NewLogSMSProvider()),
+		notify.NewInboxNotifier(store),
+	)
+	links, err := link.NewBuilder(config.PublicBaseURL, config.LinkSigningKey, config.SignedLinkDuration)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create link builder")
+	}
+
+	taskProcessor := worker.NewRedisTaskProcessor(redisOpt, store, taskDistributor, mailer, notifier, links)
 
 	log.Info().Msg("starting task processor")
 
-	err := taskProcessor.Start()
+	err = taskProcessor.Start()
 
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot start task processor")
@@ -79,6 +126,63 @@ func runTaskProcessor(config util.Config, redisOpt asynq.RedisClientOpt, store d
 
 }
 
+// runCurrencyRefresher は currencies テーブルの変更を通貨キャッシュに反映する
+func runCurrencyRefresher(store db.Store) {
+	ticker := time.NewTicker(currencyRefreshInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := db.LoadCurrencies(context.Background(), store)
+
+		if err != nil {
+			log.Error().Err(err).Msg("cannot refresh currencies")
+		}
+	}
+}
+
+func runTaskScheduler(config util.Config, redisOpt asynq.RedisClientOpt) {
+	taskScheduler, err := worker.NewRedisTaskScheduler(redisOpt, config)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create task scheduler")
+	}
+
+	log.Info().Msg("starting task scheduler")
+
+	err = taskScheduler.Start()
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot start task scheduler")
+	}
+}
+
+func runReconciliation(config util.Config, store db.Store) {
+	mailer, err := mail.NewEmailSender(config)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create email sender")
+	}
+
+	// 送ったメールを email_deliveries に記録する
+	mailer = mail.NewTrackingSender(mailer, store)
+
+	reconciler := reconcile.NewReconciler(store, mailer, reconcile.DefaultBatchSize)
+
+	result, err := reconciler.Run(context.Background())
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot reconcile ledger")
+	}
+
+	if len(result.Reports) > 0 {
+		log.Error().
+			Str("run_id", result.RunID).
+			Int("discrepancies", len(result.Reports)).
+			Msg("ledger discrepancies found")
+		os.Exit(1)
+	}
+}
+
 func runDBMigrations(migrationURL, dbSource string) {
 	migration, err := migrate.New(migrationURL, dbSource)
 
@@ -136,7 +240,10 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 		},
 	})
 
-	grpcMux := runtime.NewServeMux(jsonOption)
+	grpcMux := runtime.NewServeMux(append(
+		[]runtime.ServeMuxOption{jsonOption},
+		gapi.VerifyEmailRedirect(config.VerifyEmailSuccessURL, config.VerifyEmailFailureURL)...,
+	)...)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -151,6 +258,7 @@ func runGatewayServer(config util.Config, store db.Store, taskDistributor worker
 
 	// gRPCを受け取る
 	mux.Handle("/", grpcMux)
+	mux.HandleFunc(link.DataExportPath, server.DownloadDataExport)
 
 	statikFs, err := fs.New()
 	if err != nil {
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+)
+
+// Dispatcher はユーザーのイベントごとの設定に従って、有効なチャネルに通知を送る
+type Dispatcher struct {
+	store db.Store
+	email Notifier
+	sms   Notifier
+	inApp Notifier
+}
+
+func NewDispatcher(store db.Store, email Notifier, sms Notifier, inApp Notifier) *Dispatcher {
+	return &Dispatcher{
+		store: store,
+		email: email,
+		sms:   sms,
+		inApp: inApp,
+	}
+}
+
+// DefaultPreference は設定を保存していないイベントに使う設定
+// SMS は電話番号を登録して有効にしたユーザーにだけ送る
+func DefaultPreference(username string, event string) db.NotificationPreference {
+	return db.NotificationPreference{
+		Username: username,
+		Event:    event,
+		Email:    true,
+		Sms:      false,
+		InApp:    true,
+	}
+}
+
+// Preference はユーザーがイベントに設定した通知のチャネルを返す
+func (dispatcher *Dispatcher) Preference(ctx context.Context, username string, event string) (db.NotificationPreference, error) {
+	preference, err := dispatcher.store.GetNotificationPreference(ctx, db.GetNotificationPreferenceParams{
+		Username: username,
+		Event:    event,
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return DefaultPreference(username, event), nil
+		}
+
+		return preference, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+
+	return preference, nil
+}
+
+// Notify は有効なすべてのチャネルに送る
+// 1つのチャネルで失敗しても残りのチャネルには送り、失敗したチャネルのエラーをまとめて返す
+func (dispatcher *Dispatcher) Notify(ctx context.Context, user db.User, message Message) error {
+	preference, err := dispatcher.Preference(ctx, user.Username, message.Event)
+
+	if err != nil {
+		return err
+	}
+
+	channels := []struct {
+		name     string
+		enabled  bool
+		notifier Notifier
+	}{
+		{ChannelInApp, preference.InApp, dispatcher.inApp},
+		{ChannelEmail, preference.Email, dispatcher.email},
+		{ChannelSMS, preference.Sms, dispatcher.sms},
+	}
+
+	var errs []error
+
+	for _, channel := range channels {
+		if !channel.enabled || channel.notifier == nil {
+			continue
+		}
+
+		if err := channel.notifier.Notify(ctx, user, message); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify by %s: %w", channel.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeNotifier struct {
+	sent []Message
+	err  error
+}
+
+func (notifier *fakeNotifier) Notify(ctx context.Context, user db.User, message Message) error {
+	notifier.sent = append(notifier.sent, message)
+	return notifier.err
+}
+
+func TestDispatcherNotify(t *testing.T) {
+	user := db.User{
+		Username:    util.RandomOwner(),
+		Email:       util.RandomEmail(),
+		PhoneNumber: "+819012345678",
+	}
+
+	message := Message{
+		Event:   util.NotificationTransferReceived,
+		Subject: "You received a transfer",
+		Body:    "Account 1 received 1.00 USD from account 2.",
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		emailErr   error
+		check      func(t *testing.T, email, sms, inApp *fakeNotifier, err error)
+	}{
+		{
+			name: "DefaultPreference",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Eq(db.GetNotificationPreferenceParams{
+						Username: user.Username,
+						Event:    message.Event,
+					})).
+					Times(1).
+					Return(db.NotificationPreference{}, db.ErrorRecordNotFound)
+			},
+			check: func(t *testing.T, email, sms, inApp *fakeNotifier, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []Message{message}, email.sent)
+				require.Empty(t, sms.sent)
+				require.Equal(t, []Message{message}, inApp.sent)
+			},
+		},
+		{
+			name: "SavedPreference",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.NotificationPreference{Username: user.Username, Event: message.Event, Sms: true}, nil)
+			},
+			check: func(t *testing.T, email, sms, inApp *fakeNotifier, err error) {
+				require.NoError(t, err)
+				require.Empty(t, email.sent)
+				require.Equal(t, []Message{message}, sms.sent)
+				require.Empty(t, inApp.sent)
+			},
+		},
+		{
+			name: "ChannelError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.NotificationPreference{Email: true, Sms: true, InApp: true}, nil)
+			},
+			emailErr: errors.New("smtp is down"),
+			check: func(t *testing.T, email, sms, inApp *fakeNotifier, err error) {
+				require.ErrorContains(t, err, "failed to notify by email")
+				// 失敗したチャネルの後のチャネルにも送る
+				require.Len(t, sms.sent, 1)
+				require.Len(t, inApp.sent, 1)
+			},
+		},
+		{
+			name: "PreferenceError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetNotificationPreference(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.NotificationPreference{}, errors.New("connection refused"))
+			},
+			check: func(t *testing.T, email, sms, inApp *fakeNotifier, err error) {
+				require.Error(t, err)
+				require.Empty(t, email.sent)
+				require.Empty(t, sms.sent)
+				require.Empty(t, inApp.sent)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			email := &fakeNotifier{err: tc.emailErr}
+			sms := &fakeNotifier{}
+			inApp := &fakeNotifier{}
+
+			dispatcher := NewDispatcher(store, email, sms, inApp)
+			err := dispatcher.Notify(context.Background(), user, message)
+
+			tc.check(t, email, sms, inApp, err)
+		})
+	}
+}
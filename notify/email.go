@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+)
+
+// EmailNotifier は mail.EmailSender でメールを送る
+type EmailNotifier struct {
+	mailer mail.EmailSender
+}
+
+func NewEmailNotifier(mailer mail.EmailSender) Notifier {
+	return &EmailNotifier{mailer: mailer}
+}
+
+func (notifier *EmailNotifier) Notify(ctx context.Context, user db.User, message Message) error {
+	if user.Email == "" {
+		return nil
+	}
+
+	subject, content, err := mail.Render(mail.TemplateNotification, user.Locale, mail.NotificationData{
+		User:    user,
+		Subject: message.Subject,
+		Body:    message.Body,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = notifier.mailer.SendEmail(ctx, subject, content, []string{user.Email}, nil, nil, nil)
+
+	return err
+}
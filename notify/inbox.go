@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+)
+
+// InboxNotifier はアプリの受信箱に通知を保存する
+type InboxNotifier struct {
+	store db.Store
+}
+
+func NewInboxNotifier(store db.Store) Notifier {
+	return &InboxNotifier{store: store}
+}
+
+func (notifier *InboxNotifier) Notify(ctx context.Context, user db.User, message Message) error {
+	_, err := notifier.store.CreateNotification(ctx, db.CreateNotificationParams{
+		Username: user.Username,
+		Event:    message.Event,
+		Subject:  message.Subject,
+		Content:  message.Body,
+	})
+
+	return err
+}
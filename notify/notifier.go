@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"context"
+
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+)
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelInApp = "in_app"
+)
+
+// Message はユーザーに送る通知の内容
+// Body は改行を含むプレーンテキストで、チャネルごとに必要な形式に変換する
+type Message struct {
+	Event   string
+	Subject string
+	Body    string
+}
+
+// Notifier は1つのチャネルでユーザーに通知を送る
+type Notifier interface {
+	Notify(ctx context.Context, user db.User, message Message) error
+}
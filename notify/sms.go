@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+)
+
+// SMSProvider は SMS を配信する外部のサービス
+type SMSProvider interface {
+	SendSMS(ctx context.Context, to string, body string) error
+}
+
+// LogSMSProvider は SMS を送らずにログに出力する
+// 開発環境などで SMS のサービスを契約していない場合に使う
+type LogSMSProvider struct{}
+
+func NewLogSMSProvider() SMSProvider {
+	return &LogSMSProvider{}
+}
+
+func (provider *LogSMSProvider) SendSMS(ctx context.Context, to string, body string) error {
+	log.Info().
+		Str("to", to).
+		Str("body", body).
+		Msg("sms is not sent by the local provider")
+
+	return nil
+}
+
+// SMSNotifier は電話番号を登録しているユーザーに件名と本文を SMS で送る
+type SMSNotifier struct {
+	provider SMSProvider
+}
+
+func NewSMSNotifier(provider SMSProvider) Notifier {
+	return &SMSNotifier{provider: provider}
+}
+
+func (notifier *SMSNotifier) Notify(ctx context.Context, user db.User, message Message) error {
+	if user.PhoneNumber == "" {
+		return nil
+	}
+
+	return notifier.provider.SendSMS(ctx, user.PhoneNumber, message.Subject+"\n"+message.Body)
+}
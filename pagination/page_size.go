@@ -0,0 +1,19 @@
+package pagination
+
+// AIP-158 に合わせて、page_size が 0 の場合は既定の件数を返し、上限を超える場合は上限に切り詰める
+const (
+	DefaultPageSize int32 = 10
+	MaxPageSize     int32 = 100
+)
+
+func PageSize(requested int32) int32 {
+	if requested <= 0 {
+		return DefaultPageSize
+	}
+
+	if requested > MaxPageSize {
+		return MaxPageSize
+	}
+
+	return requested
+}
@@ -0,0 +1,90 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const minKeySize = 32
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Cursor はキーセットページネーションで最後に返した行の位置
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id"`
+}
+
+// Signer はページトークンに署名し、改ざんされていないことを確認する
+// 署名には scope も含めるので、検索条件を変えて同じトークンを使うことはできない
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key string) (*Signer, error) {
+	if len(key) < minKeySize {
+		return nil, fmt.Errorf("invalid key size: must be at least %d characters", minKeySize)
+	}
+
+	return &Signer{key: []byte(key)}, nil
+}
+
+// Encode は cursor を scope に紐づいた不透明なトークンにする
+func (signer *Signer) Encode(scope string, cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(signer.sign(scope, encoded))
+
+	return encoded + "." + signature, nil
+}
+
+// Decode はトークンの署名を確認し、cursor を取り出す
+func (signer *Signer) Decode(scope string, token string) (Cursor, error) {
+	var cursor Cursor
+
+	encoded, signature, ok := strings.Cut(token, ".")
+
+	if !ok {
+		return cursor, ErrInvalidPageToken
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+
+	if err != nil || !hmac.Equal(mac, signer.sign(scope, encoded)) {
+		return cursor, ErrInvalidPageToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	if err != nil {
+		return cursor, ErrInvalidPageToken
+	}
+
+	err = json.Unmarshal(payload, &cursor)
+
+	if err != nil || cursor.ID <= 0 {
+		return cursor, ErrInvalidPageToken
+	}
+
+	return cursor, nil
+}
+
+func (signer *Signer) sign(scope string, encoded string) []byte {
+	mac := hmac.New(sha256.New, signer.key)
+	mac.Write([]byte(scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(encoded))
+
+	return mac.Sum(nil)
+}
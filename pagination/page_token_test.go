@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer, err := NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+
+	cursor := Cursor{
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		ID:        int64(util.RandomInt(1, 1000)),
+	}
+
+	token, err := signer.Encode("accounts:alice", cursor)
+	require.NoError(t, err)
+
+	decoded, err := signer.Decode("accounts:alice", token)
+	require.NoError(t, err)
+	require.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	require.Equal(t, cursor.ID, decoded.ID)
+
+	// 検索条件が違う場合は使えない
+	_, err = signer.Decode("accounts:bob", token)
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+
+	// 別の鍵で署名したトークンは使えない
+	other, err := NewSigner(util.RandomString(32))
+	require.NoError(t, err)
+	forged, err := other.Encode("accounts:alice", cursor)
+	require.NoError(t, err)
+
+	_, err = signer.Decode("accounts:alice", forged)
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = signer.Decode("accounts:alice", "invalid")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestNewSignerShortKey(t *testing.T) {
+	_, err := NewSigner(util.RandomString(16))
+	require.Error(t, err)
+}
+
+func TestPageSize(t *testing.T) {
+	require.Equal(t, DefaultPageSize, PageSize(0))
+	require.Equal(t, int32(5), PageSize(5))
+	require.Equal(t, MaxPageSize, PageSize(MaxPageSize+1))
+}
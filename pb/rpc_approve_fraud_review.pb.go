@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: rpc_approve_fraud_review.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ApproveFraudReviewRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReviewId int64 `protobuf:"varint,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+}
+
+func (x *ApproveFraudReviewRequest) Reset() {
+	*x = ApproveFraudReviewRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_approve_fraud_review_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveFraudReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveFraudReviewRequest) ProtoMessage() {}
+
+func (x *ApproveFraudReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_approve_fraud_review_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveFraudReviewRequest.ProtoReflect.Descriptor instead.
+func (*ApproveFraudReviewRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_approve_fraud_review_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApproveFraudReviewRequest) GetReviewId() int64 {
+	if x != nil {
+		return x.ReviewId
+	}
+	return 0
+}
+
+type ApproveFraudReviewResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Review      *FraudReview `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	Transfer    *Transfer    `protobuf:"bytes,2,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	FromAccount *Account     `protobuf:"bytes,3,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount   *Account     `protobuf:"bytes,4,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+}
+
+func (x *ApproveFraudReviewResponse) Reset() {
+	*x = ApproveFraudReviewResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_approve_fraud_review_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveFraudReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveFraudReviewResponse) ProtoMessage() {}
+
+func (x *ApproveFraudReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_approve_fraud_review_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveFraudReviewResponse.ProtoReflect.Descriptor instead.
+func (*ApproveFraudReviewResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_approve_fraud_review_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ApproveFraudReviewResponse) GetReview() *FraudReview {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+func (x *ApproveFraudReviewResponse) GetTransfer() *Transfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *ApproveFraudReviewResponse) GetFromAccount() *Account {
+	if x != nil {
+		return x.FromAccount
+	}
+	return nil
+}
+
+func (x *ApproveFraudReviewResponse) GetToAccount() *Account {
+	if x != nil {
+		return x.ToAccount
+	}
+	return nil
+}
+
+var File_rpc_approve_fraud_review_proto protoreflect.FileDescriptor
+
+var file_rpc_approve_fraud_review_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x5f, 0x66, 0x72,
+	0x61, 0x75, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x70, 0x62, 0x1a, 0x0d, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x66, 0x72, 0x61, 0x75, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x38, 0x0a, 0x19, 0x41, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x46, 0x72, 0x61, 0x75, 0x64, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x49,
+	0x64, 0x22, 0xcb, 0x01, 0x0a, 0x1a, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x61,
+	0x75, 0x64, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x27, 0x0a, 0x06, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x72, 0x61, 0x75, 0x64, 0x52, 0x65, 0x76, 0x69, 0x65,
+	0x77, 0x52, 0x06, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x28, 0x0a, 0x08, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x08, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x0a, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x09, 0x74, 0x6f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42,
+	0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68,
+	0x6f, 0x75, 0x74, 0x61, 0x30, 0x37, 0x31, 0x35, 0x2f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x2d,
+	0x62, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_approve_fraud_review_proto_rawDescOnce sync.Once
+	file_rpc_approve_fraud_review_proto_rawDescData = file_rpc_approve_fraud_review_proto_rawDesc
+)
+
+func file_rpc_approve_fraud_review_proto_rawDescGZIP() []byte {
+	file_rpc_approve_fraud_review_proto_rawDescOnce.Do(func() {
+		file_rpc_approve_fraud_review_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_approve_fraud_review_proto_rawDescData)
+	})
+	return file_rpc_approve_fraud_review_proto_rawDescData
+}
+
+var file_rpc_approve_fraud_review_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_approve_fraud_review_proto_goTypes = []interface{}{
+	(*ApproveFraudReviewRequest)(nil),  // 0: pb.ApproveFraudReviewRequest
+	(*ApproveFraudReviewResponse)(nil), // 1: pb.ApproveFraudReviewResponse
+	(*FraudReview)(nil),                // 2: pb.FraudReview
+	(*Transfer)(nil),                   // 3: pb.Transfer
+	(*Account)(nil),                    // 4: pb.Account
+}
+var file_rpc_approve_fraud_review_proto_depIdxs = []int32{
+	2, // 0: pb.ApproveFraudReviewResponse.review:type_name -> pb.FraudReview
+	3, // 1: pb.ApproveFraudReviewResponse.transfer:type_name -> pb.Transfer
+	4, // 2: pb.ApproveFraudReviewResponse.from_account:type_name -> pb.Account
+	4, // 3: pb.ApproveFraudReviewResponse.to_account:type_name -> pb.Account
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_rpc_approve_fraud_review_proto_init() }
+func file_rpc_approve_fraud_review_proto_init() {
+	if File_rpc_approve_fraud_review_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	file_fraud_review_proto_init()
+	file_transfer_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_approve_fraud_review_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveFraudReviewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_approve_fraud_review_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveFraudReviewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_approve_fraud_review_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_approve_fraud_review_proto_goTypes,
+		DependencyIndexes: file_rpc_approve_fraud_review_proto_depIdxs,
+		MessageInfos:      file_rpc_approve_fraud_review_proto_msgTypes,
+	}.Build()
+	File_rpc_approve_fraud_review_proto = out.File
+	file_rpc_approve_fraud_review_proto_rawDesc = nil
+	file_rpc_approve_fraud_review_proto_goTypes = nil
+	file_rpc_approve_fraud_review_proto_depIdxs = nil
+}
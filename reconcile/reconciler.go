@@ -0,0 +1,231 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+const (
+	KindAccountBalance    = "account_balance"
+	KindTransferImbalance = "transfer_imbalance"
+
+	DefaultBatchSize = 500
+)
+
+// Reconciler は台帳の不変条件を検証する
+//   - accounts.balance は その口座の entries の合計と一致する
+//   - 1つの transfer に紐づく entries の合計は 0 になる
+//
+// 検証は行ロックを取らない SELECT をバッチごとに実行するため、稼働中のDBに対して実行できる
+type Reconciler struct {
+	store     db.Store
+	mailer    mail.EmailSender
+	batchSize int32
+}
+
+type Result struct {
+	RunID            string
+	AccountsChecked  int
+	TransfersChecked int
+	Reports          []db.ReconciliationReport
+}
+
+func NewReconciler(store db.Store, mailer mail.EmailSender, batchSize int32) *Reconciler {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Reconciler{
+		store:     store,
+		mailer:    mailer,
+		batchSize: batchSize,
+	}
+}
+
+func (reconciler *Reconciler) Run(ctx context.Context) (*Result, error) {
+	runID, err := uuid.NewRandom()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run id: %w", err)
+	}
+
+	result := &Result{RunID: runID.String()}
+
+	if err := reconciler.checkAccounts(ctx, result); err != nil {
+		return result, err
+	}
+
+	if err := reconciler.checkTransfers(ctx, result); err != nil {
+		return result, err
+	}
+
+	log.Info().
+		Str("run_id", result.RunID).
+		Int("accounts_checked", result.AccountsChecked).
+		Int("transfers_checked", result.TransfersChecked).
+		Int("discrepancies", len(result.Reports)).
+		Msg("ledger reconciliation completed")
+
+	if len(result.Reports) > 0 {
+		if err := reconciler.alertBankers(ctx, result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (reconciler *Reconciler) checkAccounts(ctx context.Context, result *Result) error {
+	var afterID int64
+
+	for {
+		rows, err := reconciler.store.ListAccountEntryTotals(ctx, db.ListAccountEntryTotalsParams{
+			AfterID: afterID,
+			Limit:   reconciler.batchSize,
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to list account entry totals: %w", err)
+		}
+
+		for _, row := range rows {
+			result.AccountsChecked++
+
+			if row.Balance == row.EntriesTotal {
+				continue
+			}
+
+			report, err := reconciler.store.CreateReconciliationReport(ctx, db.CreateReconciliationReportParams{
+				RunID: result.RunID,
+				Kind:  KindAccountBalance,
+				AccountID: pgtype.Int8{
+					Int64: row.ID,
+					Valid: true,
+				},
+				Expected: row.EntriesTotal,
+				Actual:   row.Balance,
+			})
+
+			if err != nil {
+				return fmt.Errorf("failed to create reconciliation report: %w", err)
+			}
+
+			result.Reports = append(result.Reports, report)
+		}
+
+		if len(rows) < int(reconciler.batchSize) {
+			return nil
+		}
+
+		afterID = rows[len(rows)-1].ID
+	}
+}
+
+func (reconciler *Reconciler) checkTransfers(ctx context.Context, result *Result) error {
+	var afterID int64
+
+	for {
+		rows, err := reconciler.store.ListTransferEntryTotals(ctx, db.ListTransferEntryTotalsParams{
+			AfterID: afterID,
+			Limit:   reconciler.batchSize,
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to list transfer entry totals: %w", err)
+		}
+
+		for _, row := range rows {
+			result.TransfersChecked++
+
+			// entries に transfer_id が追加される前の送金は検証できない
+			if row.EntryCount == 0 || row.EntriesTotal == 0 {
+				continue
+			}
+
+			report, err := reconciler.store.CreateReconciliationReport(ctx, db.CreateReconciliationReportParams{
+				RunID: result.RunID,
+				Kind:  KindTransferImbalance,
+				TransferID: pgtype.Int8{
+					Int64: row.ID,
+					Valid: true,
+				},
+				Expected: 0,
+				Actual:   row.EntriesTotal,
+			})
+
+			if err != nil {
+				return fmt.Errorf("failed to create reconciliation report: %w", err)
+			}
+
+			result.Reports = append(result.Reports, report)
+		}
+
+		if len(rows) < int(reconciler.batchSize) {
+			return nil
+		}
+
+		afterID = rows[len(rows)-1].ID
+	}
+}
+
+func (reconciler *Reconciler) alertBankers(ctx context.Context, result *Result) error {
+	if reconciler.mailer == nil {
+		return nil
+	}
+
+	bankers, err := reconciler.store.ListUsersByRole(ctx, util.BankerRole)
+
+	if err != nil {
+		return fmt.Errorf("failed to list bankers: %w", err)
+	}
+
+	if len(bankers) == 0 {
+		log.Warn().Str("run_id", result.RunID).Msg("no banker to alert about ledger discrepancies")
+		return nil
+	}
+
+	to := make([]string, 0, len(bankers))
+	for _, banker := range bankers {
+		to = append(to, banker.Email)
+	}
+
+	subject := fmt.Sprintf("[Simple Bank] %d ledger discrepancies found", len(result.Reports))
+
+	var lines []string
+	for _, report := range result.Reports {
+		switch report.Kind {
+		case KindAccountBalance:
+			lines = append(lines, fmt.Sprintf("account %d: balance %d, entries total %d",
+				report.AccountID.Int64, report.Actual, report.Expected))
+		case KindTransferImbalance:
+			lines = append(lines, fmt.Sprintf("transfer %d: entries total %d",
+				report.TransferID.Int64, report.Actual))
+		}
+	}
+
+	text := fmt.Sprintf("Reconciliation run %s found discrepancies.\nAccounts checked: %d, transfers checked: %d\n\n%s\n",
+		result.RunID, result.AccountsChecked, result.TransfersChecked, strings.Join(lines, "\n"))
+
+	// 銀行員向けの報告なのでテンプレートは使わず、プレーンテキストをそのまま HTML にする
+	content := mail.Content{
+		HTML: strings.ReplaceAll(html.EscapeString(text), "\n", "<br/>\n"),
+		Text: text,
+	}
+
+	_, err = reconciler.mailer.SendEmail(ctx, subject, content, to, nil, nil, nil)
+
+	if err != nil {
+		return fmt.Errorf("failed to send reconciliation alert: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	mockdb "github.com/shouta0715/simple-bank/db/mock"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+	"github.com/shouta0715/simple-bank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconcilerRun(t *testing.T) {
+	banker := db.User{
+		Username: util.RandomOwner(),
+		Email:    util.RandomEmail(),
+		Role:     util.BankerRole,
+	}
+
+	testCases := []struct {
+		name        string
+		buildStubs  func(store *mockdb.MockStore)
+		checkResult func(t *testing.T, result *Result, sender *mail.MemorySender)
+	}{
+		{
+			name: "Balanced",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAccountEntryTotals(gomock.Any(), gomock.Eq(db.ListAccountEntryTotalsParams{AfterID: 0, Limit: 2})).
+					Times(1).
+					Return([]db.ListAccountEntryTotalsRow{
+						{ID: 1, Balance: 100, EntriesTotal: 100},
+						{ID: 2, Balance: 0, EntriesTotal: 0},
+					}, nil)
+				store.EXPECT().
+					ListAccountEntryTotals(gomock.Any(), gomock.Eq(db.ListAccountEntryTotalsParams{AfterID: 2, Limit: 2})).
+					Times(1).
+					Return([]db.ListAccountEntryTotalsRow{
+						{ID: 3, Balance: 50, EntriesTotal: 50},
+					}, nil)
+				store.EXPECT().
+					ListTransferEntryTotals(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListTransferEntryTotalsRow{
+						{ID: 1, EntryCount: 2, EntriesTotal: 0},
+					}, nil)
+				store.EXPECT().CreateReconciliationReport(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListUsersByRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResult: func(t *testing.T, result *Result, sender *mail.MemorySender) {
+				require.Equal(t, 3, result.AccountsChecked)
+				require.Equal(t, 1, result.TransfersChecked)
+				require.Empty(t, result.Reports)
+				require.Empty(t, sender.Sent())
+			},
+		},
+		{
+			name: "Discrepancies",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAccountEntryTotals(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListAccountEntryTotalsRow{
+						{ID: 1, Balance: 100, EntriesTotal: 90},
+					}, nil)
+				store.EXPECT().
+					ListTransferEntryTotals(gomock.Any(), gomock.Eq(db.ListTransferEntryTotalsParams{AfterID: 0, Limit: 2})).
+					Times(1).
+					Return([]db.ListTransferEntryTotalsRow{
+						{ID: 1, EntryCount: 1, EntriesTotal: -10},
+						// transfer_id が無かった頃の送金は対象外
+						{ID: 2, EntryCount: 0, EntriesTotal: 0},
+					}, nil)
+				store.EXPECT().
+					ListTransferEntryTotals(gomock.Any(), gomock.Eq(db.ListTransferEntryTotalsParams{AfterID: 2, Limit: 2})).
+					Times(1).
+					Return([]db.ListTransferEntryTotalsRow{}, nil)
+				store.EXPECT().
+					CreateReconciliationReport(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ context.Context, arg db.CreateReconciliationReportParams) (db.ReconciliationReport, error) {
+						return db.ReconciliationReport{
+							RunID:      arg.RunID,
+							Kind:       arg.Kind,
+							AccountID:  arg.AccountID,
+							TransferID: arg.TransferID,
+							Expected:   arg.Expected,
+							Actual:     arg.Actual,
+						}, nil
+					})
+				store.EXPECT().
+					ListUsersByRole(gomock.Any(), gomock.Eq(util.BankerRole)).
+					Times(1).
+					Return([]db.User{banker}, nil)
+			},
+			checkResult: func(t *testing.T, result *Result, sender *mail.MemorySender) {
+				require.Len(t, result.Reports, 2)
+
+				require.Equal(t, KindAccountBalance, result.Reports[0].Kind)
+				require.Equal(t, int64(1), result.Reports[0].AccountID.Int64)
+				require.Equal(t, int64(90), result.Reports[0].Expected)
+				require.Equal(t, int64(100), result.Reports[0].Actual)
+
+				require.Equal(t, KindTransferImbalance, result.Reports[1].Kind)
+				require.Equal(t, int64(1), result.Reports[1].TransferID.Int64)
+				require.Equal(t, int64(-10), result.Reports[1].Actual)
+
+				for _, report := range result.Reports {
+					require.Equal(t, result.RunID, report.RunID)
+				}
+
+				sent := sender.Sent()
+				require.Len(t, sent, 1)
+				require.Equal(t, []string{banker.Email}, sent[0].To)
+				require.Contains(t, sent[0].Content.Text, "transfer 1: entries total -10")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			sender := mail.NewMemorySender()
+			reconciler := NewReconciler(store, sender, 2)
+
+			result, err := reconciler.Run(context.Background())
+			require.NoError(t, err)
+			require.NotEmpty(t, result.RunID)
+
+			tc.checkResult(t, result, sender)
+		})
+	}
+}
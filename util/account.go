@@ -0,0 +1,30 @@
+package util
+
+const (
+	CheckingAccount = "checking"
+	SavingsAccount  = "savings"
+)
+
+func IsSupportedAccountType(accountType string) bool {
+	switch accountType {
+	case CheckingAccount, SavingsAccount:
+		return true
+	}
+	return false
+}
+
+// 銀行が所有するシステム口座の持ち主
+const (
+	FeeRevenueOwner      = "system_fees"
+	CashVaultOwner       = "system_vault"
+	InterestExpenseOwner = "system_interest"
+)
+
+// IsSystemAccountOwner は銀行が所有するシステム口座かどうかを返す
+func IsSystemAccountOwner(owner string) bool {
+	switch owner {
+	case FeeRevenueOwner, CashVaultOwner, InterestExpenseOwner:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package util
+
+// 口座ごとに設定する通知のルールの種類
+const (
+	// 残高が閾値を下回ったときに通知する
+	AlertLowBalance = "low_balance"
+	// 閾値を超える金額を受け取ったときに通知する
+	AlertLargeIncomingTransfer = "large_incoming_transfer"
+)
+
+func IsSupportedAlertKind(kind string) bool {
+	switch kind {
+	case AlertLowBalance, AlertLargeIncomingTransfer:
+		return true
+	}
+	return false
+}
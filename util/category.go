@@ -0,0 +1,22 @@
+package util
+
+// 送金の分類
+const (
+	CategoryGeneral   = "general"
+	CategoryRent      = "rent"
+	CategoryUtilities = "utilities"
+	CategoryFood      = "food"
+	CategoryShopping  = "shopping"
+	CategoryTravel    = "travel"
+	CategorySalary    = "salary"
+	CategoryOther     = "other"
+)
+
+func IsSupportedCategory(category string) bool {
+	switch category {
+	case CategoryGeneral, CategoryRent, CategoryUtilities, CategoryFood,
+		CategoryShopping, CategoryTravel, CategorySalary, CategoryOther:
+		return true
+	}
+	return false
+}
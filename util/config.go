@@ -7,18 +7,71 @@ import (
 )
 
 type Config struct {
-	ENVIRONMENT          string        `mapstructure:"ENVIRONMENT"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	MigrationURL         string        `mapstructure:"MIGRATION_URL"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	RedisAddress         string        `mapstructure:"REDIS_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	EmailSenderName      string        `mapstructure:"EMAIL_SENDER_NAME"`
-	EmailSenderAddress   string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
-	EmailSenderPassword  string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	ENVIRONMENT               string        `mapstructure:"ENVIRONMENT"`
+	DBSource                  string        `mapstructure:"DB_SOURCE"`
+	MigrationURL              string        `mapstructure:"MIGRATION_URL"`
+	HTTPServerAddress         string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	GRPCServerAddress         string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	RedisAddress              string        `mapstructure:"REDIS_ADDRESS"`
+	TokenSymmetricKey         string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	PageTokenKey              string        `mapstructure:"PAGE_TOKEN_KEY"`
+	AccessTokenDuration       time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration      time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	StepUpTokenDuration       time.Duration `mapstructure:"STEP_UP_TOKEN_DURATION"`
+	PublicBaseURL             string        `mapstructure:"PUBLIC_BASE_URL"`
+	LinkSigningKey            string        `mapstructure:"LINK_SIGNING_KEY"`
+	SignedLinkDuration        time.Duration `mapstructure:"SIGNED_LINK_DURATION"`
+	VerifyEmailSuccessURL     string        `mapstructure:"VERIFY_EMAIL_SUCCESS_URL"`
+	VerifyEmailResendInterval time.Duration `mapstructure:"VERIFY_EMAIL_RESEND_INTERVAL"`
+	VerifyEmailFailureURL     string        `mapstructure:"VERIFY_EMAIL_FAILURE_URL"`
+	EmailSenderName           string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress        string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	EmailSenderPassword       string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	EmailTransport            string        `mapstructure:"EMAIL_TRANSPORT"`
+	EmailMaildirPath          string        `mapstructure:"EMAIL_MAILDIR_PATH"`
+	SMTPHost                  string        `mapstructure:"SMTP_HOST"`
+	SMTPPort                  int           `mapstructure:"SMTP_PORT"`
+	SMTPSecurity              string        `mapstructure:"SMTP_SECURITY"`
+	SMTPAuth                  string        `mapstructure:"SMTP_AUTH"`
+	SMTPUsername              string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPoolSize              int           `mapstructure:"SMTP_POOL_SIZE"`
+	SMTPTimeout               time.Duration `mapstructure:"SMTP_TIMEOUT"`
+	DKIMDomain                string        `mapstructure:"DKIM_DOMAIN"`
+	DKIMSelector              string        `mapstructure:"DKIM_SELECTOR"`
+	DKIMPrivateKeyPath        string        `mapstructure:"DKIM_PRIVATE_KEY_PATH"`
+	ReconciliationSchedule    string        `mapstructure:"RECONCILIATION_SCHEDULE"`
+	InterestAccrualSchedule   string        `mapstructure:"INTEREST_ACCRUAL_SCHEDULE"`
+	FraudRulesPath            string        `mapstructure:"FRAUD_RULES_PATH"`
+	ApprovalTTL               time.Duration `mapstructure:"APPROVAL_TTL"`
+	PaymentRequestTTL         time.Duration `mapstructure:"PAYMENT_REQUEST_TTL"`
+	PaymentRequestSchedule    string        `mapstructure:"PAYMENT_REQUEST_SCHEDULE"`
+	PendingOperationSchedule  string        `mapstructure:"PENDING_OPERATION_SCHEDULE"`
+	Argon2Memory              uint32        `mapstructure:"ARGON2_MEMORY"`
+	Argon2Iterations          uint32        `mapstructure:"ARGON2_ITERATIONS"`
+	Argon2Parallelism         uint8         `mapstructure:"ARGON2_PARALLELISM"`
+	PasswordMinLength         int           `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordMaxLength         int           `mapstructure:"PASSWORD_MAX_LENGTH"`
+	PasswordLetterAndDigit    bool          `mapstructure:"PASSWORD_REQUIRE_LETTER_AND_DIGIT"`
+	PasswordRejectCommon      bool          `mapstructure:"PASSWORD_REJECT_COMMON"`
+}
+
+// PasswordParams は設定した argon2id のパラメーターを返す。設定していない値は既定の値を使う
+func (config Config) PasswordParams() PasswordParams {
+	params := DefaultPasswordParams
+
+	if config.Argon2Memory > 0 {
+		params.Memory = config.Argon2Memory
+	}
+
+	if config.Argon2Iterations > 0 {
+		params.Iterations = config.Argon2Iterations
+	}
+
+	if config.Argon2Parallelism > 0 {
+		params.Parallelism = config.Argon2Parallelism
+	}
+
+	return params
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -1,5 +1,11 @@
 package util
 
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
 const (
 	USD = "USD"
 	EUR = "EUR"
@@ -7,11 +13,82 @@ const (
 	JPY = "JPY"
 )
 
+type Currency struct {
+	Code string
+	// 補助単位の小数点以下の桁数 (USDのセントなら2, JPYなら0)
+	MinorUnit int32
+	Enabled   bool
+	// 銀行員の操作でこの金額 (補助単位) を超えると二人目の承認が必要になる. 0 なら承認を求めない
+	ApprovalThreshold int64
+}
+
+// currencies テーブルのキャッシュ
+// DBから読み込むまでは migration で登録される通貨を使う
+var currencyCache = struct {
+	sync.RWMutex
+	currencies map[string]Currency
+}{
+	currencies: map[string]Currency{
+		USD: {Code: USD, MinorUnit: 2, Enabled: true, ApprovalThreshold: 1000000},
+		EUR: {Code: EUR, MinorUnit: 2, Enabled: true, ApprovalThreshold: 1000000},
+		CAD: {Code: CAD, MinorUnit: 2, Enabled: true, ApprovalThreshold: 1000000},
+		JPY: {Code: JPY, MinorUnit: 0, Enabled: true, ApprovalThreshold: 1500000},
+	},
+}
+
+// SetCurrencies はキャッシュを currencies テーブルの内容で置き換える
+func SetCurrencies(currencies []Currency) {
+	cache := make(map[string]Currency, len(currencies))
+
+	for _, currency := range currencies {
+		cache[currency.Code] = currency
+	}
+
+	currencyCache.Lock()
+	defer currencyCache.Unlock()
+
+	currencyCache.currencies = cache
+}
+
+func GetCurrency(code string) (Currency, bool) {
+	currencyCache.RLock()
+	defer currencyCache.RUnlock()
+
+	currency, ok := currencyCache.currencies[code]
+	return currency, ok
+}
+
 func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, CAD, JPY:
-		return true
+	c, ok := GetCurrency(currency)
+	return ok && c.Enabled
+}
+
+// FormatMoney は補助単位の金額を通貨の桁数に合わせて表示用の文字列にする
+// 例: FormatMoney(1234, "USD") = "12.34 USD", FormatMoney(1234, "JPY") = "1234 JPY"
+func FormatMoney(units int64, currency string) string {
+	c, _ := GetCurrency(currency)
+
+	sign := ""
+	// -units は MinInt64 でオーバーフローするので uint64 で扱う
+	abs := uint64(units)
+	if units < 0 {
+		sign = "-"
+		abs = -abs
 	}
-	return false
 
+	digits := fmt.Sprintf("%d", abs)
+
+	if c.MinorUnit <= 0 {
+		return fmt.Sprintf("%s%s %s", sign, digits, currency)
+	}
+
+	minorUnit := int(c.MinorUnit)
+	if len(digits) <= minorUnit {
+		digits = strings.Repeat("0", minorUnit-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-minorUnit]
+	fraction := digits[len(digits)-minorUnit:]
+
+	return fmt.Sprintf("%s%s.%s %s", sign, whole, fraction, currency)
 }
@@ -0,0 +1,75 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMoney(t *testing.T) {
+	testCases := []struct {
+		name     string
+		units    int64
+		currency string
+		result   string
+	}{
+		{
+			name:     "USD",
+			units:    1234,
+			currency: USD,
+			result:   "12.34 USD",
+		},
+		{
+			name:     "LessThanOneUnit",
+			units:    5,
+			currency: EUR,
+			result:   "0.05 EUR",
+		},
+		{
+			name:     "Negative",
+			units:    -1234,
+			currency: CAD,
+			result:   "-12.34 CAD",
+		},
+		{
+			name:     "NoMinorUnit",
+			units:    1234,
+			currency: JPY,
+			result:   "1234 JPY",
+		},
+		{
+			name:     "MinInt64",
+			units:    math.MinInt64,
+			currency: USD,
+			result:   "-92233720368547758.08 USD",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.result, FormatMoney(tc.units, tc.currency))
+		})
+	}
+}
+
+func TestSetCurrencies(t *testing.T) {
+	original := []Currency{}
+	for _, code := range []string{USD, EUR, CAD, JPY} {
+		currency, ok := GetCurrency(code)
+		require.True(t, ok)
+		original = append(original, currency)
+	}
+	defer SetCurrencies(original)
+
+	SetCurrencies([]Currency{
+		{Code: USD, MinorUnit: 2, Enabled: true},
+		{Code: EUR, MinorUnit: 2, Enabled: false},
+	})
+
+	require.True(t, IsSupportedCurrency(USD))
+	require.False(t, IsSupportedCurrency(EUR))
+	require.False(t, IsSupportedCurrency(JPY))
+}
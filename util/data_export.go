@@ -0,0 +1,8 @@
+package util
+
+// ユーザーのデータの書き出しの状態
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+)
@@ -0,0 +1,15 @@
+package util
+
+// 送ったメールの記録の状態
+const (
+	EmailDeliverySent   = "sent"
+	EmailDeliveryFailed = "failed"
+)
+
+func IsSupportedEmailDeliveryStatus(status string) bool {
+	switch status {
+	case EmailDeliverySent, EmailDeliveryFailed:
+		return true
+	}
+	return false
+}
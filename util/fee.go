@@ -0,0 +1,17 @@
+package util
+
+const basisPointsPerUnit = 10000
+
+// CalculateFee は固定手数料と送金額に対するbps単位の手数料の合計を返す
+// 端数は切り捨てる
+func CalculateFee(amount int64, flatFee int64, percentFeeBps int64) int64 {
+	if amount <= 0 {
+		return flatFee
+	}
+
+	// amount * bps がオーバーフローしないように分割して計算する
+	percentFee := (amount/basisPointsPerUnit)*percentFeeBps +
+		(amount%basisPointsPerUnit)*percentFeeBps/basisPointsPerUnit
+
+	return flatFee + percentFee
+}
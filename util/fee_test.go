@@ -0,0 +1,64 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFee(t *testing.T) {
+	testCases := []struct {
+		name          string
+		amount        int64
+		flatFee       int64
+		percentFeeBps int64
+		fee           int64
+	}{
+		{
+			name:   "NoFee",
+			amount: 1000,
+			fee:    0,
+		},
+		{
+			name:    "FlatFeeOnly",
+			amount:  1000,
+			flatFee: 25,
+			fee:     25,
+		},
+		{
+			name:          "PercentFeeOnly",
+			amount:        10000,
+			percentFeeBps: 150,
+			fee:           150,
+		},
+		{
+			name:          "FlatAndPercentFee",
+			amount:        2000,
+			flatFee:       10,
+			percentFeeBps: 250,
+			fee:           60,
+		},
+		{
+			name:          "RoundDown",
+			amount:        99,
+			percentFeeBps: 100,
+			fee:           0,
+		},
+		{
+			name:          "NoOverflow",
+			amount:        math.MaxInt64 / 2,
+			percentFeeBps: 10000,
+			fee:           math.MaxInt64 / 2,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			fee := CalculateFee(tc.amount, tc.flatFee, tc.percentFeeBps)
+			require.Equal(t, tc.fee, fee)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package util
+
+const daysPerYear = 365
+
+// InterestRemainderUnit は remainder の単位 (1 / InterestRemainderUnit)
+const InterestRemainderUnit = basisPointsPerUnit * daysPerYear
+
+// AccrueDailyInterest は残高と年利(bps)から1日分の利息を計算する
+// 1未満の端数は remainder として返し、次の日の計算に繰り越す
+func AccrueDailyInterest(balance int64, annualRateBps int64, remainder int64) (amount int64, nextRemainder int64) {
+	if balance <= 0 || annualRateBps <= 0 {
+		return 0, remainder
+	}
+
+	// balance * bps がオーバーフローしないように分割して計算する
+	fraction := (balance%InterestRemainderUnit)*annualRateBps + remainder
+
+	amount = (balance/InterestRemainderUnit)*annualRateBps + fraction/InterestRemainderUnit
+	nextRemainder = fraction % InterestRemainderUnit
+
+	return amount, nextRemainder
+}
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccrueDailyInterest(t *testing.T) {
+	testCases := []struct {
+		name          string
+		balance       int64
+		annualRateBps int64
+		remainder     int64
+		amount        int64
+		nextRemainder int64
+	}{
+		{
+			name:          "WholeUnits",
+			balance:       InterestRemainderUnit,
+			annualRateBps: 100,
+			amount:        100,
+			nextRemainder: 0,
+		},
+		{
+			name:          "FractionOnly",
+			balance:       1000,
+			annualRateBps: 500,
+			amount:        0,
+			nextRemainder: 500000,
+		},
+		{
+			name:          "CarryRemainder",
+			balance:       1000,
+			annualRateBps: 500,
+			remainder:     InterestRemainderUnit - 1,
+			amount:        1,
+			nextRemainder: 499999,
+		},
+		{
+			name:          "NegativeBalance",
+			balance:       -1000,
+			annualRateBps: 500,
+			remainder:     10,
+			amount:        0,
+			nextRemainder: 10,
+		},
+		{
+			name:          "NoRate",
+			balance:       1000,
+			remainder:     10,
+			amount:        0,
+			nextRemainder: 10,
+		},
+		{
+			name:          "LargeBalanceDoesNotOverflow",
+			balance:       math.MaxInt64,
+			annualRateBps: 10000,
+			amount:        math.MaxInt64 / daysPerYear,
+			nextRemainder: (math.MaxInt64 % daysPerYear) * basisPointsPerUnit,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			amount, nextRemainder := AccrueDailyInterest(tc.balance, tc.annualRateBps, tc.remainder)
+			require.Equal(t, tc.amount, amount)
+			require.Equal(t, tc.nextRemainder, nextRemainder)
+		})
+	}
+}
+
+func TestAccrueDailyInterestForOneYear(t *testing.T) {
+	// 端数を繰り越すので、1年分の合計は年利と一致する
+	var total, remainder int64
+
+	for day := 0; day < daysPerYear; day++ {
+		var amount int64
+		amount, remainder = AccrueDailyInterest(10000, 500, remainder)
+		total += amount
+	}
+
+	require.Equal(t, int64(500), total)
+	require.Zero(t, remainder)
+}
@@ -0,0 +1,18 @@
+package util
+
+// メールの言語
+const (
+	LocaleEnglish  = "en"
+	LocaleJapanese = "ja"
+)
+
+// DefaultLocale は言語を設定していないユーザーと、対応していない言語に使う
+const DefaultLocale = LocaleEnglish
+
+func IsSupportedLocale(locale string) bool {
+	switch locale {
+	case LocaleEnglish, LocaleJapanese:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaskName は名前の各単語の先頭の1文字だけを残して伏せる
+// 伏せた部分の長さからも名前を推測できないように、伏せ字の数は固定にする
+func MaskName(name string) string {
+	words := strings.Fields(name)
+
+	for i, word := range words {
+		first, _ := utf8.DecodeRuneInString(word)
+		words[i] = string(first) + "***"
+	}
+
+	return strings.Join(words, " ")
+}
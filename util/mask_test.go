@@ -0,0 +1,14 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskName(t *testing.T) {
+	require.Equal(t, "S*** Y***", MaskName("Shouta Yamada"))
+	require.Equal(t, "A***", MaskName("  Al  "))
+	require.Equal(t, "山***", MaskName("山田"))
+	require.Equal(t, "", MaskName(""))
+}
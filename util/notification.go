@@ -0,0 +1,21 @@
+package util
+
+// ユーザーが通知を受け取るイベント
+const (
+	NotificationTransferReceived = "transfer_received"
+	NotificationNewLogin         = "new_login"
+)
+
+func IsSupportedNotificationEvent(event string) bool {
+	switch event {
+	case NotificationTransferReceived, NotificationNewLogin:
+		return true
+	}
+	return false
+}
+
+// NotificationEvents は設定を一覧で返すときの順番
+var NotificationEvents = []string{
+	NotificationTransferReceived,
+	NotificationNewLogin,
+}
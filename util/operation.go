@@ -0,0 +1,17 @@
+package util
+
+// 二人目の銀行員の承認が必要な操作の種類
+const (
+	OperationTransfer = "transfer"
+	OperationDeposit  = "deposit"
+	OperationWithdraw = "withdraw"
+)
+
+// 監査ログの対象と操作
+const (
+	AuditResourcePendingOperation = "pending_operation"
+
+	AuditActionRequest = "request"
+	AuditActionApprove = "approve"
+	AuditActionReject  = "reject"
+)
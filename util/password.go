@@ -1,24 +1,160 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
 
+	"golang.org/x/crypto/argon2"
 	bycript "golang.org/x/crypto/bcrypt"
 )
 
+// パスワードのハッシュは PHC 形式の文字列で保存し、先頭の識別子でアルゴリズムを見分ける
+// argon2id: $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>
+// bcrypt: $2a$10$... 以前に作ったハッシュで、ログインしたときに argon2id に作り直す
+const argon2idPrefix = "$argon2id$"
+
+var (
+	ErrMismatchedPassword      = errors.New("password does not match")
+	ErrUnsupportedPasswordHash = errors.New("unsupported password hash")
+)
+
+// PasswordParams は argon2id のパラメーター。Memory の単位は KiB
+type PasswordParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultPasswordParams は OWASP が推奨する argon2id の最低限の設定
+var DefaultPasswordParams = PasswordParams{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// HashPassword は既定のパラメーターの argon2id でパスワードのハッシュを作る
 func HashPassword(password string) (string, error) {
+	return HashPasswordWithParams(password, DefaultPasswordParams)
+}
 
-	hashedPassword, err := bycript.GenerateFromPassword([]byte(password), bycript.DefaultCost)
+// HashPasswordWithParams は設定したパラメーターの argon2id でパスワードのハッシュを作る
+// bcrypt と違って、72 バイトを超えるパスワードも切り詰めずにハッシュにする
+func HashPasswordWithParams(password string, params PasswordParams) (string, error) {
+	salt := make([]byte, params.SaltLength)
 
-	if err != nil {
+	if _, err := rand.Read(salt); err != nil {
 		return "", fmt.Errorf("error hashing password: %w", err)
 	}
 
-	return string(hashedPassword), nil
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
 }
 
+// CheckPassword は保存したハッシュの先頭の識別子に合わせてパスワードを確かめる
 func CheckPassword(password, hashedPassword string) error {
+	switch {
+	case strings.HasPrefix(hashedPassword, argon2idPrefix):
+		params, salt, key, err := decodeArgon2id(hashedPassword)
+
+		if err != nil {
+			return err
+		}
+
+		actual := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
 
-	return bycript.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+		if subtle.ConstantTimeCompare(actual, key) != 1 {
+			return ErrMismatchedPassword
+		}
+
+		return nil
+	case isBcryptHash(hashedPassword):
+		err := bycript.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+
+		if errors.Is(err, bycript.ErrMismatchedHashAndPassword) {
+			return ErrMismatchedPassword
+		}
+
+		return err
+	}
+
+	return ErrUnsupportedPasswordHash
+}
+
+// NeedsRehash はハッシュを今の設定で作り直すべきかどうかを返す
+// bcrypt のハッシュや、パラメーターを変える前に作った argon2id のハッシュの場合は true を返す
+func NeedsRehash(hashedPassword string, params PasswordParams) bool {
+	if !strings.HasPrefix(hashedPassword, argon2idPrefix) {
+		return true
+	}
+
+	current, salt, key, err := decodeArgon2id(hashedPassword)
+
+	if err != nil {
+		return true
+	}
+
+	return current.Memory != params.Memory ||
+		current.Iterations != params.Iterations ||
+		current.Parallelism != params.Parallelism ||
+		uint32(len(salt)) != params.SaltLength ||
+		uint32(len(key)) != params.KeyLength
+}
+
+func decodeArgon2id(hashedPassword string) (params PasswordParams, salt []byte, key []byte, err error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(hashedPassword, "$")
+
+	if len(parts) != 6 {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	var version int
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+
+	if err != nil {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+
+	if err != nil {
+		return params, nil, nil, ErrUnsupportedPasswordHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+func isBcryptHash(hashedPassword string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(hashedPassword, prefix) {
+			return true
+		}
+	}
 
+	return false
 }
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,16 +14,52 @@ func TestPassword(t *testing.T) {
 	hashedPassword1, err := HashPassword(password)
 	require.NoError(t, err)
 	require.NotEmpty(t, hashedPassword1)
+	require.True(t, strings.HasPrefix(hashedPassword1, "$argon2id$v=19$m=19456,t=2,p=1$"))
 
 	err = CheckPassword(password, hashedPassword1)
 	require.NoError(t, err)
 
 	wrongPassword := RandomString(10)
 	err = CheckPassword(wrongPassword, hashedPassword1)
-	require.EqualError(t, err, bycript.ErrMismatchedHashAndPassword.Error())
+	require.ErrorIs(t, err, ErrMismatchedPassword)
 
 	hashedPassword2, err := HashPassword(password)
 	require.NoError(t, err)
 	require.NotEmpty(t, hashedPassword1)
 	require.NotEqual(t, hashedPassword1, hashedPassword2)
 }
+
+func TestLongPassword(t *testing.T) {
+	// bcrypt は 72 バイトで切り詰めるので、73 バイト目だけが違うパスワードを区別できない
+	password := strings.Repeat("a", 72)
+
+	hashedPassword, err := HashPassword(password + "b")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, CheckPassword(password+"c", hashedPassword), ErrMismatchedPassword)
+	require.NoError(t, CheckPassword(password+"b", hashedPassword))
+}
+
+func TestLegacyBcryptPassword(t *testing.T) {
+	password := RandomString(10)
+
+	hashedPassword, err := bycript.GenerateFromPassword([]byte(password), bycript.MinCost)
+	require.NoError(t, err)
+
+	require.NoError(t, CheckPassword(password, string(hashedPassword)))
+	require.ErrorIs(t, CheckPassword(RandomString(10), string(hashedPassword)), ErrMismatchedPassword)
+	require.True(t, NeedsRehash(string(hashedPassword), DefaultPasswordParams))
+
+	require.ErrorIs(t, CheckPassword(password, "plaintext"), ErrUnsupportedPasswordHash)
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hashedPassword, err := HashPassword(RandomString(10))
+	require.NoError(t, err)
+
+	require.False(t, NeedsRehash(hashedPassword, DefaultPasswordParams))
+
+	stronger := DefaultPasswordParams
+	stronger.Iterations++
+	require.True(t, NeedsRehash(hashedPassword, stronger))
+}
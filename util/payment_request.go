@@ -0,0 +1,12 @@
+package util
+
+// ユーザー間の支払いの請求の状態
+const (
+	PaymentRequestPending = "pending"
+	// 支払いの送金が不正検知のルールで保留され、審査を待っている
+	PaymentRequestHeld      = "held"
+	PaymentRequestPaid      = "paid"
+	PaymentRequestDeclined  = "declined"
+	PaymentRequestCancelled = "cancelled"
+	PaymentRequestExpired   = "expired"
+)
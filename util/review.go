@@ -0,0 +1,20 @@
+package util
+
+// 審査や承認待ちの操作の状態
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+	// ユーザーを削除したので審査しない
+	ReviewCancelled = "cancelled"
+	// 承認されないまま期限が切れた操作
+	ReviewExpired = "expired"
+)
+
+func IsSupportedReviewStatus(status string) bool {
+	switch status {
+	case ReviewPending, ReviewApproved, ReviewRejected, ReviewCancelled, ReviewExpired:
+		return true
+	}
+	return false
+}
@@ -1,5 +1,10 @@
 package util
+
 const (
 	DepositorRole = "depositor"
-	BankerRole = "banker"
-)
\ No newline at end of file
+	BankerRole    = "banker"
+	SystemRole    = "system"
+	// パスワードを確かめ直したことを示すトークンの role
+	// どの RPC でも受け付けないので、アクセストークンとしては使えない
+	StepUpRole = "step_up"
+)
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// HashSecret はリフレッシュトークンや確認コードのように、データベースに平文で保存しない秘密の値の SHA-256 を返す
+// どちらも十分に長いランダムな値なので、パスワードと違って遅いハッシュ関数は使わない
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckSecret は秘密の値が保存したハッシュと一致するかを一定時間で比べる
+func CheckSecret(secret string, hashedSecret string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashSecret(secret)), []byte(hashedSecret)) == 1
+}
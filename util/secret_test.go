@@ -0,0 +1,22 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	secret := RandomString(32)
+
+	hashedSecret := HashSecret(secret)
+	require.Len(t, hashedSecret, 64)
+	require.NotContains(t, hashedSecret, secret)
+
+	// 同じ値からは同じハッシュを作るので、保存したハッシュと比べられる
+	require.Equal(t, hashedSecret, HashSecret(secret))
+	require.True(t, CheckSecret(secret, hashedSecret))
+
+	require.False(t, CheckSecret(RandomString(32), hashedSecret))
+	require.False(t, CheckSecret(secret, ""))
+}
@@ -0,0 +1,95 @@
+package validator
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// 漏洩したパスワードの一覧によく出てくるパスワード。小文字で 1 行に 1 つ書く
+//
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = sync.OnceValue(func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			passwords[strings.ToLower(line)] = struct{}{}
+		}
+	}
+
+	return passwords
+})
+
+// PasswordPolicy は新しく設定するパスワードに求める条件
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// 英字と数字をそれぞれ 1 文字以上含める
+	RequireLetterAndDigit bool
+	// よく使われるパスワードを弾く
+	RejectCommon bool
+}
+
+// DefaultPasswordPolicy は SetPasswordPolicy で設定しない場合の条件
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:    8,
+	MaxLength:    128,
+	RejectCommon: true,
+}
+
+var passwordPolicy = DefaultPasswordPolicy
+
+// SetPasswordPolicy は ValidatePassword で使う条件を設定する。サーバーを起動する前に 1 度だけ呼ぶ
+// 0 の長さは既定の値を使う
+func SetPasswordPolicy(policy PasswordPolicy) {
+	if policy.MinLength <= 0 {
+		policy.MinLength = DefaultPasswordPolicy.MinLength
+	}
+
+	if policy.MaxLength <= 0 {
+		policy.MaxLength = DefaultPasswordPolicy.MaxLength
+	}
+
+	passwordPolicy = policy
+}
+
+// ValidatePassword は新しく設定するパスワードが条件を満たすかを確認する
+func ValidatePassword(password string) error {
+	policy := passwordPolicy
+
+	if err := validateString(password, policy.MinLength, policy.MaxLength); err != nil {
+		return err
+	}
+
+	if policy.RequireLetterAndDigit {
+		var hasLetter, hasDigit bool
+
+		for _, r := range password {
+			hasLetter = hasLetter || unicode.IsLetter(r)
+			hasDigit = hasDigit || unicode.IsDigit(r)
+		}
+
+		if !hasLetter || !hasDigit {
+			return fmt.Errorf("password must contain both letters and digits")
+		}
+	}
+
+	if policy.RejectCommon {
+		if _, ok := commonPasswords()[strings.ToLower(password)]; ok {
+			return fmt.Errorf("password is too common")
+		}
+	}
+
+	return nil
+}
+
+// ValidateLoginPassword はログインで受け取るパスワードを確認する
+// 条件を厳しくしても既存のユーザーがログインできるように、長さの上限だけを確認する
+func ValidateLoginPassword(password string) error {
+	return validateString(password, 1, passwordPolicy.MaxLength)
+}
@@ -4,11 +4,16 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+	"strings"
+
+	"github.com/shouta0715/simple-bank/util"
 )
 
 var (
 	isValidUsername = regexp.MustCompile(`^[a-zA-Z0-9_]+$`).MatchString
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
+	// E.164 形式の電話番号
+	isValidPhoneNumber = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`).MatchString
 )
 
 func validateString(value string, min int, max int) error {
@@ -35,15 +40,6 @@ func ValidateUsername(username string) error {
 	return nil
 }
 
-func ValidatePassword(password string) error {
-
-	if err := validateString(password, 6, 100); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func ValidateEmail(value string) error {
 
 	if err := validateString(value, 3, 50); err != nil {
@@ -80,3 +76,196 @@ func ValidateEmailID(value int64) error {
 func ValidateSecretCode(value string) error {
 	return validateString(value, 32, 128)
 }
+
+func ValidateAccountID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("account ID must be positive")
+	}
+
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("unsupported currency: %s", value)
+	}
+
+	return nil
+}
+
+func ValidatePageID(value int32) error {
+	if value < 1 {
+		return fmt.Errorf("page ID must be greater than or equal to 1")
+	}
+
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 5 || value > 10 {
+		return fmt.Errorf("page size must be between 5 and 10")
+	}
+
+	return nil
+}
+
+// ValidateRecipient は送金先のユーザー名またはメールアドレスを確認する
+func ValidateRecipient(value string) error {
+	if strings.Contains(value, "@") {
+		return ValidateEmail(value)
+	}
+
+	return ValidateUsername(value)
+}
+
+func ValidateNickname(value string) error {
+	return validateString(value, 1, 50)
+}
+
+func ValidateBeneficiaryID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("beneficiary ID must be positive")
+	}
+
+	return nil
+}
+
+func ValidateReviewID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("review ID must be positive")
+	}
+
+	return nil
+}
+
+func ValidateOperationID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("operation ID must be positive")
+	}
+
+	return nil
+}
+
+func ValidateReviewStatus(value string) error {
+	if !util.IsSupportedReviewStatus(value) {
+		return fmt.Errorf("unsupported review status: %s", value)
+	}
+
+	return nil
+}
+
+func ValidatePaymentRequestID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("payment request ID must be positive")
+	}
+
+	return nil
+}
+
+func ValidateMemo(value string) error {
+	return validateString(value, 0, 140)
+}
+
+func ValidateReference(value string) error {
+	return validateString(value, 0, 64)
+}
+
+// ValidateCategory は送金の分類を確認する。空の場合は分類しない
+func ValidateCategory(value string) error {
+	if value != "" && !util.IsSupportedCategory(value) {
+		return fmt.Errorf("unsupported category: %s", value)
+	}
+
+	return nil
+}
+
+// ValidateListPageSize はキーセットページネーションの page_size を確認する
+// 0 の場合は既定の件数を使い、上限を超える場合は上限に切り詰めるので、負の値だけを弾く
+func ValidateListPageSize(value int32) error {
+	if value < 0 {
+		return fmt.Errorf("page size must not be negative")
+	}
+
+	return nil
+}
+
+// ValidatePhoneNumber は E.164 形式の電話番号を確認する。空の場合は電話番号を削除する
+func ValidatePhoneNumber(value string) error {
+	if value != "" && !isValidPhoneNumber(value) {
+		return fmt.Errorf("phone number must be in E.164 format such as +819012345678")
+	}
+
+	return nil
+}
+
+func ValidateLocale(value string) error {
+	if !util.IsSupportedLocale(value) {
+		return fmt.Errorf("unsupported locale: %s", value)
+	}
+
+	return nil
+}
+
+func ValidateNotificationEvent(value string) error {
+	if !util.IsSupportedNotificationEvent(value) {
+		return fmt.Errorf("unsupported notification event: %s", value)
+	}
+
+	return nil
+}
+
+// ValidateNotificationIDs は一度に既読にする通知の ID を確認する
+func ValidateNotificationIDs(values []int64) error {
+	if len(values) == 0 || len(values) > 100 {
+		return fmt.Errorf("number of notification IDs must be between 1 and 100")
+	}
+
+	for _, value := range values {
+		if value <= 0 {
+			return fmt.Errorf("notification ID must be positive")
+		}
+	}
+
+	return nil
+}
+
+func ValidateAlertKind(value string) error {
+	if !util.IsSupportedAlertKind(value) {
+		return fmt.Errorf("unsupported alert kind: %s", value)
+	}
+
+	return nil
+}
+
+func ValidateAlertRuleID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("alert rule ID must be positive")
+	}
+
+	return nil
+}
+
+func ValidateEmailDeliveryID(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("email delivery ID must be positive")
+	}
+
+	return nil
+}
+
+// ValidateEmailDeliveryStatus は検索するメールの送信状態を確認する。空の場合は状態で絞り込まない
+func ValidateEmailDeliveryStatus(value string) error {
+	if value != "" && !util.IsSupportedEmailDeliveryStatus(value) {
+		return fmt.Errorf("unsupported email delivery status: %s", value)
+	}
+
+	return nil
+}
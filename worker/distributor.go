@@ -13,6 +13,51 @@ type TaskDistributor interface {
 		payload *PayloadSendVerifyEmail,
 		opts ...asynq.Option,
 	) error
+	DistributeTaskSendBeneficiaryEmail(
+		ctx context.Context,
+		payload *PayloadSendBeneficiaryEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendPaymentRequestEmail(
+		ctx context.Context,
+		payload *PayloadSendPaymentRequestEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyTransfer(
+		ctx context.Context,
+		payload *PayloadNotifyTransfer,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskNotifyLogin(
+		ctx context.Context,
+		payload *PayloadNotifyLogin,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskEvaluateAlerts(
+		ctx context.Context,
+		payload *PayloadEvaluateAlerts,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskResendEmail(
+		ctx context.Context,
+		payload *PayloadResendEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendEmailChangedEmail(
+		ctx context.Context,
+		payload *PayloadSendEmailChangedEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskSendPasswordChangedEmail(
+		ctx context.Context,
+		payload *PayloadSendPasswordChangedEmail,
+		opts ...asynq.Option,
+	) error
+	DistributeTaskExportUserData(
+		ctx context.Context,
+		payload *PayloadExportUserData,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
@@ -40,6 +40,177 @@ func (m *MockTaskDistributor) EXPECT() *MockTaskDistributorMockRecorder {
 	return m.recorder
 }
 
+// DistributeTaskEvaluateAlerts mocks base method.
+func (m *MockTaskDistributor) DistributeTaskEvaluateAlerts(arg0 context.Context, arg1 *worker.PayloadEvaluateAlerts, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskEvaluateAlerts", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskEvaluateAlerts indicates an expected call of DistributeTaskEvaluateAlerts.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskEvaluateAlerts(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskEvaluateAlerts", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskEvaluateAlerts), varargs...)
+}
+
+// DistributeTaskExportUserData mocks base method.
+func (m *MockTaskDistributor) DistributeTaskExportUserData(arg0 context.Context, arg1 *worker.PayloadExportUserData, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskExportUserData", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskExportUserData indicates an expected call of DistributeTaskExportUserData.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskExportUserData(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskExportUserData", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskExportUserData), varargs...)
+}
+
+// DistributeTaskNotifyLogin mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyLogin(arg0 context.Context, arg1 *worker.PayloadNotifyLogin, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyLogin", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyLogin indicates an expected call of DistributeTaskNotifyLogin.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyLogin(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyLogin", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyLogin), varargs...)
+}
+
+// DistributeTaskNotifyTransfer mocks base method.
+func (m *MockTaskDistributor) DistributeTaskNotifyTransfer(arg0 context.Context, arg1 *worker.PayloadNotifyTransfer, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskNotifyTransfer", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskNotifyTransfer indicates an expected call of DistributeTaskNotifyTransfer.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskNotifyTransfer(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskNotifyTransfer", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskNotifyTransfer), varargs...)
+}
+
+// DistributeTaskResendEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskResendEmail(arg0 context.Context, arg1 *worker.PayloadResendEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskResendEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskResendEmail indicates an expected call of DistributeTaskResendEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskResendEmail(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskResendEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskResendEmail), varargs...)
+}
+
+// DistributeTaskSendBeneficiaryEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendBeneficiaryEmail(arg0 context.Context, arg1 *worker.PayloadSendBeneficiaryEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendBeneficiaryEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendBeneficiaryEmail indicates an expected call of DistributeTaskSendBeneficiaryEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendBeneficiaryEmail(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendBeneficiaryEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendBeneficiaryEmail), varargs...)
+}
+
+// DistributeTaskSendEmailChangedEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendEmailChangedEmail(arg0 context.Context, arg1 *worker.PayloadSendEmailChangedEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendEmailChangedEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendEmailChangedEmail indicates an expected call of DistributeTaskSendEmailChangedEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendEmailChangedEmail(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendEmailChangedEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendEmailChangedEmail), varargs...)
+}
+
+// DistributeTaskSendPasswordChangedEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendPasswordChangedEmail(arg0 context.Context, arg1 *worker.PayloadSendPasswordChangedEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendPasswordChangedEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendPasswordChangedEmail indicates an expected call of DistributeTaskSendPasswordChangedEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPasswordChangedEmail(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPasswordChangedEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPasswordChangedEmail), varargs...)
+}
+
+// DistributeTaskSendPaymentRequestEmail mocks base method.
+func (m *MockTaskDistributor) DistributeTaskSendPaymentRequestEmail(arg0 context.Context, arg1 *worker.PayloadSendPaymentRequestEmail, arg2 ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskSendPaymentRequestEmail", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskSendPaymentRequestEmail indicates an expected call of DistributeTaskSendPaymentRequestEmail.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskSendPaymentRequestEmail(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskSendPaymentRequestEmail", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskSendPaymentRequestEmail), varargs...)
+}
+
 // DistributeTaskSendVerifyEmail mocks base method.
 func (m *MockTaskDistributor) DistributeTaskSendVerifyEmail(arg0 context.Context, arg1 *worker.PayloadSendVerifyEmail, arg2 ...asynq.Option) error {
 	m.ctrl.T.Helper()
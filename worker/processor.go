@@ -2,12 +2,15 @@ package worker
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/link"
 	"github.com/shouta0715/simple-bank/mail"
+	"github.com/shouta0715/simple-bank/notify"
 )
 
 const (
@@ -19,15 +22,41 @@ const (
 type TaskProcessor interface {
 	Start() error
 	ProcessTaskSendVerifyEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskReconcileLedger(ctx context.Context, task *asynq.Task) error
+	ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendBeneficiaryEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendPaymentRequestEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExpirePaymentRequests(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExpirePendingOperations(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyTransfer(ctx context.Context, task *asynq.Task) error
+	ProcessTaskNotifyLogin(ctx context.Context, task *asynq.Task) error
+	ProcessTaskEvaluateAlerts(ctx context.Context, task *asynq.Task) error
+	ProcessTaskResendEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendEmailChangedEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskSendPasswordChangedEmail(ctx context.Context, task *asynq.Task) error
+	ProcessTaskExportUserData(ctx context.Context, task *asynq.Task) error
 }
 
 type RedisTaskProcessor struct {
 	server *asynq.Server
 	store  db.Store
-	mailer mail.EmailSender
+	// 処理の中で次のタスクを登録する
+	distributor TaskDistributor
+	mailer      mail.EmailSender
+	// ユーザーの設定に従って通知を送る
+	notifier notify.Notifier
+	// メールに載せるリンクを作る
+	links *link.Builder
 }
 
-func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer mail.EmailSender) TaskProcessor {
+func NewRedisTaskProcessor(
+	redisOpt asynq.RedisClientOpt,
+	store db.Store,
+	distributor TaskDistributor,
+	mailer mail.EmailSender,
+	notifier notify.Notifier,
+	links *link.Builder,
+) TaskProcessor {
 	logger := NewLogger()
 	redis.SetLogger(logger)
 
@@ -49,9 +78,12 @@ func NewRedisTaskProcessor(redisOpt asynq.RedisClientOpt, store db.Store, mailer
 	})
 
 	return &RedisTaskProcessor{
-		server: server,
-		store:  store,
-		mailer: mailer,
+		server:      server,
+		store:       store,
+		distributor: distributor,
+		mailer:      mailer,
+		notifier:    notifier,
+		links:       links,
 	}
 }
 
@@ -60,6 +92,29 @@ func (processor *RedisTaskProcessor) Start() error {
 
 	// ! タスクの処理を登録する
 	mux.HandleFunc(TaskSendVerifyEmail, processor.ProcessTaskSendVerifyEmail)
+	mux.HandleFunc(TaskReconcileLedger, processor.ProcessTaskReconcileLedger)
+	mux.HandleFunc(TaskAccrueInterest, processor.ProcessTaskAccrueInterest)
+	mux.HandleFunc(TaskSendBeneficiaryEmail, processor.ProcessTaskSendBeneficiaryEmail)
+	mux.HandleFunc(TaskSendPaymentRequestEmail, processor.ProcessTaskSendPaymentRequestEmail)
+	mux.HandleFunc(TaskExpirePaymentRequests, processor.ProcessTaskExpirePaymentRequests)
+	mux.HandleFunc(TaskExpirePendingOperations, processor.ProcessTaskExpirePendingOperations)
+	mux.HandleFunc(TaskNotifyTransfer, processor.ProcessTaskNotifyTransfer)
+	mux.HandleFunc(TaskNotifyLogin, processor.ProcessTaskNotifyLogin)
+	mux.HandleFunc(TaskEvaluateAlerts, processor.ProcessTaskEvaluateAlerts)
+	mux.HandleFunc(TaskResendEmail, processor.ProcessTaskResendEmail)
+	mux.HandleFunc(TaskSendEmailChangedEmail, processor.ProcessTaskSendEmailChangedEmail)
+	mux.HandleFunc(TaskSendPasswordChangedEmail, processor.ProcessTaskSendPasswordChangedEmail)
+	mux.HandleFunc(TaskExportUserData, processor.ProcessTaskExportUserData)
 
 	return processor.server.Start(mux)
 }
+
+// sendError はメールや通知を送れなかったことを返す
+// SMTP サーバーに宛先を断られた場合などは再試行しても届かないので、再試行しない
+func sendError(action string, err error) error {
+	if mail.IsPermanentError(err) {
+		return fmt.Errorf("failed to %s: %v: %w", action, err, asynq.SkipRetry)
+	}
+
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
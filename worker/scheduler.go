@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+// ! 定期的に実行するタスクをRedisのキューに追加する
+type TaskScheduler interface {
+	Start() error
+}
+
+type RedisTaskScheduler struct {
+	scheduler *asynq.Scheduler
+}
+
+func NewRedisTaskScheduler(redisOpt asynq.RedisClientOpt, config util.Config) (TaskScheduler, error) {
+	scheduler := asynq.NewScheduler(redisOpt, &asynq.SchedulerOpts{
+		Logger: NewLogger(),
+		EnqueueErrorHandler: func(task *asynq.Task, opts []asynq.Option, err error) {
+			log.Error().
+				Err(err).
+				Str("type", task.Type()).
+				Msg("enqueue scheduled task error")
+		},
+	})
+
+	reconcileTask, err := NewTaskReconcileLedger(&PayloadReconcileLedger{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	accrueTask, err := NewTaskAccrueInterest(&PayloadAccrueInterest{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	expireTask, err := NewTaskExpirePaymentRequests(&PayloadExpirePaymentRequests{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	expireOperationsTask, err := NewTaskExpirePendingOperations(&PayloadExpirePendingOperations{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []struct {
+		cronspec string
+		task     *asynq.Task
+	}{
+		{cronspec: config.ReconciliationSchedule, task: reconcileTask},
+		{cronspec: config.InterestAccrualSchedule, task: accrueTask},
+		{cronspec: config.PaymentRequestSchedule, task: expireTask},
+		{cronspec: config.PendingOperationSchedule, task: expireOperationsTask},
+	}
+
+	for _, schedule := range schedules {
+		if schedule.cronspec == "" {
+			continue
+		}
+
+		// 同じタスクを同時に実行しないように、1回の実行が終わるまで次のタスクは追加しない
+		_, err = scheduler.Register(schedule.cronspec, schedule.task,
+			asynq.Queue(QueueDefault),
+			asynq.MaxRetry(3),
+			asynq.Unique(time.Hour),
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to register %s task: %w", schedule.task.Type(), err)
+		}
+	}
+
+	return &RedisTaskScheduler{
+		scheduler: scheduler,
+	}, nil
+}
+
+func (scheduler *RedisTaskScheduler) Start() error {
+	return scheduler.scheduler.Start()
+}
@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"github.com/shouta0715/simple-bank/interest"
+)
+
+const TaskAccrueInterest = "task:accrue_interest"
+
+type PayloadAccrueInterest struct {
+	// 2006-01-02 形式。空の場合は前日分を計上する
+	AccrualDate string `json:"accrual_date"`
+}
+
+func NewTaskAccrueInterest(payload *PayloadAccrueInterest, opts ...asynq.Option) (*asynq.Task, error) {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return asynq.NewTask(TaskAccrueInterest, jsonPayload, opts...), nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskAccrueInterest(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadAccrueInterest
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	accrualDate := interest.PreviousDate(time.Now())
+
+	if payload.AccrualDate != "" {
+		accrualDate, err = time.Parse(time.DateOnly, payload.AccrualDate)
+
+		if err != nil {
+			return fmt.Errorf("failed to parse accrual date: %w", asynq.SkipRetry)
+		}
+	}
+
+	accruer := interest.NewAccruer(processor.store, interest.DefaultBatchSize, processor.evaluateAlerts(ctx))
+
+	result, err := accruer.Run(ctx, accrualDate)
+
+	if err != nil {
+		return fmt.Errorf("failed to accrue interest: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Str("accrual_date", result.AccrualDate.Format(time.DateOnly)).
+		Int("accounts_accrued", result.AccountsAccrued).
+		Msg("processed task")
+
+	return nil
+}
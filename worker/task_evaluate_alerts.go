@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+)
+
+const TaskEvaluateAlerts = "task:evaluate_alerts"
+
+type PayloadEvaluateAlerts struct {
+	TransferID int64 `json:"transfer_id"`
+	// 送金した直後の口座ごとの残高
+	Balances map[int64]int64 `json:"balances,omitempty"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskEvaluateAlerts(
+	ctx context.Context,
+	payload *PayloadEvaluateAlerts,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskEvaluateAlerts, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// evaluateAlerts は送金を記録した後に、通知のルールを評価するタスクを登録する関数を返す
+func (processor *RedisTaskProcessor) evaluateAlerts(ctx context.Context) func(result db.TransferTxResult) error {
+	return func(result db.TransferTxResult) error {
+		// トランザクションがコミットされてから処理されるように遅らせる
+		return processor.distributor.DistributeTaskEvaluateAlerts(ctx, &PayloadEvaluateAlerts{
+			TransferID: result.Transfer.ID,
+			Balances:   result.Balances(),
+		}, asynq.MaxRetry(10), asynq.ProcessIn(10*time.Second), asynq.Queue(QueueDefault))
+	}
+}
+
+// ProcessTaskEvaluateAlerts は送金した後の口座を通知のルールで評価し、条件を満たしたルールの持ち主にメールを送る
+// 届けた通知は記録するので、再試行しても同じ通知を2回送らない
+func (processor *RedisTaskProcessor) ProcessTaskEvaluateAlerts(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadEvaluateAlerts
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	result, err := processor.store.EvaluateAlertsTx(ctx, db.EvaluateAlertsTxParams{
+		TransferID: payload.TransferID,
+		Balances:   payload.Balances,
+	})
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("transfer not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to evaluate alerts: %w", err)
+	}
+
+	for _, a := range result.Alerts {
+		user, err := processor.getUser(ctx, a.Rule.Owner)
+
+		if err != nil {
+			return err
+		}
+
+		subject, content, err := mail.Render(a.Rule.Kind, user.Locale, mail.AlertData{
+			User:     user,
+			Rule:     a.Rule,
+			Event:    a.Event,
+			Account:  a.Account,
+			Transfer: result.Transfer,
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to render alert: %w", asynq.SkipRetry)
+		}
+
+		_, err = processor.mailer.SendEmail(ctx, subject, content, []string{user.Email}, nil, nil, nil)
+
+		if err != nil {
+			return sendError("send email", err)
+		}
+
+		_, err = processor.store.MarkAlertEventDelivered(ctx, a.Event.ID)
+
+		if err != nil {
+			return fmt.Errorf("failed to mark alert delivered: %w", err)
+		}
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Int("alerts", len(result.Alerts)).
+		Msg("processed task")
+
+	return nil
+}
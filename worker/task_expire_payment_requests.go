@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskExpirePaymentRequests = "task:expire_payment_requests"
+
+const defaultExpirePaymentRequestsBatchSize = 100
+
+type PayloadExpirePaymentRequests struct {
+	BatchSize int32 `json:"batch_size"`
+}
+
+func NewTaskExpirePaymentRequests(payload *PayloadExpirePaymentRequests, opts ...asynq.Option) (*asynq.Task, error) {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return asynq.NewTask(TaskExpirePaymentRequests, jsonPayload, opts...), nil
+}
+
+// ProcessTaskExpirePaymentRequests は期限が切れた支払い待ちの請求を期限切れにし、請求した人に知らせる
+func (processor *RedisTaskProcessor) ProcessTaskExpirePaymentRequests(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExpirePaymentRequests
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	batchSize := payload.BatchSize
+
+	if batchSize <= 0 {
+		batchSize = defaultExpirePaymentRequestsBatchSize
+	}
+
+	expired := 0
+
+	for {
+		requests, err := processor.store.ExpirePaymentRequests(ctx, batchSize)
+
+		if err != nil {
+			return fmt.Errorf("failed to expire payment requests: %w", err)
+		}
+
+		// 状態はもう期限切れになっているので、メールが送れなくてもタスクは失敗にしない
+		for _, request := range requests {
+			_, err := processor.sendPaymentRequestEmail(ctx, request)
+
+			if err != nil {
+				log.Error().Err(err).
+					Int64("payment_request_id", request.ID).
+					Msg("failed to send payment request email")
+			}
+		}
+
+		expired += len(requests)
+
+		if int32(len(requests)) < batchSize {
+			break
+		}
+	}
+
+	log.Info().Str("type", task.Type()).
+		Int("expired", expired).
+		Msg("processed task")
+
+	return nil
+}
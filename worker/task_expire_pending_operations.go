@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskExpirePendingOperations = "task:expire_pending_operations"
+
+const defaultExpirePendingOperationsBatchSize = 100
+
+type PayloadExpirePendingOperations struct {
+	BatchSize int32 `json:"batch_size"`
+}
+
+func NewTaskExpirePendingOperations(payload *PayloadExpirePendingOperations, opts ...asynq.Option) (*asynq.Task, error) {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return asynq.NewTask(TaskExpirePendingOperations, jsonPayload, opts...), nil
+}
+
+// ProcessTaskExpirePendingOperations は承認されないまま期限が切れた操作を期限切れにする
+func (processor *RedisTaskProcessor) ProcessTaskExpirePendingOperations(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExpirePendingOperations
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	batchSize := payload.BatchSize
+
+	if batchSize <= 0 {
+		batchSize = defaultExpirePendingOperationsBatchSize
+	}
+
+	expired := 0
+
+	for {
+		operations, err := processor.store.ExpirePendingOperations(ctx, batchSize)
+
+		if err != nil {
+			return fmt.Errorf("failed to expire pending operations: %w", err)
+		}
+
+		expired += len(operations)
+
+		if int32(len(operations)) < batchSize {
+			break
+		}
+	}
+
+	log.Info().Str("type", task.Type()).
+		Int("expired", expired).
+		Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/export"
+	"github.com/shouta0715/simple-bank/link"
+	"github.com/shouta0715/simple-bank/mail"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+const TaskExportUserData = "task:export_user_data"
+
+type PayloadExportUserData struct {
+	ExportID int64 `json:"export_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskExportUserData(
+	ctx context.Context,
+	payload *PayloadExportUserData,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskExportUserData, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskExportUserData はユーザーのデータを ZIP にまとめて保存し、ダウンロードのリンクをメールで送る
+// リンクは署名付きで、ZIP の有効期限と同じ期間だけ使える
+func (processor *RedisTaskProcessor) ProcessTaskExportUserData(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadExportUserData
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	dataExport, err := processor.store.GetDataExport(ctx, payload.ExportID)
+
+	if err != nil {
+		// ユーザーを削除した場合は書き出しも消える
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("data export not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	if dataExport.Status == util.DataExportFailed {
+		return nil
+	}
+
+	user, err := processor.store.GetUser(ctx, dataExport.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// メールの送信に失敗して再試行した場合は、作った ZIP をそのまま使う
+	if dataExport.Status == util.DataExportPending {
+		archive, err := export.BuildArchive(ctx, processor.store, user.Username)
+
+		if err != nil {
+			return processor.failDataExport(ctx, dataExport, fmt.Errorf("failed to build archive: %w", err))
+		}
+
+		dataExport, err = processor.store.CompleteDataExport(ctx, db.CompleteDataExportParams{
+			ID:      dataExport.ID,
+			Archive: archive,
+			ExpiresAt: pgtype.Timestamptz{
+				Time:  time.Now().Add(processor.links.Duration()),
+				Valid: true,
+			},
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to complete data export: %w", err)
+		}
+	}
+
+	err = processor.sendDataExportEmail(ctx, user, dataExport)
+
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", user.Email).
+		Msg("processed task")
+
+	return nil
+}
+
+// sendDataExportEmail は書き出したデータをダウンロードする署名付きのリンクを送る
+// メールを送り直すときも、記録には残っていないリンクを署名し直す
+func (processor *RedisTaskProcessor) sendDataExportEmail(ctx context.Context, user db.User, dataExport db.DataExport) error {
+	downloadURL := processor.links.SignedURL(link.DataExportPath, link.DataExportParams(dataExport.ID))
+
+	subject, content, err := mail.Render(mail.TemplateDataExport, user.Locale, mail.DataExportData{
+		User:      user,
+		URL:       downloadURL,
+		ExpiresAt: dataExport.ExpiresAt.Time,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", asynq.SkipRetry)
+	}
+
+	content.TemplateData = map[string]string{
+		mail.TemplateDataUsername:     user.Username,
+		mail.TemplateDataDataExportID: strconv.FormatInt(dataExport.ID, 10),
+	}
+	content.Secrets = []string{downloadURL}
+
+	to := []string{user.Email}
+	_, err = processor.mailer.SendEmail(ctx, subject, content, to, nil, nil, nil)
+
+	if err != nil {
+		return sendError("send email", err)
+	}
+
+	return nil
+}
+
+// failDataExport は最後の再試行でも書き出せなかった場合に、書き出しを失敗にする
+func (processor *RedisTaskProcessor) failDataExport(ctx context.Context, dataExport db.DataExport, err error) error {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+	if retried < maxRetry {
+		return err
+	}
+
+	if failErr := processor.store.FailDataExport(ctx, db.FailDataExportParams{
+		ID:    dataExport.ID,
+		Error: err.Error(),
+	}); failErr != nil {
+		return fmt.Errorf("failed to fail data export: %w", failErr)
+	}
+
+	return err
+}
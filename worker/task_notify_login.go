@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/notify"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+const TaskNotifyLogin = "task:notify_login"
+
+type PayloadNotifyLogin struct {
+	SessionID string `json:"session_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyLogin(
+	ctx context.Context,
+	payload *PayloadNotifyLogin,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskNotifyLogin, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskNotifyLogin はログインがあったことを、ログインした端末の情報と一緒にユーザーに通知する
+func (processor *RedisTaskProcessor) ProcessTaskNotifyLogin(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyLogin
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	session, err := processor.store.GetSession(ctx, payload.SessionID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("session not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	user, err := processor.getUser(ctx, session.Username)
+
+	if err != nil {
+		return err
+	}
+
+	err = processor.notifier.Notify(ctx, user, notify.Message{
+		Event:   util.NotificationNewLogin,
+		Subject: "New login to your Simple Bank account",
+		Body: fmt.Sprintf("Your account was logged in at %s from %s (%s).\nIf this was not you, please change your password immediately.",
+			session.CreatedAt.Format("2006-01-02 15:04 MST"), session.ClientIp, session.UserAgent),
+	})
+
+	if err != nil {
+		return sendError("notify", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("username", user.Username).
+		Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/notify"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+const TaskNotifyTransfer = "task:notify_transfer"
+
+type PayloadNotifyTransfer struct {
+	TransferID int64 `json:"transfer_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskNotifyTransfer(
+	ctx context.Context,
+	payload *PayloadNotifyTransfer,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskNotifyTransfer, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskNotifyTransfer は送金を受け取った口座の持ち主に通知する
+func (processor *RedisTaskProcessor) ProcessTaskNotifyTransfer(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadNotifyTransfer
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	transfer, err := processor.store.GetTransfer(ctx, payload.TransferID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("transfer not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get transfer: %w", err)
+	}
+
+	account, err := processor.store.GetAccount(ctx, transfer.ToAccountID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("account not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// 出金は銀行の現金口座への送金として記録するので、受け取った側には知らせない
+	if util.IsSystemAccountOwner(account.Owner) {
+		log.Info().
+			Str("type", task.Type()).
+			Bytes("payload", task.Payload()).
+			Msg("skipped notification to system account")
+
+		return nil
+	}
+
+	user, err := processor.getUser(ctx, account.Owner)
+
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Account %d received %s from account %d.",
+		account.ID, util.FormatMoney(transfer.Amount, account.Currency), transfer.FromAccountID)
+
+	if transfer.Memo != "" {
+		body += "\nMemo: " + transfer.Memo
+	}
+
+	err = processor.notifier.Notify(ctx, user, notify.Message{
+		Event:   util.NotificationTransferReceived,
+		Subject: "You received a transfer",
+		Body:    body,
+	})
+
+	if err != nil {
+		return sendError("notify", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("username", user.Username).
+		Msg("processed task")
+
+	return nil
+}
+
+// getUser は通知を送るユーザーを取得する。ユーザーがいない場合は再試行しない
+func (processor *RedisTaskProcessor) getUser(ctx context.Context, username string) (db.User, error) {
+	user, err := processor.store.GetUser(ctx, username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return user, fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+
+		return user, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
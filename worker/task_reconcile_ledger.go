@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	"github.com/shouta0715/simple-bank/reconcile"
+)
+
+const TaskReconcileLedger = "task:reconcile_ledger"
+
+type PayloadReconcileLedger struct {
+	BatchSize int32 `json:"batch_size"`
+}
+
+func NewTaskReconcileLedger(payload *PayloadReconcileLedger, opts ...asynq.Option) (*asynq.Task, error) {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	return asynq.NewTask(TaskReconcileLedger, jsonPayload, opts...), nil
+}
+
+func (processor *RedisTaskProcessor) ProcessTaskReconcileLedger(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadReconcileLedger
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	reconciler := reconcile.NewReconciler(processor.store, processor.mailer, payload.BatchSize)
+
+	result, err := reconciler.Run(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to reconcile ledger: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Str("run_id", result.RunID).
+		Int("discrepancies", len(result.Reports)).
+		Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+const TaskResendEmail = "task:resend_email"
+
+type PayloadResendEmail struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskResendEmail(
+	ctx context.Context,
+	payload *PayloadResendEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskResendEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskResendEmail は記録したメールを同じ件名と本文でもう一度送る
+// 確認コードや署名付きのリンクは記録に残していないので、そのメールは発行し直してテンプレートから作り直す
+// 送り直したメールは新しい Message-ID で別に記録される
+func (processor *RedisTaskProcessor) ProcessTaskResendEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadResendEmail
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	delivery, err := processor.store.GetEmailDelivery(ctx, payload.DeliveryID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("email delivery not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get email delivery: %w", err)
+	}
+
+	switch delivery.Template {
+	case mail.TemplateVerifyEmail, mail.TemplateDataExport:
+		err = processor.renderEmailAgain(ctx, delivery)
+	default:
+		err = processor.replayEmail(ctx, delivery)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("template", delivery.Template).
+		Msg("processed task")
+
+	return nil
+}
+
+// replayEmail は記録した件名と本文をそのまま送る
+func (processor *RedisTaskProcessor) replayEmail(ctx context.Context, delivery db.EmailDelivery) error {
+	content := mail.Content{
+		Template: delivery.Template,
+		HTML:     delivery.ContentHtml,
+		Text:     delivery.ContentText,
+	}
+
+	_, err := processor.mailer.SendEmail(
+		ctx,
+		delivery.Subject,
+		content,
+		mail.SplitRecipients(delivery.Recipient),
+		mail.SplitRecipients(delivery.Cc),
+		mail.SplitRecipients(delivery.Bcc),
+		nil,
+	)
+
+	if err != nil {
+		return sendError("resend email", err)
+	}
+
+	return nil
+}
+
+// renderEmailAgain は記録したテンプレートの値から、確認コードや署名付きのリンクを発行し直してメールを送る
+func (processor *RedisTaskProcessor) renderEmailAgain(ctx context.Context, delivery db.EmailDelivery) error {
+	var data map[string]string
+
+	if err := json.Unmarshal(delivery.TemplateData, &data); err != nil {
+		return fmt.Errorf("failed to unmarshal template data: %w", asynq.SkipRetry)
+	}
+
+	// テンプレートの値を記録する前に送ったメールは作り直せない
+	username := data[mail.TemplateDataUsername]
+
+	if username == "" {
+		return fmt.Errorf("email delivery has no template data: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if delivery.Template == mail.TemplateVerifyEmail {
+		return processor.sendVerifyEmail(ctx, user)
+	}
+
+	exportID, err := strconv.ParseInt(data[mail.TemplateDataDataExportID], 10, 64)
+
+	if err != nil {
+		return fmt.Errorf("invalid data export id: %w", asynq.SkipRetry)
+	}
+
+	dataExport, err := processor.store.GetDataExport(ctx, exportID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("data export not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	// 期限が切れた書き出しのリンクを送ってもダウンロードできない
+	if dataExport.Status != util.DataExportReady || time.Now().After(dataExport.ExpiresAt.Time) {
+		return fmt.Errorf("data export cannot be downloaded: %w", asynq.SkipRetry)
+	}
+
+	return processor.sendDataExportEmail(ctx, user, dataExport)
+}
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+)
+
+const TaskSendBeneficiaryEmail = "task:send_beneficiary_email"
+
+type PayloadSendBeneficiaryEmail struct {
+	BeneficiaryID int64 `json:"beneficiary_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendBeneficiaryEmail(
+	ctx context.Context,
+	payload *PayloadSendBeneficiaryEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendBeneficiaryEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskSendBeneficiaryEmail は受取人が追加されたことを口座の持ち主に知らせる
+// 乗っ取られたアカウントで受取人が追加された場合に気付けるようにする
+func (processor *RedisTaskProcessor) ProcessTaskSendBeneficiaryEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendBeneficiaryEmail
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	beneficiary, err := processor.store.GetBeneficiary(ctx, payload.BeneficiaryID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("beneficiary not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get beneficiary: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, beneficiary.Owner)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject, content, err := mail.Render(mail.TemplateBeneficiaryAdded, user.Locale, mail.BeneficiaryAddedData{
+		User:        user,
+		Beneficiary: beneficiary,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", asynq.SkipRetry)
+	}
+
+	to := []string{user.Email}
+	_, err = processor.mailer.SendEmail(ctx, subject, content, to, nil, nil, nil)
+
+	if err != nil {
+		return sendError("send email", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", user.Email).
+		Msg("processed task")
+
+	return nil
+}
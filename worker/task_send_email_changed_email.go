@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+)
+
+const TaskSendEmailChangedEmail = "task:send_email_changed_email"
+
+type PayloadSendEmailChangedEmail struct {
+	Username  string    `json:"username"`
+	OldEmail  string    `json:"old_email"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendEmailChangedEmail(
+	ctx context.Context,
+	payload *PayloadSendEmailChangedEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendEmailChangedEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskSendEmailChangedEmail はメールアドレスが変わったことを変わる前のアドレスに知らせる
+// 乗っ取られたアカウントでメールアドレスを変えられた場合に、元の持ち主が気付けるようにする
+func (processor *RedisTaskProcessor) ProcessTaskSendEmailChangedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendEmailChangedEmail
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject, content, err := mail.Render(mail.TemplateEmailChanged, user.Locale, mail.EmailChangedData{
+		User:      user,
+		OldEmail:  payload.OldEmail,
+		ChangedAt: payload.ChangedAt,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", asynq.SkipRetry)
+	}
+
+	to := []string{payload.OldEmail}
+	_, err = processor.mailer.SendEmail(ctx, subject, content, to, nil, nil, nil)
+
+	if err != nil {
+		return sendError("send email", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", payload.OldEmail).
+		Msg("processed task")
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+)
+
+const TaskSendPasswordChangedEmail = "task:send_password_changed_email"
+
+type PayloadSendPasswordChangedEmail struct {
+	Username  string    `json:"username"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPasswordChangedEmail(
+	ctx context.Context,
+	payload *PayloadSendPasswordChangedEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendPasswordChangedEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskSendPasswordChangedEmail はパスワードが変わったことをユーザーに知らせる
+// 乗っ取られたアカウントでパスワードを変えられた場合に、元の持ち主が気付けるようにする
+func (processor *RedisTaskProcessor) ProcessTaskSendPasswordChangedEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPasswordChangedEmail
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	subject, content, err := mail.Render(mail.TemplatePasswordChanged, user.Locale, mail.PasswordChangedData{
+		User:      user,
+		ChangedAt: payload.ChangedAt,
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", asynq.SkipRetry)
+	}
+
+	to := []string{user.Email}
+	_, err = processor.mailer.SendEmail(ctx, subject, content, to, nil, nil, nil)
+
+	if err != nil {
+		return sendError("send email", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", user.Email).
+		Msg("processed task")
+
+	return nil
+}
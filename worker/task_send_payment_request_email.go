@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/rs/zerolog/log"
+	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/mail"
+	"github.com/shouta0715/simple-bank/util"
+)
+
+const TaskSendPaymentRequestEmail = "task:send_payment_request_email"
+
+type PayloadSendPaymentRequestEmail struct {
+	PaymentRequestID int64 `json:"payment_request_id"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskSendPaymentRequestEmail(
+	ctx context.Context,
+	payload *PayloadSendPaymentRequestEmail,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	task := asynq.NewTask(TaskSendPaymentRequestEmail, jsonPayload, opts...)
+	info, err := distributor.client.EnqueueContext(ctx, task)
+
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	log.Info().Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("queue", info.Queue).
+		Int("max_retry", info.MaxRetry).
+		Msg("enqueued task")
+
+	return nil
+}
+
+// ProcessTaskSendPaymentRequestEmail は支払いの請求の状態が変わったことを相手に知らせる
+func (processor *RedisTaskProcessor) ProcessTaskSendPaymentRequestEmail(ctx context.Context, task *asynq.Task) error {
+	var payload PayloadSendPaymentRequestEmail
+
+	err := json.Unmarshal(task.Payload(), &payload)
+
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	request, err := processor.store.GetPaymentRequest(ctx, payload.PaymentRequestID)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return fmt.Errorf("payment request not found: %w", asynq.SkipRetry)
+		}
+
+		return fmt.Errorf("failed to get payment request: %w", err)
+	}
+
+	email, err := processor.sendPaymentRequestEmail(ctx, request)
+
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", email).
+		Msg("processed task")
+
+	return nil
+}
+
+// sendPaymentRequestEmail は請求の状態に応じて、請求した人または支払う人にメールを送る
+// 送った先のメールアドレスを返す
+func (processor *RedisTaskProcessor) sendPaymentRequestEmail(ctx context.Context, request db.PaymentRequest) (string, error) {
+	// 請求が届いたことと取り消されたことは支払う人に、それ以外は請求した人に知らせる
+	to, other := request.Requester, request.Payer
+
+	if request.Status == util.PaymentRequestPending || request.Status == util.PaymentRequestCancelled {
+		to, other = request.Payer, request.Requester
+	}
+
+	user, err := processor.store.GetUser(ctx, to)
+
+	if err != nil {
+		if errors.Is(err, db.ErrorRecordNotFound) {
+			return "", fmt.Errorf("user not found: %w", asynq.SkipRetry)
+		}
+
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	switch request.Status {
+	case util.PaymentRequestPending, util.PaymentRequestPaid, util.PaymentRequestDeclined,
+		util.PaymentRequestCancelled, util.PaymentRequestExpired:
+	default:
+		return "", fmt.Errorf("unsupported payment request status %s: %w", request.Status, asynq.SkipRetry)
+	}
+
+	subject, content, err := mail.Render(mail.TemplatePaymentRequest, user.Locale, mail.PaymentRequestData{
+		User:    user,
+		Request: request,
+		Other:   other,
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to render email: %w", asynq.SkipRetry)
+	}
+
+	_, err = processor.mailer.SendEmail(ctx, subject, content, []string{user.Email}, nil, nil, nil)
+
+	if err != nil {
+		return "", sendError("send email", err)
+	}
+
+	return user.Email, nil
+}
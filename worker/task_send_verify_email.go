@@ -9,6 +9,8 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
 	db "github.com/shouta0715/simple-bank/db/sqlc"
+	"github.com/shouta0715/simple-bank/link"
+	"github.com/shouta0715/simple-bank/mail"
 	"github.com/shouta0715/simple-bank/util"
 )
 
@@ -67,37 +69,57 @@ func (processor *RedisTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Cont
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 
+	err = processor.sendVerifyEmail(ctx, user)
+
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Bytes("payload", task.Payload()).
+		Str("email", user.Email).
+		Msg("processed task")
+
+	return nil
+}
+
+// sendVerifyEmail は新しい確認コードを発行して、確認のリンクをユーザーのメールアドレスに送る
+// メールを送り直すときも、記録には残っていない確認コードを発行し直す
+func (processor *RedisTaskProcessor) sendVerifyEmail(ctx context.Context, user db.User) error {
+	// 確認コードはハッシュだけを保存し、平文はメールのリンクにだけ載せる
+	secretCode := util.RandomString(32)
+
 	verifyEmail, err := processor.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
-		Username:   user.Username,
-		Email:      user.Email,
-		SecretCode: util.RandomString(32),
+		Username:       user.Username,
+		Email:          user.Email,
+		SecretCodeHash: util.HashSecret(secretCode),
 	})
 
 	if err != nil {
 		return fmt.Errorf("failed to create verify email: %w", err)
 	}
 
-	subject := "Welcome to Simple Bank! Verify your email"
+	verifyUrl := processor.links.SignedURL(link.VerifyEmailPath, link.VerifyEmailParams(verifyEmail.ID, secretCode))
+
+	subject, content, err := mail.Render(mail.TemplateVerifyEmail, user.Locale, mail.VerifyEmailData{
+		User: user,
+		URL:  verifyUrl,
+	})
 
-	verifyUrl := fmt.Sprintf("http://localhost:8080/v1/verify_email?email_id=%d&secret_code=%s", verifyEmail.ID, verifyEmail.SecretCode)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", asynq.SkipRetry)
+	}
 
-	content := fmt.Sprintf(`Hello %s,<br/>
-	Thank you for registering with us!<br/>
-	Please <a href="%s">click here</a> to verify your email address.<br/>
-	`, user.FullName, verifyUrl)
+	content.TemplateData = map[string]string{mail.TemplateDataUsername: user.Username}
+	content.Secrets = []string{verifyUrl}
 
 	to := []string{user.Email}
-	err = processor.mailer.SendEmail(subject, content, to, nil, nil, nil)
+	_, err = processor.mailer.SendEmail(ctx, subject, content, to, nil, nil, nil)
 
 	if err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+		return sendError("send email", err)
 	}
 
-	log.Info().
-		Str("type", task.Type()).
-		Bytes("payload", task.Payload()).
-		Str("email", user.Email).
-		Msg("processed task")
-
 	return nil
 }